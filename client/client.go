@@ -0,0 +1,247 @@
+// Package client is a Go client for the employee API described in api/openapi.json. It authenticates with an api
+// key, retries requests that are safe to repeat with exponential backoff, and returns failures as *Error.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	mrand "math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	apiKeyHeader      = "api-key"
+	idempotencyHeader = "Idempotency-Key"
+
+	defaultRetries    = 3
+	defaultBackoff    = 100 * time.Millisecond
+	defaultMaxBackoff = 5 * time.Second
+	maxErrorBody      = 1 << 20
+)
+
+// Client calls the employee API. It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	apiKey     string
+	http       *http.Client
+	retries    int
+	backoff    time.Duration
+	maxBackoff time.Duration
+	sleep      func(context.Context, time.Duration) error
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sends the requests through h instead of http.DefaultClient.
+func WithHTTPClient(h *http.Client) Option {
+	return func(c *Client) {
+		c.http = h
+	}
+}
+
+// WithRetries retries a failed request up to retries times, waiting backoff before the first retry and doubling the
+// wait, up to maxBackoff, before each of the next ones. Zero retries disables retrying.
+func WithRetries(retries int, backoff, maxBackoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.backoff = backoff
+		c.maxBackoff = maxBackoff
+	}
+}
+
+// New returns a client of the API served at baseURL, such as http://localhost:9090, that authenticates with apiKey.
+func New(baseURL, apiKey string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		apiKey:     apiKey,
+		http:       http.DefaultClient,
+		retries:    defaultRetries,
+		backoff:    defaultBackoff,
+		maxBackoff: defaultMaxBackoff,
+		sleep:      sleep,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// call is a request to the API. Successful responses, and responses with the accept status, are decoded into out
+// from the data envelope gofr wraps them in, unless raw is set.
+type call struct {
+	method string
+	path   string
+	query  url.Values
+	header http.Header
+	body   interface{}
+	out    interface{}
+	raw    bool
+	accept int
+}
+
+// do sends req, retrying it when it failed temporarily and repeating it is safe: every method but POST is idempotent,
+// and a POST is safe to repeat when it carries an Idempotency-Key.
+func (c *Client) do(ctx context.Context, req call) error {
+	var body []byte
+
+	if req.body != nil {
+		var err error
+
+		if body, err = json.Marshal(req.body); err != nil {
+			return err
+		}
+	}
+
+	retryable := req.method != http.MethodPost || req.header.Get(idempotencyHeader) != ""
+
+	for attempt := 0; ; attempt++ {
+		err := c.send(ctx, req, body)
+		if err == nil || !retryable || attempt >= c.retries || !temporary(ctx, err) {
+			return err
+		}
+
+		if err := c.sleep(ctx, c.wait(attempt, err)); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, req call, body []byte) error {
+	u := c.baseURL + req.path
+	if len(req.query) > 0 {
+		u += "?" + req.query.Encode()
+	}
+
+	r, err := http.NewRequestWithContext(ctx, req.method, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	for name, values := range req.header {
+		r.Header[name] = values
+	}
+
+	r.Header.Set(apiKeyHeader, c.apiKey)
+	r.Header.Set("Accept", "application/json")
+
+	if body != nil {
+		r.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(r)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode != req.accept {
+		return readError(resp)
+	}
+
+	if req.out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	if req.raw {
+		return json.NewDecoder(resp.Body).Decode(req.out)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(&struct {
+		Data interface{} `json:"data"`
+	}{Data: req.out})
+}
+
+// readError returns the problem details of resp, falling back on a problem typed after the status when the body is
+// not a problem details document.
+func readError(resp *http.Response) error {
+	e := &Error{Problem: newProblem(resp.StatusCode, "")}
+
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		e.RetryAfter = time.Duration(seconds) * time.Second
+	}
+
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), problemContentType) {
+		_ = json.NewDecoder(io.LimitReader(resp.Body, maxErrorBody)).Decode(&e.Problem)
+		e.Status = resp.StatusCode
+	}
+
+	return e
+}
+
+// temporary reports whether err may go away on retry: the server was rate limiting, overloaded or unreachable.
+func temporary(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var e *Error
+	if !errors.As(err, &e) {
+		var u *url.Error
+
+		return errors.As(err, &u)
+	}
+
+	switch e.Status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// wait returns how long to wait before retry attempt+1: the Retry-After the server asked for, or else the backoff
+// doubled attempt times, capped at maxBackoff and jittered down by up to half.
+func (c *Client) wait(attempt int, err error) time.Duration {
+	var e *Error
+	if errors.As(err, &e) && e.RetryAfter > 0 {
+		return e.RetryAfter
+	}
+
+	d := time.Duration(float64(c.backoff) * math.Pow(2, float64(attempt)))
+	if d > c.maxBackoff || d <= 0 {
+		d = c.maxBackoff
+	}
+
+	half := int64(d / 2)
+	if half <= 0 {
+		return d
+	}
+
+	return time.Duration(half + mrand.Int63n(half+1)) // nolint:gosec // jitter needs no cryptographic randomness
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// newIdempotencyKey returns a random key, so that retries of a POST are not applied twice.
+func newIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
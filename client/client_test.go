@@ -0,0 +1,191 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"example/model"
+)
+
+// reply is a canned response of the test server.
+type reply struct {
+	status int
+	header map[string]string
+	body   string
+}
+
+// serve answers the requests it gets with replies, in order, and records them.
+func serve(t *testing.T, replies ...reply) (*Client, *[]*http.Request, *[]time.Duration) {
+	t.Helper()
+
+	var (
+		requests []*http.Request
+		waits    []time.Duration
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		requests = append(requests, r)
+
+		resp := replies[0]
+		if len(replies) > 1 {
+			replies = replies[1:]
+		}
+
+		for k, v := range resp.header {
+			w.Header().Set(k, v)
+		}
+
+		w.WriteHeader(resp.status)
+		_, _ = w.Write([]byte(resp.body))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := New(srv.URL+"/", "secret", WithRetries(2, time.Second, 3*time.Second))
+	c.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	return c, &requests, &waits
+}
+
+func problemReply(status int, header map[string]string) reply {
+	if header == nil {
+		header = map[string]string{}
+	}
+
+	header["Content-Type"] = problemContentType
+
+	return reply{status, header, `{"type":"/problems/x","title":"X","status":0,"detail":"failed","request_id":"r1"}`}
+}
+
+func TestClient_Retries(t *testing.T) {
+	ok := reply{http.StatusOK, nil, `{"data":{"id":1,"name":"Ram"}}`}
+	unavailable := problemReply(http.StatusServiceUnavailable, nil)
+
+	testcases := []struct {
+		desc     string
+		replies  []reply
+		call     func(*Client) error
+		attempts int
+		err      error
+	}{
+		{"success", []reply{ok}, get, 1, nil},
+		{"retried until success", []reply{unavailable, ok}, get, 2, nil},
+		{"retries exhausted", []reply{unavailable}, get, 3, ErrUnavailable},
+		{"client error not retried", []reply{problemReply(http.StatusNotFound, nil)}, get, 1, ErrNotFound},
+		{"internal error not retried", []reply{problemReply(http.StatusInternalServerError, nil)}, get, 1, ErrServer},
+		{"post with idempotency key retried", []reply{unavailable, {http.StatusCreated, nil, `{"data":{"id":1}}`}},
+			func(c *Client) error {
+				_, err := c.CreateEmployee(context.Background(), model.Employee{Name: "Ram"}, "")
+				return err
+			}, 2, nil},
+		{"post without idempotency key not retried", []reply{unavailable}, func(c *Client) error {
+			_, err := c.CreateDepartment(context.Background(), model.Department{Name: "HR"})
+			return err
+		}, 1, ErrUnavailable},
+	}
+
+	for i, tc := range testcases {
+		c, requests, _ := serve(t, tc.replies...)
+
+		err := tc.call(c)
+
+		if len(*requests) != tc.attempts {
+			t.Errorf("[Test %v]Failed. Expected %v attempts but got %v", i+1, tc.attempts, len(*requests))
+		}
+
+		if tc.err == nil && err != nil || tc.err != nil && !errors.Is(err, tc.err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestClient_Backoff(t *testing.T) {
+	c, requests, waits := serve(t, problemReply(http.StatusTooManyRequests, map[string]string{"Retry-After": "7"}),
+		problemReply(http.StatusBadGateway, nil), reply{http.StatusOK, nil, `{"data":{"id":1}}`})
+
+	if err := get(c); err != nil {
+		t.Fatalf("[Test %v]Failed. Expected no error but got %v", 1, err)
+	}
+
+	if len(*requests) != 3 || len(*waits) != 2 {
+		t.Fatalf("[Test %v]Failed. Expected 3 attempts and 2 waits but got %v and %v", 1, len(*requests), *waits)
+	}
+
+	if (*waits)[0] != 7*time.Second {
+		t.Errorf("[Test %v]Failed. Expected the Retry-After wait but got %v", 2, (*waits)[0])
+	}
+
+	if w := (*waits)[1]; w < time.Second || w > 2*time.Second {
+		t.Errorf("[Test %v]Failed. Expected a jittered wait between 1s and 2s but got %v", 3, w)
+	}
+
+	for i, r := range *requests {
+		if r.Header.Get("api-key") != "secret" {
+			t.Errorf("[Test %v]Failed. Expected the api key on attempt %v but got %q", 4, i+1, r.Header.Get("api-key"))
+		}
+	}
+}
+
+func TestClient_Wait(t *testing.T) {
+	c := New("http://localhost", "", WithRetries(5, time.Second, 3*time.Second))
+
+	for i, limit := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second} {
+		w := c.wait(i, errors.New("refused"))
+
+		if w < limit/2 || w > limit {
+			t.Errorf("[Test %v]Failed. Expected a wait between %v and %v but got %v", i+1, limit/2, limit, w)
+		}
+	}
+}
+
+func TestError(t *testing.T) {
+	c, _, _ := serve(t, problemReply(http.StatusConflict, nil))
+
+	_, err := c.UpdateDepartment(context.Background(), 1, model.Department{Name: "HR"})
+
+	var e *Error
+	if !errors.As(err, &e) {
+		t.Fatalf("[Test %v]Failed. Expected an *Error but got %v", 1, err)
+	}
+
+	expected := Problem{Type: "/problems/x", Title: "X", Status: http.StatusConflict, Detail: "failed", RequestID: "r1"}
+	if !reflect.DeepEqual(expected, e.Problem) {
+		t.Errorf("[Test %v]Failed. Expected %v but got %v", 2, expected, e.Problem)
+	}
+
+	if !errors.Is(err, ErrConflict) || errors.Is(err, ErrNotFound) || errors.Is(err, ErrServer) {
+		t.Errorf("[Test %v]Failed. Expected only ErrConflict to match %v", 3, err)
+	}
+
+	if e.Error() != "409 X: failed" {
+		t.Errorf("[Test %v]Failed. Expected %q but got %q", 4, "409 X: failed", e.Error())
+	}
+}
+
+func TestError_NotProblem(t *testing.T) {
+	c, _, _ := serve(t, reply{http.StatusUnauthorized, nil, "unauthorized"})
+
+	_, err := c.GetEmployee(context.Background(), 1)
+
+	var e *Error
+	if !errors.As(err, &e) || !errors.Is(err, ErrUnauthorized) ||
+		!reflect.DeepEqual(e.Problem, newProblem(http.StatusUnauthorized, "")) {
+		t.Errorf("[Test %v]Failed. Expected an unauthorized problem but got %v", 1, err)
+	}
+}
+
+func get(c *Client) error {
+	_, err := c.GetEmployee(context.Background(), 1)
+	return err
+}
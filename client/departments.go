@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"example/model"
+)
+
+// ListDepartments returns every department.
+func (c *Client) ListDepartments(ctx context.Context) ([]model.Department, error) {
+	var resp []model.Department
+
+	err := c.do(ctx, call{method: http.MethodGet, path: "/departments", out: &resp})
+
+	return resp, err
+}
+
+// GetDepartment returns the department with the given id.
+func (c *Client) GetDepartment(ctx context.Context, id int) (model.Department, error) {
+	var resp model.Department
+
+	err := c.do(ctx, call{method: http.MethodGet, path: departmentPath(id), out: &resp})
+
+	return resp, err
+}
+
+// CreateDepartment creates department. It is not retried, since the API does not deduplicate department creation.
+func (c *Client) CreateDepartment(ctx context.Context, department model.Department) (model.Department, error) {
+	var resp model.Department
+
+	err := c.do(ctx, call{method: http.MethodPost, path: "/departments", body: department, out: &resp})
+
+	return resp, err
+}
+
+// UpdateDepartment renames the department with the given id.
+func (c *Client) UpdateDepartment(ctx context.Context, id int, department model.Department) (model.Department, error) {
+	var resp model.Department
+
+	err := c.do(ctx, call{method: http.MethodPut, path: departmentPath(id), body: department, out: &resp})
+
+	return resp, err
+}
+
+// DeleteDepartment deletes the department with the given id. It fails with ErrConflict while employees belong to it.
+func (c *Client) DeleteDepartment(ctx context.Context, id int) error {
+	return c.do(ctx, call{method: http.MethodDelete, path: departmentPath(id)})
+}
+
+// DepartmentEmployees returns the employees of the department with the given id.
+func (c *Client) DepartmentEmployees(ctx context.Context, id int) ([]model.Employee, error) {
+	var resp []model.Employee
+
+	err := c.do(ctx, call{method: http.MethodGet, path: departmentPath(id) + "/employees", out: &resp})
+
+	return resp, err
+}
+
+func departmentPath(id int) string {
+	return "/departments/" + strconv.Itoa(id)
+}
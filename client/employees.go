@@ -0,0 +1,213 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"example/model"
+)
+
+// DefaultPageSize is the number of employees EmployeePages fetches at a time when the filter sets no Limit.
+const DefaultPageSize = 100
+
+// employeesPath is the root of the employee routes of version 2 of the API, which the client speaks. The client
+// converts between model.EmployeeV2 and model.Employee, so callers keep working with the latter.
+const employeesPath = "/v2/emp"
+
+// ListEmployees returns the employees matching filter, ordered by id. Set filter.Limit and filter.AfterID to fetch a
+// single page, or use EmployeePages to walk through every page.
+func (c *Client) ListEmployees(ctx context.Context, filter model.Filter) ([]model.Employee, error) {
+	var resp []model.EmployeeV2
+
+	err := c.do(ctx, call{method: http.MethodGet, path: employeesPath, query: query(filter), out: &resp})
+
+	return employees(resp), err
+}
+
+// GetEmployee returns the employee with the given id.
+func (c *Client) GetEmployee(ctx context.Context, id int) (model.Employee, error) {
+	var resp model.EmployeeV2
+
+	err := c.do(ctx, call{method: http.MethodGet, path: employeePath(id), out: &resp})
+
+	return employee(resp), err
+}
+
+// CreateEmployee creates e under idempotencyKey, so that retrying the request with the same key creates it at
+// most once. A random key is used when idempotencyKey is empty, which still makes the client's own retries safe.
+func (c *Client) CreateEmployee(ctx context.Context, e model.Employee, idempotencyKey string) (model.Employee, error) {
+	if idempotencyKey == "" {
+		var err error
+
+		if idempotencyKey, err = newIdempotencyKey(); err != nil {
+			return model.Employee{}, err
+		}
+	}
+
+	var resp model.EmployeeV2
+
+	err := c.do(ctx, call{method: http.MethodPost, path: employeesPath,
+		header: http.Header{idempotencyHeader: {idempotencyKey}}, body: model.NewEmployeeV2(e), out: &resp})
+
+	return employee(resp), err
+}
+
+// UpdateEmployee replaces the employee with the given id by e.
+func (c *Client) UpdateEmployee(ctx context.Context, id int, e model.Employee) (model.Employee, error) {
+	var resp model.EmployeeV2
+
+	err := c.do(ctx, call{method: http.MethodPut, path: employeePath(id), body: model.NewEmployeeV2(e), out: &resp})
+
+	return employee(resp), err
+}
+
+// Reports returns the direct reports of the employee with the given id.
+func (c *Client) Reports(ctx context.Context, id int) ([]model.Employee, error) {
+	return c.related(ctx, employeePath(id)+"/reports")
+}
+
+// Subtree returns every employee reporting to the employee with the given id, directly or not.
+func (c *Client) Subtree(ctx context.Context, id int) ([]model.Employee, error) {
+	return c.related(ctx, employeePath(id)+"/subtree")
+}
+
+// Chain returns the managers of the employee with the given id, from the direct manager up.
+func (c *Client) Chain(ctx context.Context, id int) ([]model.Employee, error) {
+	return c.related(ctx, employeePath(id)+"/chain")
+}
+
+func (c *Client) related(ctx context.Context, path string) ([]model.Employee, error) {
+	var resp []model.EmployeeV2
+
+	err := c.do(ctx, call{method: http.MethodGet, path: path, out: &resp})
+
+	return employees(resp), err
+}
+
+// EmployeePager walks through the employees matching a filter one page at a time:
+//
+//	p := c.EmployeePages(model.Filter{Status: model.StatusActive})
+//	for p.Next(ctx) {
+//		for _, e := range p.Page() { ... }
+//	}
+//	if err := p.Err(); err != nil { ... }
+type EmployeePager struct {
+	client *Client
+	filter model.Filter
+	page   []model.Employee
+	done   bool
+	err    error
+}
+
+// EmployeePages returns a pager over the employees matching filter, starting after filter.AfterID and fetching
+// filter.Limit employees, or DefaultPageSize when it is zero, at a time.
+func (c *Client) EmployeePages(filter model.Filter) *EmployeePager {
+	if filter.Limit == 0 {
+		filter.Limit = DefaultPageSize
+	}
+
+	return &EmployeePager{client: c, filter: filter}
+}
+
+// Next fetches the next page. It returns false once every page was fetched or a request failed.
+func (p *EmployeePager) Next(ctx context.Context) bool {
+	if p.done {
+		return false
+	}
+
+	p.page, p.err = p.client.ListEmployees(ctx, p.filter)
+	if p.err != nil || len(p.page) == 0 {
+		p.done = true
+
+		return false
+	}
+
+	if len(p.page) < p.filter.Limit {
+		p.done = true
+	}
+
+	last := p.page[len(p.page)-1].ID
+	p.filter.AfterID = &last
+
+	return true
+}
+
+// Page returns the page fetched by the last call to Next.
+func (p *EmployeePager) Page() []model.Employee {
+	return p.page
+}
+
+// Err returns the error that stopped the pager, if any.
+func (p *EmployeePager) Err() error {
+	return p.err
+}
+
+// AllEmployees fetches every page of the employees matching filter and returns them together.
+func (c *Client) AllEmployees(ctx context.Context, filter model.Filter) ([]model.Employee, error) {
+	var all []model.Employee
+
+	p := c.EmployeePages(filter)
+	for p.Next(ctx) {
+		all = append(all, p.Page()...)
+	}
+
+	return all, p.Err()
+}
+
+func employeePath(id int) string {
+	return employeesPath + "/" + strconv.Itoa(id)
+}
+
+// employee returns the employee e represents, keeping the derived age and the audit fields, which
+// model.EmployeeV2.Employee leaves out since the server never takes them from clients.
+func employee(e model.EmployeeV2) model.Employee {
+	emp := e.Employee()
+	emp.Age = e.Age
+	emp.CreatedAt, emp.CreatedBy = e.Audit.CreatedAt, e.Audit.CreatedBy
+	emp.UpdatedAt, emp.UpdatedBy = e.Audit.UpdatedAt, e.Audit.UpdatedBy
+
+	return emp
+}
+
+func employees(e []model.EmployeeV2) []model.Employee {
+	if e == nil {
+		return nil
+	}
+
+	resp := make([]model.Employee, len(e))
+	for i := range e {
+		resp[i] = employee(e[i])
+	}
+
+	return resp
+}
+
+func query(filter model.Filter) url.Values {
+	q := url.Values{}
+
+	set := func(name string, id *int) {
+		if id != nil {
+			q.Set(name, strconv.Itoa(*id))
+		}
+	}
+
+	if filter.Title != "" {
+		q.Set("title", filter.Title)
+	}
+
+	if filter.Status != "" {
+		q.Set("status", string(filter.Status))
+	}
+
+	set("department_id", filter.DepartmentID)
+	set("manager_id", filter.ManagerID)
+	set("after_id", filter.AfterID)
+
+	if filter.Limit > 0 {
+		q.Set("limit", strconv.Itoa(filter.Limit))
+	}
+
+	return q
+}
@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"example/model"
+)
+
+func TestClient_Employees(t *testing.T) {
+	one := 1
+	list := reply{http.StatusOK, nil, `{"data":[{"id":2,"name":"Sai"}]}`}
+	single := reply{http.StatusOK, nil, `{"data":{"id":2,"name":"Sai"}}`}
+	employees := []model.Employee{{ID: 2, Name: "Sai"}}
+
+	testcases := []struct {
+		desc   string
+		reply  reply
+		call   func(*Client) (interface{}, error)
+		method string
+		uri    string
+		body   string
+		output interface{}
+	}{
+		{"list", list, func(c *Client) (interface{}, error) {
+			return c.ListEmployees(context.Background(), model.Filter{Title: "Engineer", Status: model.StatusActive,
+				DepartmentID: &one, ManagerID: &one, AfterID: &one, Limit: 10})
+		}, http.MethodGet, "/v2/emp?after_id=1&department_id=1&limit=10&manager_id=1&status=active&title=Engineer", "",
+			employees},
+		{"get", single, func(c *Client) (interface{}, error) {
+			return c.GetEmployee(context.Background(), 2)
+		}, http.MethodGet, "/v2/emp/2", "", employees[0]},
+		{"create", single, func(c *Client) (interface{}, error) {
+			return c.CreateEmployee(context.Background(), model.Employee{Name: "Sai"}, "key-1")
+		}, http.MethodPost, "/v2/emp", `"name":"Sai"`, employees[0]},
+		{"update", single, func(c *Client) (interface{}, error) {
+			return c.UpdateEmployee(context.Background(), 2, model.Employee{Name: "Sai"})
+		}, http.MethodPut, "/v2/emp/2", `"name":"Sai"`, employees[0]},
+		{"reports", list, func(c *Client) (interface{}, error) {
+			return c.Reports(context.Background(), 1)
+		}, http.MethodGet, "/v2/emp/1/reports", "", employees},
+		{"subtree", list, func(c *Client) (interface{}, error) {
+			return c.Subtree(context.Background(), 1)
+		}, http.MethodGet, "/v2/emp/1/subtree", "", employees},
+		{"chain", list, func(c *Client) (interface{}, error) {
+			return c.Chain(context.Background(), 1)
+		}, http.MethodGet, "/v2/emp/1/chain", "", employees},
+		{"department employees", list, func(c *Client) (interface{}, error) {
+			return c.DepartmentEmployees(context.Background(), 1)
+		}, http.MethodGet, "/departments/1/employees", "", employees},
+	}
+
+	for i, tc := range testcases {
+		c, requests, _ := serve(t, tc.reply)
+
+		resp, err := tc.call(c)
+		if err != nil {
+			t.Errorf("[Test %v]Failed. Expected no error but got %v", i+1, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, resp)
+		}
+
+		r := (*requests)[0]
+		if r.Method != tc.method || r.URL.RequestURI() != tc.uri {
+			t.Errorf("[Test %v]Failed. Expected %v %v but got %v %v", i+1, tc.method, tc.uri, r.Method, r.URL.RequestURI())
+		}
+
+		if body, _ := io.ReadAll(r.Body); tc.body != "" && !strings.Contains(string(body), tc.body) {
+			t.Errorf("[Test %v]Failed. Expected a body with %v but got %s", i+1, tc.body, body)
+		}
+	}
+}
+
+func TestClient_EmployeeV2(t *testing.T) {
+	c, requests, _ := serve(t, reply{http.StatusOK, nil, `{"data":{"id":2,"name":"Sai","age":30,"department":{"id":1},` +
+		`"manager":{"id":3},"audit":{"created_by":"ram","updated_by":"sai"}}}`})
+
+	one := 1
+	resp, err := c.UpdateEmployee(context.Background(), 2, model.Employee{Name: "Sai", DepartmentID: &one})
+	if err != nil {
+		t.Fatalf("[Test %v]Failed. Expected no error but got %v", 1, err)
+	}
+
+	three := 3
+	expected := model.Employee{ID: 2, Name: "Sai", Age: 30, DepartmentID: &one, ManagerID: &three, CreatedBy: "ram",
+		UpdatedBy: "sai"}
+	if !reflect.DeepEqual(expected, resp) {
+		t.Errorf("[Test %v]Failed. Expected %v but got %v", 2, expected, resp)
+	}
+
+	if body, _ := io.ReadAll((*requests)[0].Body); !strings.Contains(string(body), `"department":{"id":1}`) {
+		t.Errorf("[Test %v]Failed. Expected the version 2 representation but got %s", 3, body)
+	}
+}
+
+func TestClient_CreateEmployeeIdempotencyKey(t *testing.T) {
+	c, requests, _ := serve(t, reply{http.StatusCreated, nil, `{"data":{"id":1}}`})
+
+	for i, key := range []string{"key-1", ""} {
+		if _, err := c.CreateEmployee(context.Background(), model.Employee{Name: "Ram"}, key); err != nil {
+			t.Errorf("[Test %v]Failed. Expected no error but got %v", i+1, err)
+		}
+	}
+
+	if got := (*requests)[0].Header.Get("Idempotency-Key"); got != "key-1" {
+		t.Errorf("[Test %v]Failed. Expected %v but got %v", 1, "key-1", got)
+	}
+
+	if got := (*requests)[1].Header.Get("Idempotency-Key"); len(got) != 32 {
+		t.Errorf("[Test %v]Failed. Expected a generated key but got %q", 2, got)
+	}
+}
+
+func TestEmployeePager(t *testing.T) {
+	c, requests, _ := serve(t,
+		reply{http.StatusOK, nil, `{"data":[{"id":1},{"id":3}]}`},
+		reply{http.StatusOK, nil, `{"data":[{"id":4},{"id":7}]}`},
+		reply{http.StatusOK, nil, `{"data":[{"id":9}]}`})
+
+	all, err := c.AllEmployees(context.Background(), model.Filter{Status: model.StatusActive, Limit: 2})
+	if err != nil {
+		t.Fatalf("[Test %v]Failed. Expected no error but got %v", 1, err)
+	}
+
+	expected := []model.Employee{{ID: 1}, {ID: 3}, {ID: 4}, {ID: 7}, {ID: 9}}
+	if !reflect.DeepEqual(expected, all) {
+		t.Errorf("[Test %v]Failed. Expected %v but got %v", 2, expected, all)
+	}
+
+	uris := []string{"/v2/emp?limit=2&status=active", "/v2/emp?after_id=3&limit=2&status=active",
+		"/v2/emp?after_id=7&limit=2&status=active"}
+	for i, r := range *requests {
+		if r.URL.RequestURI() != uris[i] {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+3, uris[i], r.URL.RequestURI())
+		}
+	}
+}
+
+func TestEmployeePager_Stops(t *testing.T) {
+	testcases := []struct {
+		desc     string
+		reply    reply
+		pages    int
+		requests int
+		err      error
+	}{
+		{"empty last page", reply{http.StatusOK, nil, `{"data":[]}`}, 0, 1, nil},
+		{"failure", problemReply(http.StatusBadRequest, nil), 0, 1, ErrInvalid},
+	}
+
+	for i, tc := range testcases {
+		c, requests, _ := serve(t, tc.reply)
+		p := c.EmployeePages(model.Filter{})
+
+		pages := 0
+		for p.Next(context.Background()) {
+			pages++
+		}
+
+		if pages != tc.pages || len(*requests) != tc.requests || p.Next(context.Background()) {
+			t.Errorf("[Test %v]Failed. Expected %v pages in %v requests but got %v in %v", i+1, tc.pages, tc.requests,
+				pages, len(*requests))
+		}
+
+		if (tc.err == nil) != (p.Err() == nil) || tc.err != nil && !errors.Is(p.Err(), tc.err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, p.Err())
+		}
+
+		if (*requests)[0].URL.Query().Get("limit") != "100" {
+			t.Errorf("[Test %v]Failed. Expected the default page size but got %v", i+1, (*requests)[0].URL.RawQuery)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// problemContentType is the media type of the problem details the server describes failures with.
+const problemContentType = "application/problem+json"
+
+// Kinds of errors the server answers with. Test for them with errors.Is, and use errors.As with *Error for the details.
+var (
+	ErrInvalid       = errors.New("invalid request")
+	ErrUnauthorized  = errors.New("unauthorized")
+	ErrNotFound      = errors.New("not found")
+	ErrConflict      = errors.New("conflict")
+	ErrTooLarge      = errors.New("payload too large")
+	ErrUnprocessable = errors.New("unprocessable request")
+	ErrRateLimited   = errors.New("rate limited")
+	ErrServer        = errors.New("server error")
+	ErrUnavailable   = errors.New("service unavailable")
+)
+
+var kinds = map[int]error{
+	http.StatusBadRequest:            ErrInvalid,
+	http.StatusUnauthorized:          ErrUnauthorized,
+	http.StatusNotFound:              ErrNotFound,
+	http.StatusConflict:              ErrConflict,
+	http.StatusRequestEntityTooLarge: ErrTooLarge,
+	http.StatusUnprocessableEntity:   ErrUnprocessable,
+	http.StatusTooManyRequests:       ErrRateLimited,
+	http.StatusServiceUnavailable:    ErrUnavailable,
+}
+
+// Problem is the RFC 7807 problem details document the server describes a failure with. InvalidParams names the
+// request parameters or fields that were rejected.
+type Problem struct {
+	Type          string   `json:"type"`
+	Title         string   `json:"title"`
+	Status        int      `json:"status"`
+	Detail        string   `json:"detail,omitempty"`
+	Instance      string   `json:"instance,omitempty"`
+	RequestID     string   `json:"request_id,omitempty"`
+	InvalidParams []string `json:"invalid_params,omitempty"`
+}
+
+// newProblem returns the problem the server would send for status, for failures that came without problem details.
+func newProblem(status int, detail string) Problem {
+	title := http.StatusText(status)
+
+	return Problem{
+		Type:   "/problems/" + strings.ReplaceAll(strings.ToLower(title), " ", "-"),
+		Title:  title,
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// Error is a failed request: the problem details the server sent, and how long it asked the client to wait before
+// retrying, if it did.
+type Error struct {
+	Problem
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	if e.Detail == "" {
+		return fmt.Sprintf("%d %s", e.Status, e.Title)
+	}
+
+	return fmt.Sprintf("%d %s: %s", e.Status, e.Title, e.Detail)
+}
+
+// Is reports whether e is of the kind target, such as ErrNotFound for a 404. Every 5xx is an ErrServer.
+func (e *Error) Is(target error) bool {
+	if target == ErrServer {
+		return e.Status >= http.StatusInternalServerError
+	}
+
+	return kinds[e.Status] == target
+}
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"example/model"
+)
+
+// Live reports whether the service process is serving requests.
+func (c *Client) Live(ctx context.Context) (model.Health, error) {
+	var resp model.Health
+
+	err := c.do(ctx, call{method: http.MethodGet, path: "/health/live", out: &resp, raw: true})
+
+	return resp, err
+}
+
+// Ready returns the readiness of the service and of its dependencies. When the service is not ready, Ready returns
+// the failing dependencies along with an *Error of kind ErrUnavailable, without retrying.
+func (c *Client) Ready(ctx context.Context) (model.Health, error) {
+	var resp model.Health
+
+	err := c.do(ctx, call{method: http.MethodGet, path: "/health/ready", out: &resp, raw: true,
+		accept: http.StatusServiceUnavailable})
+	if err == nil && resp.Status != model.HealthUp {
+		err = &Error{Problem: newProblem(http.StatusServiceUnavailable, "service is "+resp.Status)}
+	}
+
+	return resp, err
+}
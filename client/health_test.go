@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"example/model"
+)
+
+func TestClient_Health(t *testing.T) {
+	testcases := []struct {
+		desc     string
+		reply    reply
+		ready    bool
+		output   model.Health
+		err      error
+		requests int
+	}{
+		{"live", reply{http.StatusOK, nil, `{"status":"up"}`}, false, model.Health{Status: model.HealthUp}, nil, 1},
+		{"ready", reply{http.StatusOK, nil, `{"status":"up"}`}, true, model.Health{Status: model.HealthUp}, nil, 1},
+		{"not ready", reply{http.StatusServiceUnavailable, nil,
+			`{"status":"down","dependencies":{"database":{"status":"down","latency_ms":2,"error":"refused"}}}`}, true,
+			model.Health{Status: model.HealthDown, Dependencies: map[string]model.DependencyHealth{
+				"database": {Status: model.HealthDown, LatencyMS: 2, Error: "refused"}}}, ErrUnavailable, 1},
+	}
+
+	for i, tc := range testcases {
+		c, requests, _ := serve(t, tc.reply)
+
+		check := c.Live
+		if tc.ready {
+			check = c.Ready
+		}
+
+		resp, err := check(context.Background())
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, resp)
+		}
+
+		if (tc.err == nil) != (err == nil) || tc.err != nil && !errors.Is(err, tc.err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+
+		if len(*requests) != tc.requests {
+			t.Errorf("[Test %v]Failed. Expected %v requests but got %v", i+1, tc.requests, len(*requests))
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/migrations"
+)
+
+func main() {
+	app := gofr.New()
+
+	applied, err := migrations.Run(app.DB().DB, migrations.All())
+
+	for _, m := range applied {
+		app.Logger.Infof("applied migration %d %s", m.Version, m.Name)
+	}
+
+	if err != nil {
+		app.Logger.Fatalf("migration failed: %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strings"
+
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/datastore/department"
+	"example/datastore/employee"
+	"example/model"
+	"example/seed"
+)
+
+func main() {
+	deptFiles := flag.String("departments", "db/departments.yaml", "comma separated department fixture files to load")
+	files := flag.String("files", "db/employees.yaml,db/employees.json", "comma separated fixture files to load")
+	count := flag.Int("count", 0, "number of fake employees to generate")
+	seedValue := flag.Int64("seed", 1, "seed for generated employees")
+	firstID := flag.Int("first-id", 1000, "id of the first generated employee")
+	flag.Parse()
+
+	app := gofr.New()
+	ctx := gofr.NewContext(nil, nil, app)
+	ctx.Context = context.Background()
+
+	var (
+		dept []model.Department
+		emp  []model.Employee
+	)
+
+	for _, f := range split(*deptFiles) {
+		var fixtures []model.Department
+		if err := seed.Load(f, &fixtures); err != nil {
+			app.Logger.Fatalf("%v", err)
+		}
+
+		dept = append(dept, fixtures...)
+	}
+
+	for _, f := range split(*files) {
+		var fixtures []model.Employee
+		if err := seed.Load(f, &fixtures); err != nil {
+			app.Logger.Fatalf("%v", err)
+		}
+
+		emp = append(emp, fixtures...)
+	}
+
+	departmentIDs := make([]int, 0, len(dept))
+	for i := range dept {
+		departmentIDs = append(departmentIDs, dept[i].ID)
+	}
+
+	emp = append(emp, seed.Generate(*count, *seedValue, *firstID, departmentIDs)...)
+
+	s := seed.New(employee.New(), department.New())
+
+	if err := s.SeedDepartments(ctx, dept); err != nil {
+		app.Logger.Fatalf("seeding departments failed: %v", err)
+	}
+
+	if err := s.Seed(ctx, emp); err != nil {
+		app.Logger.Fatalf("seeding failed: %v", err)
+	}
+
+	app.Logger.Infof("seeded %d departments and %d employees", len(dept), len(emp))
+}
+
+func split(files string) []string {
+	var paths []string
+
+	for _, f := range strings.Split(files, ",") {
+		if f != "" {
+			paths = append(paths, f)
+		}
+	}
+
+	return paths
+}
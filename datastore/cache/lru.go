@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+)
+
+type entry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+type lru struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	items    map[string]*list.Element
+}
+
+// NewLRU returns an in-process cache holding at most capacity entries, evicting the least recently used first.
+func NewLRU(capacity int) *lru {
+	return &lru{capacity: capacity, order: list.New(), items: make(map[string]*list.Element)}
+}
+
+func (l *lru) Get(_ *gofr.Context, key string) ([]byte, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	el, ok := l.live(key)
+	if !ok {
+		return nil, false
+	}
+
+	l.order.MoveToFront(el)
+
+	return el.Value.(*entry).value, true
+}
+
+func (l *lru) Set(_ *gofr.Context, key string, value []byte, ttl time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.set(key, value, ttl)
+
+	return nil
+}
+
+func (l *lru) Add(_ *gofr.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.live(key); ok {
+		return false, nil
+	}
+
+	l.set(key, value, ttl)
+
+	return true, nil
+}
+
+// live returns the element of key unless it is missing or expired, removing it when it expired.
+func (l *lru) live(key string) (*list.Element, bool) {
+	el, ok := l.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	if e := el.Value.(*entry); !e.expires.IsZero() && time.Now().After(e.expires) {
+		l.order.Remove(el)
+		delete(l.items, key)
+
+		return nil, false
+	}
+
+	return el, true
+}
+
+func (l *lru) set(key string, value []byte, ttl time.Duration) {
+	var expires time.Time
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+
+	if el, ok := l.items[key]; ok {
+		el.Value = &entry{key: key, value: value, expires: expires}
+		l.order.MoveToFront(el)
+
+		return
+	}
+
+	l.items[key] = l.order.PushFront(&entry{key: key, value: value, expires: expires})
+
+	if l.order.Len() > l.capacity {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.items, oldest.Value.(*entry).key)
+	}
+}
+
+func (l *lru) Delete(_ *gofr.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if el, ok := l.items[key]; ok {
+		l.order.Remove(el)
+		delete(l.items, key)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLRU(t *testing.T) {
+	l := NewLRU(2)
+
+	_ = l.Set(nil, "a", []byte("1"), 0)
+	_ = l.Set(nil, "b", []byte("2"), 0)
+	_, _ = l.Get(nil, "a")
+	_ = l.Set(nil, "c", []byte("3"), 0)
+	_ = l.Set(nil, "d", []byte("4"), time.Nanosecond)
+
+	time.Sleep(time.Millisecond)
+
+	testcases := []struct {
+		desc   string
+		key    string
+		output []byte
+		found  bool
+	}{
+		{"evicted least recently used", "b", nil, false},
+		{"evicted after d was added", "a", nil, false},
+		{"present", "c", []byte("3"), true},
+		{"expired", "d", nil, false},
+	}
+
+	for i, tc := range testcases {
+		resp, ok := l.Get(nil, tc.key)
+
+		if !reflect.DeepEqual(tc.output, resp) || tc.found != ok {
+			t.Errorf("[Test %v]Failed. Expected %s %v but got %s %v", i+1, tc.output, tc.found, resp, ok)
+		}
+	}
+
+	_ = l.Delete(nil, "c")
+
+	if _, ok := l.Get(nil, "c"); ok {
+		t.Errorf("Failed. Expected deleted key to be missing")
+	}
+}
+
+func TestLRU_Add(t *testing.T) {
+	l := NewLRU(2)
+
+	_ = l.Set(nil, "a", []byte("1"), 0)
+	_ = l.Set(nil, "b", []byte("2"), time.Nanosecond)
+
+	time.Sleep(time.Millisecond)
+
+	testcases := []struct {
+		desc   string
+		key    string
+		added  bool
+		output []byte
+	}{
+		{"present", "a", false, []byte("1")},
+		{"expired", "b", true, []byte("new")},
+		{"missing", "c", true, []byte("new")},
+	}
+
+	for i, tc := range testcases {
+		added, err := l.Add(nil, tc.key, []byte("new"), 0)
+		resp, _ := l.Get(nil, tc.key)
+
+		if added != tc.added || err != nil || !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed. Expected %v %s but got %v %s %v", i+1, tc.added, tc.output, added, resp, err)
+		}
+	}
+}
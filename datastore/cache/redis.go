@@ -0,0 +1,35 @@
+package cache
+
+import (
+	"time"
+
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+)
+
+type redis struct{}
+
+// NewRedis returns a cache backed by the redis client configured on the gofr application.
+func NewRedis() redis {
+	return redis{}
+}
+
+func (r redis) Get(ctx *gofr.Context, key string) ([]byte, bool) {
+	value, err := ctx.Redis.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil, false
+	}
+
+	return value, true
+}
+
+func (r redis) Set(ctx *gofr.Context, key string, value []byte, ttl time.Duration) error {
+	return ctx.Redis.Set(ctx, key, value, ttl).Err()
+}
+
+func (r redis) Add(ctx *gofr.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	return ctx.Redis.SetNX(ctx, key, value, ttl).Result()
+}
+
+func (r redis) Delete(ctx *gofr.Context, key string) error {
+	return ctx.Redis.Del(ctx, key).Err()
+}
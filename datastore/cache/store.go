@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/datastore"
+	"example/model"
+)
+
+// tombstone is cached in place of an employee being written, and is never valid JSON.
+// nolint:gochecknoglobals // compared against, never modified
+var tombstone = []byte("invalidated")
+
+// tombstoneTTL is how long a tombstone keeps reads that started before the write from caching what they read.
+const tombstoneTTL = 30 * time.Second
+
+type store struct {
+	store datastore.EmpStore
+	cache datastore.Cache
+	ttl   time.Duration
+}
+
+// New wraps s so that employee lookups by id are served from c, and writes invalidate the cached entry.
+func New(s datastore.EmpStore, c datastore.Cache, ttl time.Duration) store {
+	return store{store: s, cache: c, ttl: ttl}
+}
+
+func key(id int) string {
+	return "employee:" + strconv.Itoa(id)
+}
+
+func (s store) EmpGet(ctx *gofr.Context, filter model.Filter) ([]model.Employee, error) {
+	return s.store.EmpGet(ctx, filter)
+}
+
+// EmpGetByID caches the employee read only when its key is absent: a write landing between the read and the caching
+// leaves a tombstone under the key, which keeps the employee it replaced from being cached.
+func (s store) EmpGetByID(ctx *gofr.Context, id int) (model.Employee, error) {
+	var e model.Employee
+
+	if data, ok := s.cache.Get(ctx, key(id)); ok && !bytes.Equal(data, tombstone) && json.Unmarshal(data, &e) == nil {
+		return e, nil
+	}
+
+	e, err := s.store.EmpGetByID(ctx, id)
+	if err != nil {
+		return model.Employee{}, err
+	}
+
+	data, err := json.Marshal(e)
+	if err == nil {
+		_, err = s.cache.Add(ctx, key(id), data, s.ttl)
+	}
+
+	if err != nil {
+		ctx.Logger.Warnf("failed to cache employee %d: %v", id, err)
+	}
+
+	return e, nil
+}
+
+func (s store) EmpSubtree(ctx *gofr.Context, id int) ([]model.Employee, error) {
+	return s.store.EmpSubtree(ctx, id)
+}
+
+func (s store) EmpChain(ctx *gofr.Context, id int) ([]model.Employee, error) {
+	return s.store.EmpChain(ctx, id)
+}
+
+func (s store) EmpCreate(ctx *gofr.Context, employee model.Employee, event *model.Event) (model.Employee, error) {
+	resp, err := s.store.EmpCreate(ctx, employee, event)
+
+	s.invalidate(ctx, employee.ID)
+
+	return resp, err
+}
+
+func (s store) EmpUpdate(ctx *gofr.Context, employee model.Employee, event *model.Event) (model.Employee, error) {
+	resp, err := s.store.EmpUpdate(ctx, employee, event)
+
+	s.invalidate(ctx, employee.ID)
+
+	return resp, err
+}
+
+func (s store) EmpPing(ctx *gofr.Context) error {
+	return s.store.EmpPing(ctx)
+}
+
+// invalidate replaces the cached employee with a tombstone, for longer than a read takes. It runs even when the write
+// fails, since the database may have applied it before returning the error.
+func (s store) invalidate(ctx *gofr.Context, id int) {
+	if err := s.cache.Set(ctx, key(id), tombstone, tombstoneTTL); err != nil {
+		ctx.Logger.Errorf("failed to invalidate cached employee %d: %v", id, err)
+	}
+}
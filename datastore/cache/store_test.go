@@ -0,0 +1,148 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/datastore/mocks"
+	"example/model"
+)
+
+func newContext() *gofr.Context {
+	ctx := gofr.NewContext(nil, nil, gofr.New())
+	ctx.Context = context.Background()
+
+	return ctx
+}
+
+func TestStore_EmpGetByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockEmpStore(ctrl)
+	c := mocks.NewMockCache(ctrl)
+	s := New(m, c, time.Minute)
+	ctx := newContext()
+
+	emp := model.Employee{ID: 1, Age: 21, Name: "Ram"}
+	data, _ := json.Marshal(emp)
+
+	testcases := []struct {
+		desc   string
+		output model.Employee
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"hit", emp, nil, []*gomock.Call{
+			c.EXPECT().Get(gomock.Any(), "employee:1").Return(data, true),
+		}},
+		{"miss", emp, nil, []*gomock.Call{
+			c.EXPECT().Get(gomock.Any(), "employee:1").Return(nil, false),
+			m.EXPECT().EmpGetByID(gomock.Any(), 1).Return(emp, nil),
+			c.EXPECT().Add(gomock.Any(), "employee:1", data, time.Minute).Return(true, nil),
+		}},
+		{"invalidated", emp, nil, []*gomock.Call{
+			c.EXPECT().Get(gomock.Any(), "employee:1").Return(tombstone, true),
+			m.EXPECT().EmpGetByID(gomock.Any(), 1).Return(emp, nil),
+			c.EXPECT().Add(gomock.Any(), "employee:1", data, time.Minute).Return(false, nil),
+		}},
+		{"set failure", emp, nil, []*gomock.Call{
+			c.EXPECT().Get(gomock.Any(), "employee:1").Return(nil, false),
+			m.EXPECT().EmpGetByID(gomock.Any(), 1).Return(emp, nil),
+			c.EXPECT().Add(gomock.Any(), "employee:1", data, time.Minute).Return(false,
+				errors.Error("connection refused")),
+		}},
+		{"store failure", model.Employee{}, errors.Error("Scan Error"), []*gomock.Call{
+			c.EXPECT().Get(gomock.Any(), "employee:1").Return(nil, false),
+			m.EXPECT().EmpGetByID(gomock.Any(), 1).Return(model.Employee{}, errors.Error("Scan Error")),
+		}},
+	}
+
+	for i, tc := range testcases {
+		resp, err := s.EmpGetByID(ctx, 1)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestStore_Writes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockEmpStore(ctrl)
+	c := mocks.NewMockCache(ctrl)
+	s := New(m, c, time.Minute)
+	ctx := newContext()
+
+	emp := model.Employee{ID: 1, Age: 21, Name: "Ram"}
+	event := &model.Event{ID: "e1", Type: model.EmployeeCreated}
+
+	gomock.InOrder(
+		m.EXPECT().EmpCreate(gomock.Any(), emp, event).Return(emp, nil),
+		c.EXPECT().Set(gomock.Any(), "employee:1", tombstone, tombstoneTTL).Return(nil),
+		m.EXPECT().EmpUpdate(gomock.Any(), emp, nil).Return(model.Employee{}, errors.Error("Internal DB Error")),
+		c.EXPECT().Set(gomock.Any(), "employee:1", tombstone, tombstoneTTL).Return(nil),
+		m.EXPECT().EmpGet(gomock.Any(), model.Filter{}).Return([]model.Employee{emp}, nil),
+	)
+
+	if _, err := s.EmpCreate(ctx, emp, event); err != nil {
+		t.Errorf("Failed. Expected no error but got %v", err)
+	}
+
+	if _, err := s.EmpUpdate(ctx, emp, nil); !reflect.DeepEqual(errors.Error("Internal DB Error"), err) {
+		t.Errorf("Failed. Expected Internal DB Error but got %v", err)
+	}
+
+	if resp, _ := s.EmpGet(ctx, model.Filter{}); !reflect.DeepEqual([]model.Employee{emp}, resp) {
+		t.Errorf("Failed. Expected %v but got %v", []model.Employee{emp}, resp)
+	}
+}
+
+func TestStore_EmpGetByIDConcurrentUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockEmpStore(ctrl)
+	s := New(m, NewLRU(10), time.Minute)
+	ctx := newContext()
+
+	stale := model.Employee{ID: 1, Name: "Ram", Title: "Engineer"}
+	updated := model.Employee{ID: 1, Name: "Ram", Title: "Manager"}
+
+	gomock.InOrder(
+		// the update and its invalidation land after the first read got the row, but before it caches it
+		m.EXPECT().EmpGetByID(gomock.Any(), 1).DoAndReturn(func(ctx *gofr.Context, _ int) (model.Employee, error) {
+			if _, err := s.EmpUpdate(ctx, updated, nil); err != nil {
+				t.Errorf("Failed. Expected no error but got %v", err)
+			}
+
+			return stale, nil
+		}),
+		m.EXPECT().EmpUpdate(gomock.Any(), updated, nil).Return(updated, nil),
+		m.EXPECT().EmpGetByID(gomock.Any(), 1).Return(updated, nil),
+	)
+
+	testcases := []struct {
+		desc   string
+		output model.Employee
+	}{
+		{"read before the update", stale},
+		{"read after the update", updated},
+	}
+
+	for i, tc := range testcases {
+		resp, err := s.EmpGetByID(ctx, 1)
+
+		if !reflect.DeepEqual(tc.output, resp) || err != nil {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v %v", i+1, tc.output, resp, err)
+		}
+	}
+}
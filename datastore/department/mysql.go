@@ -0,0 +1,132 @@
+package department
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/datastore/sqlerr"
+	"example/domain"
+	"example/model"
+)
+
+type store struct {
+	now func() time.Time
+}
+
+func New() store {
+	return store{now: time.Now}
+}
+
+// timestamp returns the current time at the microsecond precision the database keeps.
+func (s store) timestamp() time.Time {
+	return s.now().UTC().Truncate(time.Microsecond)
+}
+
+func (s store) DeptGet(ctx *gofr.Context) ([]model.Department, error) {
+	var dept []model.Department
+
+	rows, err := ctx.DB().DB.Query("select id,name,created_at,updated_at from department order by id")
+	if err != nil {
+		return nil, errors.DB{Err: errors.Error("Internal DB error")}
+	}
+
+	defer func(rows *sql.Rows) {
+		err = rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+
+	for rows.Next() {
+		var d model.Department
+
+		err = rows.Scan(&d.ID, &d.Name, &d.CreatedAt, &d.UpdatedAt)
+
+		if err != nil {
+			return nil, errors.Error("Scan Error")
+		}
+
+		dept = append(dept, d)
+	}
+
+	return dept, nil
+}
+
+func (s store) DeptGetByID(ctx *gofr.Context, id int) (model.Department, error) {
+	var d model.Department
+
+	row := ctx.DB().DB.QueryRow("select id,name,created_at,updated_at from department where id = $1", id)
+
+	err := row.Scan(&d.ID, &d.Name, &d.CreatedAt, &d.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return model.Department{}, errors.EntityNotFound{Entity: "department", ID: strconv.Itoa(id)}
+	}
+
+	if err != nil {
+		return model.Department{}, errors.Error("Scan Error")
+	}
+
+	return d, nil
+}
+
+func (s store) DeptCreate(ctx *gofr.Context, department model.Department) (model.Department, error) {
+	department.CreatedAt = s.timestamp()
+	department.UpdatedAt = department.CreatedAt
+
+	_, err := ctx.DB().DB.Exec("insert into department(id,name,created_at,updated_at) VALUES ($1,$2,$3,$4)",
+		department.ID, department.Name, department.CreatedAt, department.UpdatedAt)
+
+	if c, ok := sqlerr.AsConflict(err, "department", "id", "name"); ok {
+		return model.Department{}, c
+	}
+
+	if err != nil {
+		return model.Department{}, errors.Error("Internal DB Error")
+	}
+
+	return department, nil
+}
+
+func (s store) DeptUpdate(ctx *gofr.Context, department model.Department) (model.Department, error) {
+	res, err := ctx.DB().DB.Exec("update department set name = $1,updated_at = $2 where id = $3",
+		department.Name, s.timestamp(), department.ID)
+
+	if c, ok := sqlerr.AsConflict(err, "department", "id", "name"); ok {
+		return model.Department{}, c
+	}
+
+	if err != nil {
+		return model.Department{}, errors.Error("Internal DB Error")
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return model.Department{}, errors.EntityNotFound{Entity: "department", ID: strconv.Itoa(department.ID)}
+	}
+
+	return s.DeptGetByID(ctx, department.ID)
+}
+
+// DeptDelete deletes the department. It returns a conflict when employees still belong to it, as their department_id
+// references it.
+func (s store) DeptDelete(ctx *gofr.Context, id int) error {
+	res, err := ctx.DB().DB.Exec("delete from department where id = $1", id)
+
+	if sqlerr.Referenced(err) {
+		return domain.Conflict{Entity: "department", Reason: "department " + strconv.Itoa(id) + " still has employees"}
+	}
+
+	if err != nil {
+		return errors.Error("Internal DB Error")
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return errors.EntityNotFound{Entity: "department", ID: strconv.Itoa(id)}
+	}
+
+	return nil
+}
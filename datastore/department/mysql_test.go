@@ -0,0 +1,228 @@
+package department
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+
+	"developer.zopsmart.com/go/gofr/pkg/datastore"
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/domain"
+	"example/model"
+)
+
+func columnNames() []string {
+	return []string{"id", "name", "created_at", "updated_at"}
+}
+
+func newContext(t *testing.T) (*gofr.Context, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("database error %s", err)
+	}
+
+	g := gofr.Gofr{DataStore: datastore.DataStore{ORM: db}}
+	ctx := gofr.NewContext(nil, nil, &g)
+	ctx.Context = context.Background()
+
+	return ctx, mock, func() { db.Close() }
+}
+
+func TestStore_DeptGet(t *testing.T) {
+	ctx, mock, done := newContext(t)
+	defer done()
+
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	dataStore := store{now: func() time.Time { return now }}
+	query := "select id,name,created_at,updated_at from department order by id"
+
+	testcases := []struct {
+		desc   string
+		output []model.Department
+		err    error
+		mock   []interface{}
+	}{
+		{"success", []model.Department{{ID: 1, Name: "Engineering", CreatedAt: now, UpdatedAt: now}}, nil, []interface{}{
+			mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows(columnNames()).AddRow(1, "Engineering", now, now)),
+		}},
+		{"failure", nil, errors.DB{Err: errors.Error("Internal DB error")}, []interface{}{
+			mock.ExpectQuery(query).WillReturnError(errors.Error("connection refused")),
+		}},
+		{"scan error", nil, errors.Error("Scan Error"), []interface{}{
+			mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows(append(columnNames(), "err")).
+				AddRow(1, "Engineering", now, now, "error")),
+		}},
+	}
+
+	for i, tc := range testcases {
+		resp, err := dataStore.DeptGet(ctx)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestStore_DeptGetByID(t *testing.T) {
+	ctx, mock, done := newContext(t)
+	defer done()
+
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	dataStore := store{now: func() time.Time { return now }}
+	query := "select id,name,created_at,updated_at from department where id = $1"
+
+	testcases := []struct {
+		desc   string
+		id     int
+		output model.Department
+		err    error
+		mock   []interface{}
+	}{
+		{"success", 1, model.Department{ID: 1, Name: "Engineering", CreatedAt: now, UpdatedAt: now}, nil, []interface{}{
+			mock.ExpectQuery(query).WithArgs(1).WillReturnRows(sqlmock.NewRows(columnNames()).AddRow(1, "Engineering", now, now)),
+		}},
+		{"not found", 2, model.Department{}, errors.EntityNotFound{Entity: "department", ID: "2"}, []interface{}{
+			mock.ExpectQuery(query).WithArgs(2).WillReturnRows(sqlmock.NewRows(columnNames())),
+		}},
+		{"scan error", 3, model.Department{}, errors.Error("Scan Error"), []interface{}{
+			mock.ExpectQuery(query).WithArgs(3).WillReturnError(errors.Error("connection refused")),
+		}},
+	}
+
+	for i, tc := range testcases {
+		resp, err := dataStore.DeptGetByID(ctx, tc.id)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestStore_DeptCreate(t *testing.T) {
+	ctx, mock, done := newContext(t)
+	defer done()
+
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	dataStore := store{now: func() time.Time { return now }}
+	exec := "insert into department(id,name,created_at,updated_at) VALUES ($1,$2,$3,$4)"
+
+	testcases := []struct {
+		desc   string
+		input  model.Department
+		output model.Department
+		err    error
+		mock   []interface{}
+	}{
+		{"success", model.Department{ID: 1, Name: "Engineering"},
+			model.Department{ID: 1, Name: "Engineering", CreatedAt: now, UpdatedAt: now}, nil, []interface{}{
+				mock.ExpectExec(exec).WithArgs(1, "Engineering", now, now).WillReturnResult(sqlmock.NewResult(1, 1)),
+			}},
+		{"failure", model.Department{ID: 2, Name: "Sales"}, model.Department{}, errors.Error("Internal DB Error"), []interface{}{
+			mock.ExpectExec(exec).WithArgs(2, "Sales", now, now).WillReturnError(errors.Error("duplicate key")),
+		}},
+	}
+
+	for i, tc := range testcases {
+		resp, err := dataStore.DeptCreate(ctx, tc.input)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestStore_DeptUpdate(t *testing.T) {
+	ctx, mock, done := newContext(t)
+	defer done()
+
+	created := time.Date(2022, time.January, 1, 10, 0, 0, 0, time.UTC)
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	dataStore := store{now: func() time.Time { return now }}
+	exec := "update department set name = $1,updated_at = $2 where id = $3"
+	query := "select id,name,created_at,updated_at from department where id = $1"
+
+	testcases := []struct {
+		desc   string
+		input  model.Department
+		output model.Department
+		err    error
+		mock   []interface{}
+	}{
+		{"success", model.Department{ID: 1, Name: "Platform"},
+			model.Department{ID: 1, Name: "Platform", CreatedAt: created, UpdatedAt: now}, nil, []interface{}{
+				mock.ExpectExec(exec).WithArgs("Platform", now, 1).WillReturnResult(sqlmock.NewResult(0, 1)),
+				mock.ExpectQuery(query).WithArgs(1).WillReturnRows(sqlmock.NewRows(columnNames()).
+					AddRow(1, "Platform", created, now)),
+			}},
+		{"not found", model.Department{ID: 2, Name: "Sales"}, model.Department{},
+			errors.EntityNotFound{Entity: "department", ID: "2"}, []interface{}{
+				mock.ExpectExec(exec).WithArgs("Sales", now, 2).WillReturnResult(sqlmock.NewResult(0, 0)),
+			}},
+		{"failure", model.Department{ID: 3, Name: "Finance"}, model.Department{}, errors.Error("Internal DB Error"),
+			[]interface{}{mock.ExpectExec(exec).WithArgs("Finance", now, 3).WillReturnError(errors.Error("duplicate key"))}},
+	}
+
+	for i, tc := range testcases {
+		resp, err := dataStore.DeptUpdate(ctx, tc.input)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestStore_DeptDelete(t *testing.T) {
+	ctx, mock, done := newContext(t)
+	defer done()
+
+	dataStore := New()
+	exec := "delete from department where id = $1"
+
+	testcases := []struct {
+		desc string
+		id   int
+		err  error
+		mock []interface{}
+	}{
+		{"success", 1, nil, []interface{}{mock.ExpectExec(exec).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))}},
+		{"not found", 2, errors.EntityNotFound{Entity: "department", ID: "2"}, []interface{}{
+			mock.ExpectExec(exec).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 0)),
+		}},
+		{"has employees", 3, domain.Conflict{Entity: "department", Reason: "department 3 still has employees"},
+			[]interface{}{mock.ExpectExec(exec).WithArgs(3).WillReturnError(&pq.Error{Code: "23503",
+				Constraint: "employee_department_id_fkey"})}},
+		{"failure", 4, errors.Error("Internal DB Error"), []interface{}{
+			mock.ExpectExec(exec).WithArgs(4).WillReturnError(errors.Error("connection reset")),
+		}},
+	}
+
+	for i, tc := range testcases {
+		err := dataStore.DeptDelete(ctx, tc.id)
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package employee
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/metrics"
+	"example/model"
+	"example/tracing"
+)
+
+// instrumented traces every store call, with the statement it ran, observes its latency, and refreshes the
+// employee count on the interval given to Count.
+type instrumented struct {
+	store
+}
+
+func (s instrumented) EmpGet(ctx *gofr.Context, filter model.Filter) ([]model.Employee, error) {
+	defer metrics.Store(ctx, "EmpGet", time.Now())
+
+	span := tracing.Start(ctx, "EmpStore.EmpGet")
+	resp, err := s.store.EmpGet(ctx, filter)
+	span.SetAttributes(attribute.Int("db.rows", len(resp)))
+	span.End(err)
+
+	return resp, err
+}
+
+func (s instrumented) EmpGetByID(ctx *gofr.Context, id int) (model.Employee, error) {
+	defer metrics.Store(ctx, "EmpGetByID", time.Now())
+
+	span := tracing.Start(ctx, "EmpStore.EmpGetByID", attribute.Int("employee.id", id))
+	resp, err := s.store.EmpGetByID(ctx, id)
+	span.End(err)
+
+	return resp, err
+}
+
+func (s instrumented) EmpCreate(ctx *gofr.Context, employee model.Employee, event *model.Event) (model.Employee,
+	error) {
+	defer metrics.Store(ctx, "EmpCreate", time.Now())
+
+	span := tracing.Start(ctx, "EmpStore.EmpCreate", attribute.Int("employee.id", employee.ID))
+	resp, err := s.store.EmpCreate(ctx, employee, event)
+	span.End(err)
+
+	return resp, err
+}
+
+// Count records the employee count now and every interval after until ctx is done, so that the gauge is set from
+// startup and follows every change to the table, including rows removed from the database directly. A count that
+// fails leaves the gauge as it was.
+func (s instrumented) Count(ctx *gofr.Context, interval time.Duration) {
+	for {
+		span := tracing.Start(ctx, "EmpStore.count")
+		n, err := s.store.count(ctx)
+		span.End(err)
+
+		if err == nil {
+			metrics.EmployeeCount(ctx, n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (s instrumented) EmpUpdate(ctx *gofr.Context, employee model.Employee, event *model.Event) (model.Employee,
+	error) {
+	defer metrics.Store(ctx, "EmpUpdate", time.Now())
+
+	span := tracing.Start(ctx, "EmpStore.EmpUpdate", attribute.Int("employee.id", employee.ID))
+	resp, err := s.store.EmpUpdate(ctx, employee, event)
+	span.End(err)
+
+	return resp, err
+}
+
+func (s instrumented) EmpSubtree(ctx *gofr.Context, id int) ([]model.Employee, error) {
+	defer metrics.Store(ctx, "EmpSubtree", time.Now())
+
+	span := tracing.Start(ctx, "EmpStore.EmpSubtree", attribute.Int("employee.id", id))
+	resp, err := s.store.EmpSubtree(ctx, id)
+	span.SetAttributes(attribute.Int("db.rows", len(resp)))
+	span.End(err)
+
+	return resp, err
+}
+
+func (s instrumented) EmpChain(ctx *gofr.Context, id int) ([]model.Employee, error) {
+	defer metrics.Store(ctx, "EmpChain", time.Now())
+
+	span := tracing.Start(ctx, "EmpStore.EmpChain", attribute.Int("employee.id", id))
+	resp, err := s.store.EmpChain(ctx, id)
+	span.SetAttributes(attribute.Int("db.rows", len(resp)))
+	span.End(err)
+
+	return resp, err
+}
+
+func (s instrumented) EmpPing(ctx *gofr.Context) error {
+	defer metrics.Store(ctx, "EmpPing", time.Now())
+
+	span := tracing.Start(ctx, "EmpStore.EmpPing")
+	err := s.store.EmpPing(ctx)
+	span.End(err)
+
+	return err
+}
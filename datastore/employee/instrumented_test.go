@@ -0,0 +1,136 @@
+package employee
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"developer.zopsmart.com/go/gofr/pkg/datastore"
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"developer.zopsmart.com/go/gofr/pkg/log"
+
+	"example/model"
+)
+
+type metric struct {
+	recorded []string
+}
+
+func (m *metric) IncCounter(name string, labels ...string) error {
+	m.recorded = append(m.recorded, fmt.Sprint(name, labels))
+	return nil
+}
+
+func (m *metric) ObserveHistogram(name string, _ float64, labels ...string) error {
+	m.recorded = append(m.recorded, fmt.Sprint(name, labels))
+	return nil
+}
+
+func (m *metric) SetGauge(name string, value float64, labels ...string) error {
+	m.recorded = append(m.recorded, fmt.Sprint(name, labels, value))
+	return nil
+}
+
+// cancelling cancels once after values were recorded.
+type cancelling struct {
+	*metric
+	after  int
+	cancel func()
+}
+
+func (m *cancelling) SetGauge(name string, value float64, labels ...string) error {
+	err := m.metric.SetGauge(name, value, labels...)
+	if len(m.recorded) == m.after {
+		m.cancel()
+	}
+
+	return err
+}
+
+func TestInstrumented_EmpCreate(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("database error %s", err)
+	}
+
+	defer db.Close()
+
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	exec := "insert into employee(" + columns + ") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)"
+
+	testcases := []struct {
+		desc     string
+		recorded []string
+		err      error
+		mock     []interface{}
+	}{
+		// the employee count is left to Count rather than queried on every write
+		{"created", []string{"employee_store_duration_seconds[EmpCreate]"}, nil, []interface{}{
+			mock.ExpectBegin(),
+			mock.ExpectExec(exec).WillReturnResult(sqlmock.NewResult(1, 1)),
+			mock.ExpectCommit(),
+		}},
+		{"failed", []string{"employee_store_duration_seconds[EmpCreate]"}, errors.Error("Internal DB Error"),
+			[]interface{}{mock.ExpectBegin(), mock.ExpectExec(exec).WillReturnError(errors.Error("connection reset")),
+				mock.ExpectRollback()}},
+	}
+
+	for i, tc := range testcases {
+		m := &metric{}
+		ctx := gofr.NewContext(nil, nil, &gofr.Gofr{DataStore: datastore.DataStore{ORM: db}, Metric: m,
+			Logger: log.NewMockLogger(io.Discard)})
+		ctx.Context = context.Background()
+		s := instrumented{store{now: func() time.Time { return now }}}
+
+		_, err := s.EmpCreate(ctx, model.Employee{ID: 1, Name: "Ram"}, nil)
+
+		if !reflect.DeepEqual(tc.err, err) || !reflect.DeepEqual(tc.recorded, m.recorded) {
+			t.Errorf("[Test %v]Failed. Expected %v %v but got %v %v", i+1, tc.err, tc.recorded, err, m.recorded)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("[Test %v]Failed. %v", len(testcases)+1, err)
+	}
+}
+
+func TestInstrumented_Count(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("database error %s", err)
+	}
+
+	defer db.Close()
+
+	count := "select count(*) from employee"
+
+	mock.ExpectQuery(count).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+	mock.ExpectQuery(count).WillReturnError(errors.Error("connection reset"))
+	mock.ExpectQuery(count).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+
+	c, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// stops counting once the second count is recorded
+	m := &cancelling{metric: &metric{}, after: 2, cancel: cancel}
+	ctx := gofr.NewContext(nil, nil, &gofr.Gofr{DataStore: datastore.DataStore{ORM: db}, Metric: m,
+		Logger: log.NewMockLogger(io.Discard)})
+	ctx.Context = c
+
+	instrumented{store{now: time.Now}}.Count(ctx, time.Millisecond)
+
+	// counted at once and on every interval, skipping the count that failed
+	if expected := []string{"employee_count[] 7", "employee_count[] 5"}; !reflect.DeepEqual(expected, m.recorded) {
+		t.Errorf("[Test 1]Failed. Expected %v but got %v", expected, m.recorded)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("[Test 2]Failed. %v", err)
+	}
+}
@@ -2,24 +2,170 @@ package employee
 
 import (
 	"database/sql"
+	"strconv"
+	"strings"
+	"time"
 
 	"developer.zopsmart.com/go/gofr/pkg/errors"
 	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"github.com/lib/pq"
 
+	"example/datastore/outbox"
+	"example/datastore/sqlerr"
+	"example/domain"
 	"example/model"
+	"example/tracing"
 )
 
-type store struct{}
+const columns = "id,name,email,department_id,title,manager_id,hire_date,status,date_of_birth,created_at,created_by,updated_at,updated_by"
 
-func New() store {
-	return store{}
+// maxDepth bounds the recursive hierarchy queries, so a manager cycle already in the table cannot make them loop
+// forever, and the chain of managers EmpUpdate accepts above a new manager.
+const maxDepth = 64
+
+type store struct {
+	now func() time.Time
+}
+
+// New returns the employee store, tracing its calls and observing their latency in metrics.StoreDuration.
+func New() instrumented {
+	return instrumented{store{now: time.Now}}
+}
+
+// timestamp returns the current time at the microsecond precision the database keeps.
+func (s store) timestamp() time.Time {
+	return s.now().UTC().Truncate(time.Microsecond)
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scan reads a row selected with columns into e and derives the age from the date of birth.
+func (s store) scan(row scanner, e *model.Employee) error {
+	var (
+		email      sql.NullString
+		department sql.NullInt64
+		manager    sql.NullInt64
+	)
+
+	err := row.Scan(&e.ID, &e.Name, &email, &department, &e.Title, &manager, &e.HireDate, &e.Status, &e.DateOfBirth,
+		&e.CreatedAt, &e.CreatedBy, &e.UpdatedAt, &e.UpdatedBy)
+	if err != nil {
+		return err
+	}
+
+	e.Email = email.String
+	e.DepartmentID = nullableID(department)
+	e.ManagerID = nullableID(manager)
+
+	e.Age = e.DateOfBirth.YearsAt(s.now())
+
+	return nil
+}
+
+func nullableID(n sql.NullInt64) *int {
+	if !n.Valid {
+		return nil
+	}
+
+	id := int(n.Int64)
+
+	return &id
+}
+
+// where builds the condition and arguments selecting the employees that match filter.
+func where(filter model.Filter) (string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	add := func(column, operator string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, column+" "+operator+" $"+strconv.Itoa(len(args)))
+	}
+
+	if filter.IDs != nil {
+		args = append(args, pq.Array(filter.IDs))
+		conditions = append(conditions, "id = any($"+strconv.Itoa(len(args))+")")
+	}
+
+	if filter.DepartmentID != nil {
+		add("department_id", "=", *filter.DepartmentID)
+	}
+
+	if filter.Title != "" {
+		add("title", "=", filter.Title)
+	}
+
+	if filter.Status != "" {
+		add("status", "=", filter.Status)
+	}
+
+	if filter.ManagerID != nil {
+		add("manager_id", "=", *filter.ManagerID)
+	}
+
+	if filter.AfterID != nil {
+		add("id", ">", *filter.AfterID)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	return " where " + strings.Join(conditions, " and "), args
+}
+
+func (s store) EmpGet(ctx *gofr.Context, filter model.Filter) ([]model.Employee, error) {
+	cond, args := where(filter)
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		cond += " order by id limit $" + strconv.Itoa(len(args))
+	} else {
+		cond += " order by id"
+	}
+
+	return s.query(ctx, "select "+columns+" from employee"+cond, args...)
+}
+
+// EmpSubtree returns every employee reporting to id directly or indirectly, nearest levels first.
+func (s store) EmpSubtree(ctx *gofr.Context, id int) ([]model.Employee, error) {
+	return s.query(ctx, `with recursive subtree(id, depth) as (
+			select id, 1 from employee where manager_id = $1
+			union all
+			select e.id, t.depth + 1 from employee e join subtree t on e.manager_id = t.id where t.depth < $2
+		)
+		select `+qualified("e")+` from subtree t join employee e on e.id = t.id order by t.depth, e.id`, id, maxDepth)
+}
+
+// EmpChain returns the managers of id from its direct manager up to the root of the hierarchy.
+func (s store) EmpChain(ctx *gofr.Context, id int) ([]model.Employee, error) {
+	return s.query(ctx, `with recursive chain(id, depth) as (
+			select manager_id, 1 from employee where id = $1 and manager_id is not null
+			union all
+			select e.manager_id, c.depth + 1 from employee e join chain c on e.id = c.id
+			where e.manager_id is not null and c.depth < $2
+		)
+		select `+qualified("e")+` from chain c join employee e on e.id = c.id order by c.depth`, id, maxDepth)
+}
+
+// qualified prefixes every column with the table alias, for queries joining employee with another relation.
+func qualified(alias string) string {
+	return alias + "." + strings.ReplaceAll(columns, ",", ","+alias+".")
 }
 
-func (s store) EmpGet(ctx *gofr.Context) ([]model.Employee, error) {
+// query runs a select of columns and scans every returned row.
+func (s store) query(ctx *gofr.Context, query string, args ...interface{}) ([]model.Employee, error) {
 	var emp []model.Employee
 
-	rows, err := ctx.DB().DB.Query("select * from employee")
+	tracing.Statement(ctx, query)
+
+	rows, err := ctx.DB().DB.Query(query, args...)
 	if err != nil {
+		ctx.Logger.Errorf("failed to query employees: %v", err)
 		return nil, errors.DB{Err: errors.Error("Internal DB error")}
 	}
 
@@ -33,9 +179,10 @@ func (s store) EmpGet(ctx *gofr.Context) ([]model.Employee, error) {
 	for rows.Next() {
 		var e model.Employee
 
-		err = rows.Scan(&e.ID, &e.Age, &e.Name)
+		err = s.scan(rows, &e)
 
 		if err != nil {
+			ctx.Logger.Errorf("failed to scan employee: %v", err)
 			return nil, errors.Error("Scan Error")
 		}
 
@@ -48,35 +195,202 @@ func (s store) EmpGet(ctx *gofr.Context) ([]model.Employee, error) {
 func (s store) EmpGetByID(ctx *gofr.Context, id int) (model.Employee, error) {
 	var e model.Employee
 
-	row := ctx.DB().DB.QueryRow("select * from employee where id = $1", id)
+	query := "select " + columns + " from employee where id = $1"
+	tracing.Statement(ctx, query)
 
-	err := row.Scan(&e.ID, &e.Age, &e.Name)
+	row := ctx.DB().DB.QueryRow(query, id)
+
+	err := s.scan(row, &e)
+
+	if err == sql.ErrNoRows {
+		return model.Employee{}, errors.EntityNotFound{Entity: "employee", ID: strconv.Itoa(id)}
+	}
 
 	if err != nil {
+		ctx.Logger.Errorf("failed to get employee %d: %v", id, err)
 		return model.Employee{}, errors.Error("Scan Error")
 	}
 
 	return e, nil
 }
 
-func (s store) EmpCreate(ctx *gofr.Context, employee model.Employee) (model.Employee, error) {
-	_, err := ctx.DB().DB.Exec("insert into employee(id,age,name) VALUES ($1,$2,$3)",
-		employee.ID, employee.Age, employee.Name)
+// EmpCreate stores the employee with the event of its creation, see datastore.EmpStore.
+func (s store) EmpCreate(ctx *gofr.Context, employee model.Employee, event *model.Event) (model.Employee, error) {
+	employee.CreatedAt = s.timestamp()
+	employee.UpdatedAt = employee.CreatedAt
+	employee.Age = employee.DateOfBirth.YearsAt(s.now())
+
+	if employee.UpdatedBy == "" {
+		employee.UpdatedBy = employee.CreatedBy
+	}
+
+	return s.write(ctx, event, func(tx *sql.Tx) (model.Employee, error) {
+		query := "insert into employee(" + columns + ") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)"
+		tracing.Statement(ctx, query)
+
+		_, err := tx.Exec(query, employee.ID, employee.Name, employee.Email, employee.DepartmentID, employee.Title,
+			employee.ManagerID, employee.HireDate, employee.Status, employee.DateOfBirth, employee.CreatedAt,
+			employee.CreatedBy, employee.UpdatedAt, employee.UpdatedBy)
+
+		if c, ok := sqlerr.AsConflict(err, "employee", "id", "email"); ok {
+			return model.Employee{}, c
+		}
 
+		// the manager or department was deleted since the service checked it exists
+		if f, ok := sqlerr.AsReference(err, "manager_id", "department_id"); ok {
+			return model.Employee{}, domain.Invalid{Param: []string{f}}
+		}
+
+		if err != nil {
+			ctx.Logger.Errorf("failed to create employee %d: %v", employee.ID, err)
+			return model.Employee{}, errors.Error("Internal DB Error")
+		}
+
+		return employee, nil
+	})
+}
+
+// EmpUpdate leaves created_at and created_by untouched and returns the employee as stored after the update, which is
+// stored with the event of the update, see datastore.EmpStore. It refuses a manager that reports to the employee,
+// see checkManager.
+func (s store) EmpUpdate(ctx *gofr.Context, employee model.Employee, event *model.Event) (model.Employee, error) {
+	return s.write(ctx, event, func(tx *sql.Tx) (model.Employee, error) {
+		if err := s.checkManager(ctx, tx, employee); err != nil {
+			return model.Employee{}, err
+		}
+
+		query := "update employee set name = $1,email = $2,department_id = $3,title = $4,manager_id = $5," +
+			"hire_date = $6,status = $7,date_of_birth = $8,updated_at = $9,updated_by = $10 where id = $11"
+		tracing.Statement(ctx, query)
+
+		res, err := tx.Exec(query, employee.Name, employee.Email, employee.DepartmentID, employee.Title,
+			employee.ManagerID, employee.HireDate, employee.Status, employee.DateOfBirth, s.timestamp(),
+			employee.UpdatedBy, employee.ID)
+
+		if c, ok := sqlerr.AsConflict(err, "employee", "id", "email"); ok {
+			return model.Employee{}, c
+		}
+
+		// the manager or department was deleted since the service checked it exists
+		if f, ok := sqlerr.AsReference(err, "manager_id", "department_id"); ok {
+			return model.Employee{}, domain.Invalid{Param: []string{f}}
+		}
+
+		if err != nil {
+			ctx.Logger.Errorf("failed to update employee %d: %v", employee.ID, err)
+			return model.Employee{}, errors.Error("Internal DB Error")
+		}
+
+		if n, err := res.RowsAffected(); err == nil && n == 0 {
+			return model.Employee{}, errors.EntityNotFound{Entity: "employee", ID: strconv.Itoa(employee.ID)}
+		}
+
+		var e model.Employee
+
+		query = "select " + columns + " from employee where id = $1"
+		tracing.Statement(ctx, query)
+
+		if err = s.scan(tx.QueryRow(query, employee.ID), &e); err != nil {
+			ctx.Logger.Errorf("failed to get employee %d: %v", employee.ID, err)
+			return model.Employee{}, errors.Error("Scan Error")
+		}
+
+		return e, nil
+	})
+}
+
+// checkManager walks up the chain of managers from the new manager of e in tx, locking every row it reads until tx
+// ends, so that a concurrent update cannot close a cycle between the check and the commit. It rejects a manager that
+// reports to e, directly or not, or whose chain is longer than maxDepth, which the hierarchy queries would cut short.
+func (s store) checkManager(ctx *gofr.Context, tx *sql.Tx, e model.Employee) error {
+	if e.ManagerID == nil {
+		return nil
+	}
+
+	query := "select manager_id from employee where id = $1 for update"
+	tracing.Statement(ctx, query)
+
+	id := *e.ManagerID
+
+	for depth := 1; depth <= maxDepth; depth++ {
+		if id == e.ID {
+			return domain.Invalid{Param: []string{"manager_id"}}
+		}
+
+		var manager sql.NullInt64
+
+		err := tx.QueryRow(query, id).Scan(&manager)
+		if err == sql.ErrNoRows {
+			return domain.Invalid{Param: []string{"manager_id"}}
+		}
+
+		if err != nil {
+			ctx.Logger.Errorf("failed to lock the managers of employee %d: %v", e.ID, err)
+			return errors.Error("Internal DB Error")
+		}
+
+		if !manager.Valid {
+			return nil
+		}
+
+		id = int(manager.Int64)
+	}
+
+	return domain.Conflict{Entity: "employee", Field: "manager_id",
+		Reason: "the manager has more than " + strconv.Itoa(maxDepth) + " levels of managers above it"}
+}
+
+// write runs fn in a transaction and adds the event, completed with the employee fn returns, to the outbox in the
+// same transaction, so that a change is never stored without its event nor its event published without the change.
+func (s store) write(ctx *gofr.Context, event *model.Event, fn func(tx *sql.Tx) (model.Employee, error)) (
+	model.Employee, error) {
+	tx, err := ctx.DB().DB.Begin()
 	if err != nil {
+		ctx.Logger.Errorf("failed to begin a transaction: %v", err)
 		return model.Employee{}, errors.Error("Internal DB Error")
 	}
 
-	return employee, nil
-}
+	resp, err := fn(tx)
+	if err == nil && event != nil {
+		e := *event
+		e.Employee = resp
 
-func (s store) EmpUpdate(ctx *gofr.Context, employee model.Employee) (model.Employee, error) {
-	_, err := ctx.DB().DB.Exec("update employee set age = $1,name = $2 where id = $3",
-		employee.Age, employee.Name, employee.ID)
+		err = outbox.Insert(ctx, tx, e, s.timestamp())
+	}
 
 	if err != nil {
+		_ = tx.Rollback()
+		return model.Employee{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		ctx.Logger.Errorf("failed to commit employee %d: %v", resp.ID, err)
 		return model.Employee{}, errors.Error("Internal DB Error")
 	}
 
-	return employee, nil
+	return resp, nil
+}
+
+// count returns the number of employees stored.
+func (s store) count(ctx *gofr.Context) (int, error) {
+	var n int
+
+	query := "select count(*) from employee"
+	tracing.Statement(ctx, query)
+
+	if err := ctx.DB().DB.QueryRow(query).Scan(&n); err != nil {
+		ctx.Logger.Errorf("failed to count employees: %v", err)
+		return 0, errors.DB{Err: err}
+	}
+
+	return n, nil
+}
+
+// EmpPing checks that the database holding the employees can be reached, within the deadline of ctx.
+func (s store) EmpPing(ctx *gofr.Context) error {
+	if err := ctx.DB().DB.PingContext(ctx); err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return nil
 }
@@ -2,18 +2,29 @@ package employee
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 
 	"developer.zopsmart.com/go/gofr/pkg/datastore"
 	"developer.zopsmart.com/go/gofr/pkg/errors"
 	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"developer.zopsmart.com/go/gofr/pkg/log"
 
+	"example/domain"
 	"example/model"
 )
 
+func columnNames() []string {
+	return []string{"id", "name", "email", "department_id", "title", "manager_id", "hire_date", "status", "date_of_birth",
+		"created_at", "created_by", "updated_at", "updated_by"}
+}
+
 func TestStore_EmpGet(t *testing.T) {
 	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 
@@ -23,18 +34,30 @@ func TestStore_EmpGet(t *testing.T) {
 
 	defer db.Close()
 
-	g := gofr.Gofr{DataStore: datastore.DataStore{ORM: db}}
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	dob := model.NewDate(2000, time.March, 2)
+	hired := model.NewDate(2021, time.June, 1)
+	manager, dept := 1, 4
+
+	g := gofr.Gofr{DataStore: datastore.DataStore{ORM: db}, Logger: log.NewMockLogger(io.Discard)}
 	ctx := gofr.NewContext(nil, nil, &g)
 	ctx.Context = context.Background()
-	dataStore := New()
+	dataStore := store{now: func() time.Time { return now }}
 
-	query := "select * from employee"
+	query := "select " + columns + " from employee order by id"
+	filtered := "select " + columns + " from employee where department_id = $1 and status = $2 and manager_id = $3 order by id"
+	paged := "select " + columns + " from employee where id > $1 order by id limit $2"
+	byIDs := "select " + columns + " from employee where id = any($1) and status = $2 order by id"
 
-	row := sqlmock.NewRows([]string{"id", "age", "name"}).AddRow(1, 21, "Ram")
-	scanError := sqlmock.NewRows([]string{"id", "age", "name", "err"}).AddRow(1, 21, "Ram", "error")
+	row := sqlmock.NewRows(columnNames()).
+		AddRow(2, "Ram", "ram@example.com", 4, "Engineer", 1, hired.Time, "active", dob.Time, now, "ram", now, "sai")
+	legacy := sqlmock.NewRows(columnNames()).AddRow(3, "Sai", nil, nil, "", nil, nil, "active", nil, now, "", now, "")
+	scanError := sqlmock.NewRows(append(columnNames(), "err")).
+		AddRow(2, "Ram", "ram@example.com", 4, "Engineer", 1, hired.Time, "active", dob.Time, now, "ram", now, "sai", "error")
 
 	testcases := []struct {
 		desc   string
+		filter model.Filter
 		output []model.Employee
 		err    error
 		Mock   []interface{}
@@ -42,17 +65,33 @@ func TestStore_EmpGet(t *testing.T) {
 		{desc: "Failure", err: errors.DB{Err: errors.Error("Internal DB error")}, Mock: []interface{}{
 			mock.ExpectQuery(query).WithArgs().WillReturnError(errors.DB{Err: errors.Error("Internal DB error")}),
 		}},
-		{"success", []model.Employee{{1, 21, "Ram"}}, nil, []interface{}{
+		{"success", model.Filter{}, []model.Employee{{ID: 2, Name: "Ram", Email: "ram@example.com", DepartmentID: &dept,
+			Title: "Engineer", ManagerID: &manager, HireDate: hired, Status: model.StatusActive, DateOfBirth: dob, Age: 21,
+			CreatedAt: now, CreatedBy: "ram", UpdatedAt: now, UpdatedBy: "sai"}}, nil, []interface{}{
 			mock.ExpectQuery(query).WithArgs().WillReturnRows(row),
 		}},
-		{"ScanError", nil, errors.Error("Scan Error"), []interface{}{
+		{"filtered legacy row", model.Filter{DepartmentID: &dept, Status: model.StatusActive, ManagerID: &manager},
+			[]model.Employee{{ID: 3, Name: "Sai", Status: model.StatusActive, CreatedAt: now, UpdatedAt: now}}, nil, []interface{}{
+				mock.ExpectQuery(filtered).WithArgs(4, model.StatusActive, 1).WillReturnRows(legacy),
+			}},
+		{"page", model.Filter{AfterID: &manager, Limit: 1},
+			[]model.Employee{{ID: 3, Name: "Sai", Status: model.StatusActive, CreatedAt: now, UpdatedAt: now}}, nil, []interface{}{
+				mock.ExpectQuery(paged).WithArgs(1, 1).WillReturnRows(sqlmock.NewRows(columnNames()).
+					AddRow(3, "Sai", nil, nil, "", nil, nil, "active", nil, now, "", now, "")),
+			}},
+		{"by ids", model.Filter{IDs: []int{3, 5}, Status: model.StatusActive},
+			[]model.Employee{{ID: 3, Name: "Sai", Status: model.StatusActive, CreatedAt: now, UpdatedAt: now}}, nil, []interface{}{
+				mock.ExpectQuery(byIDs).WithArgs(pq.Array([]int{3, 5}), model.StatusActive).
+					WillReturnRows(sqlmock.NewRows(columnNames()).AddRow(3, "Sai", nil, nil, "", nil, nil, "active", nil, now, "", now, "")),
+			}},
+		{"ScanError", model.Filter{}, nil, errors.Error("Scan Error"), []interface{}{
 			mock.ExpectQuery(query).WithArgs().WillReturnRows(scanError),
 		}},
 	}
 	for i, tc := range testcases {
 		t.Run(tc.desc, func(t *testing.T) {
 			tc := tc
-			resp, err := dataStore.EmpGet(ctx)
+			resp, err := dataStore.EmpGet(ctx, tc.filter)
 
 			if !reflect.DeepEqual(tc.output, resp) {
 				t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, resp)
@@ -74,15 +113,22 @@ func TestStore_EmpGetByID(t *testing.T) {
 
 	defer db.Close()
 
-	g := gofr.Gofr{DataStore: datastore.DataStore{ORM: db}}
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	dob := model.NewDate(2000, time.March, 1)
+	hired := model.NewDate(2021, time.June, 1)
+	dept := 4
+
+	g := gofr.Gofr{DataStore: datastore.DataStore{ORM: db}, Logger: log.NewMockLogger(io.Discard)}
 	cxt := gofr.NewContext(nil, nil, &g)
 	cxt.Context = context.Background()
-	dataStore := New()
+	dataStore := store{now: func() time.Time { return now }}
 
-	query := "select * from employee where id = $1"
+	query := "select " + columns + " from employee where id = $1"
 
-	row := sqlmock.NewRows([]string{"id", "age", "name"}).AddRow(1, 21, "Ram")
-	scanError := sqlmock.NewRows([]string{"id", "age", "name", "err"}).AddRow(1, 21, "Ram", "error")
+	row := sqlmock.NewRows(columnNames()).
+		AddRow(1, "Ram", "ram@example.com", 4, "Director", nil, hired.Time, "active", dob.Time, now, "ram", now, "ram")
+	scanError := sqlmock.NewRows(append(columnNames(), "err")).
+		AddRow(1, "Ram", "ram@example.com", 4, "Director", nil, hired.Time, "active", dob.Time, now, "ram", now, "ram", "error")
 
 	testcases := []struct {
 		desc   string
@@ -91,12 +137,17 @@ func TestStore_EmpGetByID(t *testing.T) {
 		err    error
 		mock   []interface{}
 	}{
-		{desc: "success", id: 1, output: model.Employee{ID: 1, Age: 21, Name: "Ram"}, mock: []interface{}{
+		{desc: "success", id: 1, output: model.Employee{ID: 1, Name: "Ram", Email: "ram@example.com", DepartmentID: &dept,
+			Title: "Director", HireDate: hired, Status: model.StatusActive, DateOfBirth: dob, Age: 22, CreatedAt: now,
+			CreatedBy: "ram", UpdatedAt: now, UpdatedBy: "ram"}, mock: []interface{}{
 			mock.ExpectQuery(query).WithArgs(1).WillReturnRows(row),
 		}},
 		{"scanError", 3, model.Employee{}, errors.Error("Scan Error"), []interface{}{
 			mock.ExpectQuery(query).WithArgs(3).WillReturnRows(scanError),
 		}},
+		{"notFound", 4, model.Employee{}, errors.EntityNotFound{Entity: "employee", ID: "4"}, []interface{}{
+			mock.ExpectQuery(query).WithArgs(4).WillReturnRows(sqlmock.NewRows(columnNames())),
+		}},
 	}
 
 	for i, tc := range testcases {
@@ -123,32 +174,63 @@ func TestStore_EmpCreate(t *testing.T) {
 
 	defer db.Close()
 
-	g := gofr.Gofr{DataStore: datastore.DataStore{ORM: db}}
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	dob := model.NewDate(2000, time.March, 1)
+	hired := model.NewDate(2021, time.June, 1)
+	manager, dept := 1, 4
+
+	g := gofr.Gofr{DataStore: datastore.DataStore{ORM: db}, Logger: log.NewMockLogger(io.Discard)}
 	cxt := gofr.NewContext(nil, nil, &g)
 	cxt.Context = context.Background()
-	dataStore := New()
+	dataStore := store{now: func() time.Time { return now }}
+
+	exec := "insert into employee(" + columns + ") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)"
+
+	input := model.Employee{ID: 2, Name: "Sai", Email: "sai@example.com", DepartmentID: &dept, Title: "Engineer",
+		ManagerID: &manager, HireDate: hired, Status: model.StatusActive, DateOfBirth: dob, CreatedBy: "ram"}
+	output := input
+	output.Age, output.CreatedAt, output.UpdatedAt, output.UpdatedBy = 22, now, now, "ram"
 
-	exec := "insert into employee(id,age,name) VALUES ($1,$2,$3)"
-	execError := "insert into employee(id,age,name,err) VALUES ($1,$2,$3,$4)"
+	event := &model.Event{ID: "e1", Type: model.EmployeeCreated, SchemaVersion: 1, Principal: "ram"}
+	payload := eventPayload(*event, output)
 
 	testcases := []struct {
 		desc   string
 		input  model.Employee
+		event  *model.Event
 		output model.Employee
 		err    error
 		mock   []interface{}
 	}{
-		{desc: "Success", input: model.Employee{ID: 1, Age: 21, Name: "Ram"}, output: model.Employee{ID: 1, Age: 21, Name: "Ram"},
-			mock: []interface{}{mock.ExpectExec(exec).WithArgs(1, 21, "Ram").WillReturnResult(sqlmock.NewResult(1, 1))}},
-		{desc: "Failure", input: model.Employee{ID: 2, Age: 22, Name: "Sai"}, err: errors.Error("Internal DB Error"),
-			mock: []interface{}{mock.ExpectExec(execError).WithArgs(2, 22, "Sai", err).
-				WillReturnError(errors.Error("Internal DB Error")),
+		{desc: "Success", input: input, event: event, output: output,
+			mock: []interface{}{mock.ExpectBegin(), mock.ExpectExec(exec).WithArgs(2, "Sai", "sai@example.com", 4, "Engineer",
+				1, "2021-06-01", model.StatusActive, "2000-03-01", now, "ram", now, "ram").WillReturnResult(sqlmock.NewResult(1, 1)),
+				mock.ExpectExec(insertEvent).WithArgs("e1", 2, model.EmployeeCreated, payload, now).
+					WillReturnResult(sqlmock.NewResult(1, 1)),
+				mock.ExpectCommit()}},
+		{desc: "Outbox failure", input: input, event: event, err: errors.Error("Internal DB Error"),
+			mock: []interface{}{mock.ExpectBegin(), mock.ExpectExec(exec).WillReturnResult(sqlmock.NewResult(1, 1)),
+				mock.ExpectExec(insertEvent).WillReturnError(errors.Error("connection reset")), mock.ExpectRollback()}},
+		{desc: "Failure", input: model.Employee{ID: 3, Name: "Kiran"}, err: errors.Error("Internal DB Error"),
+			mock: []interface{}{mock.ExpectBegin(), mock.ExpectExec(exec).WithArgs(3, "Kiran", "", nil, "", nil, nil,
+				model.Status(""), nil, now, "", now, "").WillReturnError(errors.Error("Internal DB Error")), mock.ExpectRollback(),
 			}},
+		{desc: "Duplicate", input: model.Employee{ID: 4, Name: "Gopal"}, err: domain.Conflict{Entity: "employee", Field: "id"},
+			mock: []interface{}{mock.ExpectBegin(), mock.ExpectExec(exec).WithArgs(4, "Gopal", "", nil, "", nil, nil,
+				model.Status(""), nil, now, "", now, "").WillReturnError(&pq.Error{Code: "23505", Constraint: "employee_pkey",
+				Detail: "Key (id)=(4) already exists."}), mock.ExpectRollback(),
+			}},
+		{desc: "Unknown manager", input: input, err: errors.InvalidParam{Param: []string{"manager_id"}},
+			mock: []interface{}{mock.ExpectBegin(), mock.ExpectExec(exec).WillReturnError(&pq.Error{Code: "23503",
+				Constraint: "employee_manager_id_fkey", Detail: `Key (manager_id)=(1) is not present in table "employee".`}),
+				mock.ExpectRollback()}},
+		{desc: "Begin failure", input: input, err: errors.Error("Internal DB Error"),
+			mock: []interface{}{mock.ExpectBegin().WillReturnError(errors.Error("connection reset"))}},
 	}
 
 	for i, tc := range testcases {
 		t.Run(tc.desc, func(t *testing.T) {
-			resp, err := dataStore.EmpCreate(cxt, tc.input)
+			resp, err := dataStore.EmpCreate(cxt, tc.input, tc.event)
 
 			if !reflect.DeepEqual(tc.output, resp) {
 				t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, resp)
@@ -170,31 +252,112 @@ func TestStore_EmpUpdate(t *testing.T) {
 
 	defer db.Close()
 
-	g := gofr.Gofr{DataStore: datastore.DataStore{ORM: db}}
+	created := time.Date(2022, time.January, 1, 10, 0, 0, 0, time.UTC)
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	dob := model.NewDate(2000, time.March, 1)
+	hired := model.NewDate(2021, time.June, 1)
+	dept, manager := 4, 9
+
+	g := gofr.Gofr{DataStore: datastore.DataStore{ORM: db}, Logger: log.NewMockLogger(io.Discard)}
 	cxt := gofr.NewContext(nil, nil, &g)
 	cxt.Context = context.Background()
-	dataStore := New()
+	dataStore := store{now: func() time.Time { return now }}
+
+	update := "update employee set name = $1,email = $2,department_id = $3,title = $4,manager_id = $5,hire_date = $6,status = $7," +
+		"date_of_birth = $8,updated_at = $9,updated_by = $10 where id = $11"
+	query := "select " + columns + " from employee where id = $1"
+	lock := "select manager_id from employee where id = $1 for update"
+
+	// deep locks a chain of managers from manager up that is longer than maxDepth
+	deep := func() []interface{} {
+		calls := []interface{}{mock.ExpectBegin()}
+		for i := 0; i < maxDepth; i++ {
+			calls = append(calls, mock.ExpectQuery(lock).WithArgs(manager+i).
+				WillReturnRows(sqlmock.NewRows([]string{"manager_id"}).AddRow(manager+i+1)))
+		}
+
+		return append(calls, mock.ExpectRollback())
+	}
 
-	update := "update employee set age = $1,name = $2 where id = $3"
-	updateError := "update set age = $1,name = $2,err = $3 where id = $4"
+	input := model.Employee{ID: 1, Name: "Ram", Email: "ram@example.com", DepartmentID: &dept, Title: "Director",
+		HireDate: hired, Status: model.StatusOnLeave, DateOfBirth: dob, UpdatedBy: "sai"}
+	output := input
+	output.Age, output.CreatedAt, output.CreatedBy, output.UpdatedAt = 22, created, "ram", now
+
+	event := &model.Event{ID: "e2", Type: model.EmployeeUpdated, SchemaVersion: 1, Principal: "sai"}
 
 	testcases := []struct {
 		desc   string
 		input  model.Employee
+		event  *model.Event
 		output model.Employee
 		err    error
 		mock   []interface{}
 	}{
-		{desc: "success", input: model.Employee{ID: 1, Age: 21, Name: "Ram"}, output: model.Employee{ID: 1, Age: 21, Name: "Ram"},
-			mock: []interface{}{mock.ExpectExec(update).WithArgs(21, "Ram", 1).WillReturnResult(sqlmock.NewResult(1, 1))}},
-		{desc: "Failure", input: model.Employee{ID: 2, Age: 22, Name: "Sai"}, err: errors.Error("Internal DB Error"),
-			mock: []interface{}{mock.ExpectExec(updateError).WithArgs(2, 22, "Sai", 2, err).WillReturnError(errors.Error("Internal DB Error"))},
+		{desc: "success", input: input, event: event, output: output,
+			mock: []interface{}{
+				mock.ExpectBegin(),
+				mock.ExpectExec(update).WithArgs("Ram", "ram@example.com", 4, "Director", nil, "2021-06-01",
+					model.StatusOnLeave, "2000-03-01", now, "sai", 1).WillReturnResult(sqlmock.NewResult(1, 1)),
+				mock.ExpectQuery(query).WithArgs(1).WillReturnRows(sqlmock.NewRows(columnNames()).AddRow(1, "Ram", "ram@example.com",
+					4, "Director", nil, hired.Time, "on_leave", dob.Time, created, "ram", now, "sai")),
+				mock.ExpectExec(insertEvent).WithArgs("e2", 1, model.EmployeeUpdated, eventPayload(*event, output), now).
+					WillReturnResult(sqlmock.NewResult(1, 1)),
+				mock.ExpectCommit(),
+			}},
+		{desc: "Failure", input: model.Employee{ID: 2, Name: "Sai"}, err: errors.Error("Internal DB Error"),
+			mock: []interface{}{mock.ExpectBegin(), mock.ExpectExec(update).WithArgs("Sai", "", nil, "", nil, nil,
+				model.Status(""), nil, now, "", 2).WillReturnError(errors.Error("Internal DB Error")), mock.ExpectRollback()},
+		},
+		{desc: "Manager", input: model.Employee{ID: 5, Name: "Sai", ManagerID: &manager, UpdatedBy: "sai"},
+			output: model.Employee{ID: 5, Name: "Sai", ManagerID: &manager, Status: model.StatusActive, CreatedAt: created,
+				CreatedBy: "ram", UpdatedAt: now, UpdatedBy: "sai"},
+			mock: []interface{}{mock.ExpectBegin(),
+				mock.ExpectQuery(lock).WithArgs(9).WillReturnRows(sqlmock.NewRows([]string{"manager_id"}).AddRow(2)),
+				mock.ExpectQuery(lock).WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"manager_id"}).AddRow(nil)),
+				mock.ExpectExec(update).WillReturnResult(sqlmock.NewResult(1, 1)),
+				mock.ExpectQuery(query).WithArgs(5).WillReturnRows(sqlmock.NewRows(columnNames()).AddRow(5, "Sai", nil,
+					nil, "", 9, nil, "active", nil, created, "ram", now, "sai")),
+				mock.ExpectCommit()},
+		},
+		{desc: "Cycle", input: model.Employee{ID: 5, Name: "Sai", ManagerID: &manager},
+			err: errors.InvalidParam{Param: []string{"manager_id"}}, mock: []interface{}{mock.ExpectBegin(),
+				mock.ExpectQuery(lock).WithArgs(9).WillReturnRows(sqlmock.NewRows([]string{"manager_id"}).AddRow(5)),
+				mock.ExpectRollback()},
+		},
+		{desc: "Unknown manager", input: model.Employee{ID: 5, Name: "Sai", ManagerID: &manager},
+			err: errors.InvalidParam{Param: []string{"manager_id"}}, mock: []interface{}{mock.ExpectBegin(),
+				mock.ExpectQuery(lock).WithArgs(9).WillReturnRows(sqlmock.NewRows([]string{"manager_id"})),
+				mock.ExpectRollback()},
+		},
+		{desc: "Chain too deep", input: model.Employee{ID: 5, Name: "Sai", ManagerID: &manager},
+			err: domain.Conflict{Entity: "employee", Field: "manager_id",
+				Reason: "the manager has more than 64 levels of managers above it"}, mock: deep(),
+		},
+		{desc: "Lock failure", input: model.Employee{ID: 5, Name: "Sai", ManagerID: &manager},
+			err: errors.Error("Internal DB Error"), mock: []interface{}{mock.ExpectBegin(),
+				mock.ExpectQuery(lock).WillReturnError(errors.Error("deadlock detected")), mock.ExpectRollback()},
+		},
+		{desc: "Unknown department", input: model.Employee{ID: 5, Name: "Sai", DepartmentID: &dept},
+			err: errors.InvalidParam{Param: []string{"department_id"}}, mock: []interface{}{mock.ExpectBegin(),
+				mock.ExpectExec(update).WillReturnError(&pq.Error{Code: "23503", Constraint: "employee_department_id_fkey"}),
+				mock.ExpectRollback()},
+		},
+		{desc: "NotFound", input: model.Employee{ID: 3, Name: "Kiran"}, err: errors.EntityNotFound{Entity: "employee", ID: "3"},
+			mock: []interface{}{mock.ExpectBegin(), mock.ExpectExec(update).WithArgs("Kiran", "", nil, "", nil, nil,
+				model.Status(""), nil, now, "", 3).WillReturnResult(sqlmock.NewResult(0, 0)), mock.ExpectRollback()},
+		},
+		{desc: "Commit failure", input: model.Employee{ID: 4, Name: "Gopal"}, err: errors.Error("Internal DB Error"),
+			mock: []interface{}{mock.ExpectBegin(), mock.ExpectExec(update).WillReturnResult(sqlmock.NewResult(1, 1)),
+				mock.ExpectQuery(query).WithArgs(4).WillReturnRows(sqlmock.NewRows(columnNames()).AddRow(4, "Gopal", nil,
+					nil, "", nil, nil, "active", nil, created, "ram", now, "")),
+				mock.ExpectCommit().WillReturnError(errors.Error("connection reset"))},
 		},
 	}
 
 	for i, tc := range testcases {
 		t.Run(tc.desc, func(t *testing.T) {
-			resp, err := dataStore.EmpUpdate(cxt, tc.input)
+			resp, err := dataStore.EmpUpdate(cxt, tc.input, tc.event)
 
 			if !reflect.DeepEqual(tc.output, resp) {
 				t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, resp)
@@ -206,3 +369,115 @@ func TestStore_EmpUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestStore_Hierarchy(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+
+	if err != nil {
+		t.Errorf("Database error %v", err)
+	}
+
+	defer db.Close()
+
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	manager := 1
+
+	g := gofr.Gofr{DataStore: datastore.DataStore{ORM: db}, Logger: log.NewMockLogger(io.Discard)}
+	cxt := gofr.NewContext(nil, nil, &g)
+	cxt.Context = context.Background()
+	dataStore := store{now: func() time.Time { return now }}
+
+	subtree := `with recursive subtree(id, depth) as (
+			select id, 1 from employee where manager_id = $1
+			union all
+			select e.id, t.depth + 1 from employee e join subtree t on e.manager_id = t.id where t.depth < $2
+		)
+		select ` + qualified("e") + ` from subtree t join employee e on e.id = t.id order by t.depth, e.id`
+	chain := `with recursive chain(id, depth) as (
+			select manager_id, 1 from employee where id = $1 and manager_id is not null
+			union all
+			select e.manager_id, c.depth + 1 from employee e join chain c on e.id = c.id
+			where e.manager_id is not null and c.depth < $2
+		)
+		select ` + qualified("e") + ` from chain c join employee e on e.id = c.id order by c.depth`
+
+	testcases := []struct {
+		desc   string
+		get    func(*gofr.Context, int) ([]model.Employee, error)
+		id     int
+		output []model.Employee
+		err    error
+		mock   []interface{}
+	}{
+		{"subtree", dataStore.EmpSubtree, 1, []model.Employee{{ID: 2, Name: "Sai", ManagerID: &manager, Status: model.StatusActive,
+			CreatedAt: now, UpdatedAt: now}}, nil, []interface{}{
+			mock.ExpectQuery(subtree).WithArgs(1, maxDepth).WillReturnRows(sqlmock.NewRows(columnNames()).
+				AddRow(2, "Sai", nil, nil, "", 1, nil, "active", nil, now, "", now, "")),
+		}},
+		{"subtree failure", dataStore.EmpSubtree, 2, nil, errors.DB{Err: errors.Error("Internal DB error")}, []interface{}{
+			mock.ExpectQuery(subtree).WithArgs(2, maxDepth).WillReturnError(errors.Error("connection refused")),
+		}},
+		{"chain", dataStore.EmpChain, 2, []model.Employee{{ID: 1, Name: "Ram", Status: model.StatusActive, CreatedAt: now,
+			UpdatedAt: now}}, nil, []interface{}{
+			mock.ExpectQuery(chain).WithArgs(2, maxDepth).WillReturnRows(sqlmock.NewRows(columnNames()).
+				AddRow(1, "Ram", nil, nil, "", nil, nil, "active", nil, now, "", now, "")),
+		}},
+		{"chain of root", dataStore.EmpChain, 1, nil, nil, []interface{}{
+			mock.ExpectQuery(chain).WithArgs(1, maxDepth).WillReturnRows(sqlmock.NewRows(columnNames())),
+		}},
+	}
+
+	for i, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := tc.get(cxt, tc.id)
+
+			if !reflect.DeepEqual(tc.output, resp) {
+				t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, resp)
+			}
+
+			if !reflect.DeepEqual(tc.err, err) {
+				t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+			}
+		})
+	}
+}
+
+func TestStore_EmpPing(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("database error %s", err)
+	}
+
+	defer db.Close()
+
+	g := gofr.Gofr{DataStore: datastore.DataStore{ORM: db}, Logger: log.NewMockLogger(io.Discard)}
+	ctx := gofr.NewContext(nil, nil, &g)
+	ctx.Context = context.Background()
+
+	testcases := []struct {
+		desc string
+		err  error
+		mock *sqlmock.ExpectedPing
+	}{
+		{"up", nil, mock.ExpectPing()},
+		{"down", errors.DB{Err: errors.Error("connection refused")},
+			mock.ExpectPing().WillReturnError(errors.Error("connection refused"))},
+	}
+
+	for i, tc := range testcases {
+		if err := New().EmpPing(ctx); !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+const insertEvent = "insert into outbox(event_id,employee_id,type,payload,next_attempt_at,created_at) " +
+	"VALUES ($1,$2,$3,$4,$5,$5)"
+
+// eventPayload returns the outbox payload of the event completed with the employee.
+func eventPayload(event model.Event, e model.Employee) string {
+	event.Employee = e
+	b, _ := json.Marshal(event)
+
+	return string(b)
+}
@@ -0,0 +1,28 @@
+package events
+
+import (
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/datastore"
+	"example/model"
+)
+
+type fanout []datastore.Publisher
+
+// NewFanout returns a publisher handing every event to each of publishers. It fails when any of them fails, after
+// trying them all, so the event is published again to every one of them: publishers must tolerate duplicates.
+func NewFanout(publishers ...datastore.Publisher) fanout {
+	return publishers
+}
+
+func (f fanout) Publish(ctx *gofr.Context, event model.Event) error {
+	var failed error
+
+	for _, p := range f {
+		if err := p.Publish(ctx, event); err != nil && failed == nil {
+			failed = err
+		}
+	}
+
+	return failed
+}
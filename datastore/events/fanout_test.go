@@ -0,0 +1,41 @@
+package events
+
+import (
+	"reflect"
+	"testing"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"github.com/golang/mock/gomock"
+
+	"example/datastore/mocks"
+	"example/model"
+)
+
+func TestFanout_Publish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	first, second := mocks.NewMockPublisher(ctrl), mocks.NewMockPublisher(ctrl)
+	ctx := gofr.NewContext(nil, nil, gofr.New())
+	event := model.Event{ID: "e1"}
+
+	testcases := []struct {
+		desc string
+		err  error
+		mock []*gomock.Call
+	}{
+		{"success", nil, []*gomock.Call{
+			first.EXPECT().Publish(ctx, event).Return(nil),
+			second.EXPECT().Publish(ctx, event).Return(nil),
+		}},
+		{"failure", errors.Error("unreachable"), []*gomock.Call{
+			first.EXPECT().Publish(ctx, event).Return(errors.Error("unreachable")),
+			second.EXPECT().Publish(ctx, event).Return(errors.Error("timeout")),
+		}},
+	}
+
+	for i, tc := range testcases {
+		if err := NewFanout(first, second).Publish(ctx, event); !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
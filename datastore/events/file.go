@@ -0,0 +1,45 @@
+package events
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/model"
+)
+
+type file struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+// NewFile returns a publisher appending the events it is given to the file at path, one JSON document per line.
+func NewFile(path string) (*file, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &file{f: f}, nil
+}
+
+func (p *file) Publish(_ *gofr.Context, event model.Event) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, err = p.f.Write(append(b, '\n'))
+
+	return err
+}
+
+// Close closes the file.
+func (p *file) Close() error {
+	return p.f.Close()
+}
@@ -0,0 +1,64 @@
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/model"
+)
+
+func TestFile_Publish(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	ctx := gofr.NewContext(nil, nil, gofr.New())
+	published := []model.Event{
+		{ID: "e1", Type: model.EmployeeCreated, SchemaVersion: 1, Employee: model.Employee{ID: 7, Name: "Ram"}},
+		{ID: "e2", Type: model.EmployeeUpdated, SchemaVersion: 1, Employee: model.Employee{ID: 7, Name: "Sai"}},
+	}
+
+	// events are appended across restarts
+	for i, e := range published {
+		p, err := NewFile(path)
+		if err != nil {
+			t.Fatalf("[Test %v]Failed. Expected no error but got %v", i+1, err)
+		}
+
+		if err := p.Publish(ctx, e); err != nil {
+			t.Errorf("[Test %v]Failed. Expected no error but got %v", i+1, err)
+		}
+
+		_ = p.Close()
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("[Test %v]Failed. Expected no error but got %v", 3, err)
+	}
+	defer f.Close()
+
+	var read []model.Event
+
+	for s := bufio.NewScanner(f); s.Scan(); {
+		var e model.Event
+		if err := json.Unmarshal(s.Bytes(), &e); err != nil {
+			t.Fatalf("[Test %v]Failed. Expected a JSON line but got %s", 4, s.Bytes())
+		}
+
+		read = append(read, e)
+	}
+
+	if !reflect.DeepEqual(published, read) {
+		t.Errorf("[Test %v]Failed. Expected %v but got %v", 5, published, read)
+	}
+}
+
+func TestNewFile_Error(t *testing.T) {
+	if _, err := NewFile(filepath.Join(t.TempDir(), "missing", "events.jsonl")); err == nil {
+		t.Errorf("[Test %v]Failed. Expected an error for a missing directory", 1)
+	}
+}
@@ -0,0 +1,63 @@
+package events
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"github.com/Shopify/sarama"
+
+	"example/model"
+)
+
+type kafka struct {
+	producer sarama.SyncProducer
+	topic    string
+}
+
+// NewKafka returns a publisher producing the events it is given to topic on the Kafka cluster of brokers. An event
+// is acknowledged once every in-sync replica has it.
+func NewKafka(brokers []string, topic string) (kafka, error) {
+	cfg := sarama.NewConfig()
+	cfg.Producer.RequiredAcks = sarama.WaitForAll
+	cfg.Producer.Return.Successes = true
+
+	producer, err := sarama.NewSyncProducer(brokers, cfg)
+	if err != nil {
+		return kafka{}, err
+	}
+
+	return newKafka(producer, topic), nil
+}
+
+func newKafka(producer sarama.SyncProducer, topic string) kafka {
+	return kafka{producer: producer, topic: topic}
+}
+
+// Publish produces the event as JSON, keyed by the id of its employee so that the events of an employee land on the
+// same partition and are consumed in order. Its type and schema version are also sent as headers, letting consumers
+// skip events without decoding them.
+func (k kafka) Publish(_ *gofr.Context, event model.Event) error {
+	value, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = k.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: k.topic,
+		Key:   sarama.StringEncoder(strconv.Itoa(event.Employee.ID)),
+		Value: sarama.ByteEncoder(value),
+		Headers: []sarama.RecordHeader{
+			{Key: []byte("id"), Value: []byte(event.ID)},
+			{Key: []byte("type"), Value: []byte(event.Type)},
+			{Key: []byte("schema_version"), Value: []byte(strconv.Itoa(event.SchemaVersion))},
+		},
+	})
+
+	return err
+}
+
+// Close flushes and closes the producer.
+func (k kafka) Close() error {
+	return k.producer.Close()
+}
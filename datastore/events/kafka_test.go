@@ -0,0 +1,83 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+
+	"example/model"
+)
+
+func TestKafka_Publish(t *testing.T) {
+	event := model.Event{ID: "e1", Type: model.EmployeeUpdated, SchemaVersion: 1, Employee: model.Employee{ID: 7}}
+	value, _ := json.Marshal(event)
+
+	testcases := []struct {
+		desc string
+		err  error
+	}{
+		{"success", nil},
+		{"failure", sarama.ErrNotLeaderForPartition},
+	}
+
+	check := func(b []byte) error {
+		if !reflect.DeepEqual(value, b) {
+			return errors.New("unexpected value " + string(b))
+		}
+
+		return nil
+	}
+
+	for i, tc := range testcases {
+		producer := mocks.NewSyncProducer(t, nil)
+		if tc.err == nil {
+			producer.ExpectSendMessageWithCheckerFunctionAndSucceed(check)
+		} else {
+			producer.ExpectSendMessageWithCheckerFunctionAndFail(check, tc.err)
+		}
+
+		err := newKafka(producer, "employee-events").Publish(gofr.NewContext(nil, nil, gofr.New()), event)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+
+		_ = producer.Close()
+	}
+}
+
+type recordingProducer struct {
+	sarama.SyncProducer
+	msg *sarama.ProducerMessage
+}
+
+func (p *recordingProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	p.msg = msg
+	return 0, 0, nil
+}
+
+func TestKafka_Message(t *testing.T) {
+	p := &recordingProducer{}
+	event := model.Event{ID: "e1", Type: model.EmployeeCreated, SchemaVersion: 1, Employee: model.Employee{ID: 7}}
+
+	if err := newKafka(p, "employee-events").Publish(gofr.NewContext(nil, nil, gofr.New()), event); err != nil {
+		t.Fatalf("[Test %v]Failed. Expected no error but got %v", 1, err)
+	}
+
+	key, _ := p.msg.Key.Encode()
+	headers := map[string]string{}
+
+	for _, h := range p.msg.Headers {
+		headers[string(h.Key)] = string(h.Value)
+	}
+
+	expected := map[string]string{"id": "e1", "type": "EmployeeCreated", "schema_version": "1"}
+	if p.msg.Topic != "employee-events" || string(key) != "7" || !reflect.DeepEqual(expected, headers) {
+		t.Errorf("[Test %v]Failed. Expected employee-events 7 %v but got %v %s %v", 2, expected, p.msg.Topic, key,
+			headers)
+	}
+}
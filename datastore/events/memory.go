@@ -0,0 +1,49 @@
+// Package events implements datastore.Publisher, publishing the events of employee changes to Kafka, or to a file or
+// memory for local runs.
+package events
+
+import (
+	"sync"
+
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/model"
+)
+
+type memory struct {
+	mu     sync.Mutex
+	events []model.Event
+}
+
+// NewMemory returns a publisher keeping the events it is given in memory, in the order they were published.
+func NewMemory() *memory {
+	return &memory{}
+}
+
+func (m *memory) Publish(_ *gofr.Context, event model.Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.events = append(m.events, event)
+
+	return nil
+}
+
+// Events returns the events published so far.
+func (m *memory) Events() []model.Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]model.Event(nil), m.events...)
+}
+
+type discard struct{}
+
+// NewDiscard returns a publisher dropping every event, for deployments without consumers.
+func NewDiscard() discard {
+	return discard{}
+}
+
+func (discard) Publish(*gofr.Context, model.Event) error {
+	return nil
+}
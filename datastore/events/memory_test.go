@@ -0,0 +1,30 @@
+package events
+
+import (
+	"reflect"
+	"testing"
+
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/model"
+)
+
+func TestMemory_Publish(t *testing.T) {
+	m := NewMemory()
+	ctx := gofr.NewContext(nil, nil, gofr.New())
+	published := []model.Event{{ID: "e1"}, {ID: "e2"}}
+
+	for _, e := range published {
+		_ = m.Publish(ctx, e)
+	}
+
+	events := m.Events()
+	if !reflect.DeepEqual(published, events) {
+		t.Errorf("[Test %v]Failed. Expected %v but got %v", 1, published, events)
+	}
+
+	events[0].ID = "changed"
+	if m.Events()[0].ID != "e1" {
+		t.Errorf("[Test %v]Failed. Expected Events to return a copy", 2)
+	}
+}
@@ -0,0 +1,124 @@
+package idempotency
+
+import (
+	"database/sql"
+	"time"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/datastore/sqlerr"
+	"example/model"
+)
+
+type store struct {
+	now func() time.Time
+}
+
+func New() store {
+	return store{now: time.Now}
+}
+
+// timestamp returns the current time at the microsecond precision the database keeps.
+func (s store) timestamp() time.Time {
+	return s.now().UTC().Truncate(time.Microsecond)
+}
+
+func (s store) KeyGet(ctx *gofr.Context, principal, id string) (model.IdempotencyKey, error) {
+	var (
+		k        model.IdempotencyKey
+		response sql.NullString
+	)
+
+	row := ctx.DB().DB.QueryRow("select principal,id,request_hash,response,created_at from idempotency_key "+
+		"where principal = $1 and id = $2", principal, id)
+
+	err := row.Scan(&k.Principal, &k.ID, &k.RequestHash, &response, &k.CreatedAt)
+
+	if err == sql.ErrNoRows {
+		return model.IdempotencyKey{}, errors.EntityNotFound{Entity: "idempotency key", ID: id}
+	}
+
+	if err != nil {
+		return model.IdempotencyKey{}, errors.Error("Scan Error")
+	}
+
+	if response.Valid {
+		k.Response = []byte(response.String)
+	}
+
+	return k, nil
+}
+
+// KeyCreate reserves the key for a request in progress. It returns a domain.Conflict when the key is already taken.
+func (s store) KeyCreate(ctx *gofr.Context, key model.IdempotencyKey) error {
+	_, err := ctx.DB().DB.Exec("insert into idempotency_key(principal,id,request_hash,created_at) VALUES ($1,$2,$3,$4)",
+		key.Principal, key.ID, key.RequestHash, s.timestamp())
+
+	if c, ok := sqlerr.AsConflict(err, "idempotency key", "id"); ok {
+		return c
+	}
+
+	if err != nil {
+		return errors.Error("Internal DB Error")
+	}
+
+	return nil
+}
+
+// KeyUpdate records the response of the request the key was reserved for.
+func (s store) KeyUpdate(ctx *gofr.Context, key model.IdempotencyKey) error {
+	_, err := ctx.DB().DB.Exec("update idempotency_key set response = $1 where principal = $2 and id = $3",
+		string(key.Response), key.Principal, key.ID)
+
+	if err != nil {
+		return errors.Error("Internal DB Error")
+	}
+
+	return nil
+}
+
+// KeyReplace takes over an expired key, which old was read as, for a new request. It returns false when another
+// request took the key over, or finished with it, since old was read.
+func (s store) KeyReplace(ctx *gofr.Context, old, key model.IdempotencyKey) (bool, error) {
+	res, err := ctx.DB().DB.Exec("update idempotency_key set request_hash = $1,response = null,created_at = $2 "+
+		"where principal = $3 and id = $4 and created_at = $5", key.RequestHash, s.timestamp(), key.Principal, key.ID,
+		old.CreatedAt)
+
+	if err != nil {
+		return false, errors.Error("Internal DB Error")
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Error("Internal DB Error")
+	}
+
+	return n > 0, nil
+}
+
+// KeyPurge deletes the keys created before before, whether their request finished or not.
+func (s store) KeyPurge(ctx *gofr.Context, before time.Time) (int64, error) {
+	res, err := ctx.DB().DB.Exec("delete from idempotency_key where created_at < $1", before.UTC())
+
+	if err != nil {
+		return 0, errors.Error("Internal DB Error")
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Error("Internal DB Error")
+	}
+
+	return n, nil
+}
+
+func (s store) KeyDelete(ctx *gofr.Context, principal, id string) error {
+	_, err := ctx.DB().DB.Exec("delete from idempotency_key where principal = $1 and id = $2", principal, id)
+
+	if err != nil {
+		return errors.Error("Internal DB Error")
+	}
+
+	return nil
+}
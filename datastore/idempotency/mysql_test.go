@@ -0,0 +1,182 @@
+package idempotency
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+
+	"developer.zopsmart.com/go/gofr/pkg/datastore"
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/domain"
+	"example/model"
+)
+
+func newContext(t *testing.T) (*gofr.Context, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("database error %s", err)
+	}
+
+	g := gofr.Gofr{DataStore: datastore.DataStore{ORM: db}}
+	ctx := gofr.NewContext(nil, nil, &g)
+	ctx.Context = context.Background()
+
+	return ctx, mock, func() { db.Close() }
+}
+
+func TestStore_KeyGet(t *testing.T) {
+	ctx, mock, done := newContext(t)
+	defer done()
+
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	query := "select principal,id,request_hash,response,created_at from idempotency_key where principal = $1 and id = $2"
+	columns := []string{"principal", "id", "request_hash", "response", "created_at"}
+
+	testcases := []struct {
+		desc   string
+		id     string
+		output model.IdempotencyKey
+		err    error
+		mock   []interface{}
+	}{
+		{"completed", "a", model.IdempotencyKey{Principal: "ram", ID: "a", RequestHash: "h", Response: []byte(`{"id":1}`),
+			CreatedAt: now}, nil, []interface{}{
+			mock.ExpectQuery(query).WithArgs("ram", "a").WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("ram", "a", "h", `{"id":1}`, now)),
+		}},
+		{"in progress", "b", model.IdempotencyKey{Principal: "ram", ID: "b", RequestHash: "h", CreatedAt: now}, nil,
+			[]interface{}{mock.ExpectQuery(query).WithArgs("ram", "b").WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("ram", "b", "h", nil, now))}},
+		{"not found", "c", model.IdempotencyKey{}, errors.EntityNotFound{Entity: "idempotency key", ID: "c"}, []interface{}{
+			mock.ExpectQuery(query).WithArgs("ram", "c").WillReturnRows(sqlmock.NewRows(columns)),
+		}},
+		{"failure", "d", model.IdempotencyKey{}, errors.Error("Scan Error"), []interface{}{
+			mock.ExpectQuery(query).WithArgs("ram", "d").WillReturnError(errors.Error("connection refused")),
+		}},
+	}
+
+	for i, tc := range testcases {
+		resp, err := New().KeyGet(ctx, "ram", tc.id)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestStore_KeyWrites(t *testing.T) {
+	ctx, mock, done := newContext(t)
+	defer done()
+
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	s := store{now: func() time.Time { return now }}
+	key := model.IdempotencyKey{Principal: "ram", ID: "a", RequestHash: "h", Response: []byte(`{"id":1}`)}
+	insert := "insert into idempotency_key(principal,id,request_hash,created_at) VALUES ($1,$2,$3,$4)"
+	update := "update idempotency_key set response = $1 where principal = $2 and id = $3"
+	del := "delete from idempotency_key where principal = $1 and id = $2"
+
+	testcases := []struct {
+		desc  string
+		write func(*gofr.Context, model.IdempotencyKey) error
+		err   error
+		mock  []interface{}
+	}{
+		{"create", s.KeyCreate, nil, []interface{}{
+			mock.ExpectExec(insert).WithArgs("ram", "a", "h", now).WillReturnResult(sqlmock.NewResult(0, 1)),
+		}},
+		{"create taken", s.KeyCreate, domain.Conflict{Entity: "idempotency key", Field: "id"}, []interface{}{
+			mock.ExpectExec(insert).WithArgs("ram", "a", "h", now).
+				WillReturnError(&pq.Error{Code: "23505", Constraint: "idempotency_key_pkey"}),
+		}},
+		{"create failure", s.KeyCreate, errors.Error("Internal DB Error"), []interface{}{
+			mock.ExpectExec(insert).WithArgs("ram", "a", "h", now).WillReturnError(errors.Error("connection refused")),
+		}},
+		{"update", s.KeyUpdate, nil, []interface{}{
+			mock.ExpectExec(update).WithArgs(`{"id":1}`, "ram", "a").WillReturnResult(sqlmock.NewResult(0, 1)),
+		}},
+		{"update failure", s.KeyUpdate, errors.Error("Internal DB Error"), []interface{}{
+			mock.ExpectExec(update).WithArgs(`{"id":1}`, "ram", "a").WillReturnError(errors.Error("connection refused")),
+		}},
+		{"delete", func(ctx *gofr.Context, k model.IdempotencyKey) error { return s.KeyDelete(ctx, k.Principal, k.ID) },
+			nil, []interface{}{mock.ExpectExec(del).WithArgs("ram", "a").WillReturnResult(sqlmock.NewResult(0, 1))}},
+	}
+
+	for i, tc := range testcases {
+		err := tc.write(ctx, key)
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestStore_KeyReplace(t *testing.T) {
+	ctx, mock, done := newContext(t)
+	defer done()
+
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	s := store{now: func() time.Time { return now }}
+	old := model.IdempotencyKey{Principal: "ram", ID: "a", RequestHash: "h", CreatedAt: now.Add(-time.Hour)}
+	key := model.IdempotencyKey{Principal: "ram", ID: "a", RequestHash: "h2"}
+	update := "update idempotency_key set request_hash = $1,response = null,created_at = $2 where principal = $3 " +
+		"and id = $4 and created_at = $5"
+
+	testcases := []struct {
+		desc     string
+		replaced bool
+		err      error
+		mock     []interface{}
+	}{
+		{"replaced", true, nil, []interface{}{mock.ExpectExec(update).WithArgs("h2", now, "ram", "a", old.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))}},
+		{"changed since read", false, nil, []interface{}{mock.ExpectExec(update).
+			WithArgs("h2", now, "ram", "a", old.CreatedAt).WillReturnResult(sqlmock.NewResult(0, 0))}},
+		{"failure", false, errors.Error("Internal DB Error"), []interface{}{mock.ExpectExec(update).
+			WillReturnError(errors.Error("connection refused"))}},
+	}
+
+	for i, tc := range testcases {
+		replaced, err := s.KeyReplace(ctx, old, key)
+
+		if replaced != tc.replaced || !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v %v but got %v %v", i+1, tc.replaced, tc.err, replaced, err)
+		}
+	}
+}
+
+func TestStore_KeyPurge(t *testing.T) {
+	ctx, mock, done := newContext(t)
+	defer done()
+
+	before := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	del := "delete from idempotency_key where created_at < $1"
+
+	testcases := []struct {
+		desc   string
+		purged int64
+		err    error
+		mock   []interface{}
+	}{
+		{"purged", 3, nil, []interface{}{mock.ExpectExec(del).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 3))}},
+		{"failure", 0, errors.Error("Internal DB Error"), []interface{}{mock.ExpectExec(del).
+			WillReturnError(errors.Error("connection refused"))}},
+	}
+
+	for i, tc := range testcases {
+		purged, err := New().KeyPurge(ctx, before)
+
+		if purged != tc.purged || !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v %v but got %v %v", i+1, tc.purged, tc.err, purged, err)
+		}
+	}
+}
@@ -1,14 +1,83 @@
 package datastore
 
 import (
+	"time"
+
 	"developer.zopsmart.com/go/gofr/pkg/gofr"
 
 	"example/model"
 )
 
+// EmpStore keeps the employees. EmpCreate and EmpUpdate add the event of the change, completed with the employee as
+// stored, to the outbox in the transaction making the change. A nil event records none.
 type EmpStore interface {
-	EmpGet(ctx *gofr.Context) ([]model.Employee, error)
+	EmpGet(ctx *gofr.Context, filter model.Filter) ([]model.Employee, error)
 	EmpGetByID(ctx *gofr.Context, id int) (model.Employee, error)
-	EmpCreate(ctx *gofr.Context, employee model.Employee) (model.Employee, error)
-	EmpUpdate(ctx *gofr.Context, employee model.Employee) (model.Employee, error)
+	EmpCreate(ctx *gofr.Context, employee model.Employee, event *model.Event) (model.Employee, error)
+	EmpUpdate(ctx *gofr.Context, employee model.Employee, event *model.Event) (model.Employee, error)
+	EmpSubtree(ctx *gofr.Context, id int) ([]model.Employee, error)
+	EmpChain(ctx *gofr.Context, id int) ([]model.Employee, error)
+	EmpPing(ctx *gofr.Context) error
+}
+
+type DeptStore interface {
+	DeptGet(ctx *gofr.Context) ([]model.Department, error)
+	DeptGetByID(ctx *gofr.Context, id int) (model.Department, error)
+	DeptCreate(ctx *gofr.Context, department model.Department) (model.Department, error)
+	DeptUpdate(ctx *gofr.Context, department model.Department) (model.Department, error)
+	DeptDelete(ctx *gofr.Context, id int) error
+}
+
+// IdempotencyStore keeps the Idempotency-Key of requests with their responses. KeyReplace takes an expired key over
+// for a new request, and reports false when it changed since it was read. KeyPurge deletes the keys created before a
+// time.
+type IdempotencyStore interface {
+	KeyGet(ctx *gofr.Context, principal, id string) (model.IdempotencyKey, error)
+	KeyCreate(ctx *gofr.Context, key model.IdempotencyKey) error
+	KeyUpdate(ctx *gofr.Context, key model.IdempotencyKey) error
+	KeyDelete(ctx *gofr.Context, principal, id string) error
+	KeyReplace(ctx *gofr.Context, old, key model.IdempotencyKey) (bool, error)
+	KeyPurge(ctx *gofr.Context, before time.Time) (int64, error)
+}
+
+// OutboxStore keeps the events of employee changes until the outbox relay has delivered them.
+type OutboxStore interface {
+	OutboxClaim(ctx *gofr.Context, limit int, lease time.Duration) ([]model.OutboxEvent, error)
+	OutboxDelivered(ctx *gofr.Context, id int64) error
+	OutboxRetry(ctx *gofr.Context, id int64, at time.Time, reason string) error
+	OutboxDead(ctx *gofr.Context, id int64, reason string) error
+}
+
+// WebhookStore keeps the webhook subscriptions and the log of the deliveries to them.
+type WebhookStore interface {
+	WebhookGet(ctx *gofr.Context) ([]model.Webhook, error)
+	WebhookGetByID(ctx *gofr.Context, id int) (model.Webhook, error)
+	WebhookCreate(ctx *gofr.Context, webhook model.Webhook) (model.Webhook, error)
+	WebhookUpdate(ctx *gofr.Context, webhook model.Webhook) (model.Webhook, error)
+	WebhookDelete(ctx *gofr.Context, id int) error
+	DeliveryGet(ctx *gofr.Context, webhookID int, status model.DeliveryStatus, limit int) ([]model.Delivery, error)
+	DeliveryGetByID(ctx *gofr.Context, webhookID int, id int64) (model.Delivery, error)
+	DeliveryReplay(ctx *gofr.Context, webhookID int, id int64) (model.Delivery, error)
+}
+
+// DeliveryStore hands the due webhook deliveries to the delivery worker and records the outcome of every attempt.
+type DeliveryStore interface {
+	DeliveryClaim(ctx *gofr.Context, limit int, lease time.Duration) ([]model.Delivery, error)
+	DeliverySucceeded(ctx *gofr.Context, id int64, status int) error
+	DeliveryRetry(ctx *gofr.Context, id int64, at time.Time, status *int, reason string) error
+	DeliveryFailed(ctx *gofr.Context, id int64, status *int, reason string) error
+}
+
+// Publisher delivers the events of employee changes to the services reacting to them.
+type Publisher interface {
+	Publish(ctx *gofr.Context, event model.Event) error
+}
+
+// Cache is a key value store used to keep copies of datastore reads. A zero ttl means the entry never expires.
+// Add stores the value only when the key is absent, and reports whether it did.
+type Cache interface {
+	Get(ctx *gofr.Context, key string) ([]byte, bool)
+	Set(ctx *gofr.Context, key string, value []byte, ttl time.Duration) error
+	Add(ctx *gofr.Context, key string, value []byte, ttl time.Duration) (bool, error)
+	Delete(ctx *gofr.Context, key string) error
 }
@@ -7,6 +7,7 @@ package mocks
 import (
 	model "example/model"
 	reflect "reflect"
+	time "time"
 
 	gofr "developer.zopsmart.com/go/gofr/pkg/gofr"
 	gomock "github.com/golang/mock/gomock"
@@ -35,34 +36,49 @@ func (m *MockEmpStore) EXPECT() *MockEmpStoreMockRecorder {
 	return m.recorder
 }
 
+// EmpChain mocks base method.
+func (m *MockEmpStore) EmpChain(ctx *gofr.Context, id int) ([]model.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EmpChain", ctx, id)
+	ret0, _ := ret[0].([]model.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EmpChain indicates an expected call of EmpChain.
+func (mr *MockEmpStoreMockRecorder) EmpChain(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmpChain", reflect.TypeOf((*MockEmpStore)(nil).EmpChain), ctx, id)
+}
+
 // EmpCreate mocks base method.
-func (m *MockEmpStore) EmpCreate(ctx *gofr.Context, employee model.Employee) (model.Employee, error) {
+func (m *MockEmpStore) EmpCreate(ctx *gofr.Context, employee model.Employee, event *model.Event) (model.Employee, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EmpCreate", ctx, employee)
+	ret := m.ctrl.Call(m, "EmpCreate", ctx, employee, event)
 	ret0, _ := ret[0].(model.Employee)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // EmpCreate indicates an expected call of EmpCreate.
-func (mr *MockEmpStoreMockRecorder) EmpCreate(ctx, employee interface{}) *gomock.Call {
+func (mr *MockEmpStoreMockRecorder) EmpCreate(ctx, employee, event interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmpCreate", reflect.TypeOf((*MockEmpStore)(nil).EmpCreate), ctx, employee)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmpCreate", reflect.TypeOf((*MockEmpStore)(nil).EmpCreate), ctx, employee, event)
 }
 
 // EmpGet mocks base method.
-func (m *MockEmpStore) EmpGet(ctx *gofr.Context) ([]model.Employee, error) {
+func (m *MockEmpStore) EmpGet(ctx *gofr.Context, filter model.Filter) ([]model.Employee, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EmpGet", ctx)
+	ret := m.ctrl.Call(m, "EmpGet", ctx, filter)
 	ret0, _ := ret[0].([]model.Employee)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // EmpGet indicates an expected call of EmpGet.
-func (mr *MockEmpStoreMockRecorder) EmpGet(ctx interface{}) *gomock.Call {
+func (mr *MockEmpStoreMockRecorder) EmpGet(ctx, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmpGet", reflect.TypeOf((*MockEmpStore)(nil).EmpGet), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmpGet", reflect.TypeOf((*MockEmpStore)(nil).EmpGet), ctx, filter)
 }
 
 // EmpGetByID mocks base method.
@@ -80,17 +96,673 @@ func (mr *MockEmpStoreMockRecorder) EmpGetByID(ctx, id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmpGetByID", reflect.TypeOf((*MockEmpStore)(nil).EmpGetByID), ctx, id)
 }
 
+// EmpPing mocks base method.
+func (m *MockEmpStore) EmpPing(ctx *gofr.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EmpPing", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EmpPing indicates an expected call of EmpPing.
+func (mr *MockEmpStoreMockRecorder) EmpPing(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmpPing", reflect.TypeOf((*MockEmpStore)(nil).EmpPing), ctx)
+}
+
+// EmpSubtree mocks base method.
+func (m *MockEmpStore) EmpSubtree(ctx *gofr.Context, id int) ([]model.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EmpSubtree", ctx, id)
+	ret0, _ := ret[0].([]model.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EmpSubtree indicates an expected call of EmpSubtree.
+func (mr *MockEmpStoreMockRecorder) EmpSubtree(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmpSubtree", reflect.TypeOf((*MockEmpStore)(nil).EmpSubtree), ctx, id)
+}
+
 // EmpUpdate mocks base method.
-func (m *MockEmpStore) EmpUpdate(ctx *gofr.Context, employee model.Employee) (model.Employee, error) {
+func (m *MockEmpStore) EmpUpdate(ctx *gofr.Context, employee model.Employee, event *model.Event) (model.Employee, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EmpUpdate", ctx, employee)
+	ret := m.ctrl.Call(m, "EmpUpdate", ctx, employee, event)
 	ret0, _ := ret[0].(model.Employee)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // EmpUpdate indicates an expected call of EmpUpdate.
-func (mr *MockEmpStoreMockRecorder) EmpUpdate(ctx, employee interface{}) *gomock.Call {
+func (mr *MockEmpStoreMockRecorder) EmpUpdate(ctx, employee, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmpUpdate", reflect.TypeOf((*MockEmpStore)(nil).EmpUpdate), ctx, employee, event)
+}
+
+// MockDeptStore is a mock of DeptStore interface.
+type MockDeptStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeptStoreMockRecorder
+}
+
+// MockDeptStoreMockRecorder is the mock recorder for MockDeptStore.
+type MockDeptStoreMockRecorder struct {
+	mock *MockDeptStore
+}
+
+// NewMockDeptStore creates a new mock instance.
+func NewMockDeptStore(ctrl *gomock.Controller) *MockDeptStore {
+	mock := &MockDeptStore{ctrl: ctrl}
+	mock.recorder = &MockDeptStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeptStore) EXPECT() *MockDeptStoreMockRecorder {
+	return m.recorder
+}
+
+// DeptCreate mocks base method.
+func (m *MockDeptStore) DeptCreate(ctx *gofr.Context, department model.Department) (model.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeptCreate", ctx, department)
+	ret0, _ := ret[0].(model.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeptCreate indicates an expected call of DeptCreate.
+func (mr *MockDeptStoreMockRecorder) DeptCreate(ctx, department interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeptCreate", reflect.TypeOf((*MockDeptStore)(nil).DeptCreate), ctx, department)
+}
+
+// DeptDelete mocks base method.
+func (m *MockDeptStore) DeptDelete(ctx *gofr.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeptDelete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeptDelete indicates an expected call of DeptDelete.
+func (mr *MockDeptStoreMockRecorder) DeptDelete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeptDelete", reflect.TypeOf((*MockDeptStore)(nil).DeptDelete), ctx, id)
+}
+
+// DeptGet mocks base method.
+func (m *MockDeptStore) DeptGet(ctx *gofr.Context) ([]model.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeptGet", ctx)
+	ret0, _ := ret[0].([]model.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeptGet indicates an expected call of DeptGet.
+func (mr *MockDeptStoreMockRecorder) DeptGet(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeptGet", reflect.TypeOf((*MockDeptStore)(nil).DeptGet), ctx)
+}
+
+// DeptGetByID mocks base method.
+func (m *MockDeptStore) DeptGetByID(ctx *gofr.Context, id int) (model.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeptGetByID", ctx, id)
+	ret0, _ := ret[0].(model.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeptGetByID indicates an expected call of DeptGetByID.
+func (mr *MockDeptStoreMockRecorder) DeptGetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeptGetByID", reflect.TypeOf((*MockDeptStore)(nil).DeptGetByID), ctx, id)
+}
+
+// DeptUpdate mocks base method.
+func (m *MockDeptStore) DeptUpdate(ctx *gofr.Context, department model.Department) (model.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeptUpdate", ctx, department)
+	ret0, _ := ret[0].(model.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeptUpdate indicates an expected call of DeptUpdate.
+func (mr *MockDeptStoreMockRecorder) DeptUpdate(ctx, department interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeptUpdate", reflect.TypeOf((*MockDeptStore)(nil).DeptUpdate), ctx, department)
+}
+
+// MockIdempotencyStore is a mock of IdempotencyStore interface.
+type MockIdempotencyStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyStoreMockRecorder
+}
+
+// MockIdempotencyStoreMockRecorder is the mock recorder for MockIdempotencyStore.
+type MockIdempotencyStoreMockRecorder struct {
+	mock *MockIdempotencyStore
+}
+
+// NewMockIdempotencyStore creates a new mock instance.
+func NewMockIdempotencyStore(ctrl *gomock.Controller) *MockIdempotencyStore {
+	mock := &MockIdempotencyStore{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyStore) EXPECT() *MockIdempotencyStoreMockRecorder {
+	return m.recorder
+}
+
+// KeyCreate mocks base method.
+func (m *MockIdempotencyStore) KeyCreate(ctx *gofr.Context, key model.IdempotencyKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KeyCreate", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// KeyCreate indicates an expected call of KeyCreate.
+func (mr *MockIdempotencyStoreMockRecorder) KeyCreate(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KeyCreate", reflect.TypeOf((*MockIdempotencyStore)(nil).KeyCreate), ctx, key)
+}
+
+// KeyDelete mocks base method.
+func (m *MockIdempotencyStore) KeyDelete(ctx *gofr.Context, principal, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KeyDelete", ctx, principal, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// KeyDelete indicates an expected call of KeyDelete.
+func (mr *MockIdempotencyStoreMockRecorder) KeyDelete(ctx, principal, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KeyDelete", reflect.TypeOf((*MockIdempotencyStore)(nil).KeyDelete), ctx, principal, id)
+}
+
+// KeyGet mocks base method.
+func (m *MockIdempotencyStore) KeyGet(ctx *gofr.Context, principal, id string) (model.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KeyGet", ctx, principal, id)
+	ret0, _ := ret[0].(model.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// KeyGet indicates an expected call of KeyGet.
+func (mr *MockIdempotencyStoreMockRecorder) KeyGet(ctx, principal, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KeyGet", reflect.TypeOf((*MockIdempotencyStore)(nil).KeyGet), ctx, principal, id)
+}
+
+// KeyPurge mocks base method.
+func (m *MockIdempotencyStore) KeyPurge(ctx *gofr.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KeyPurge", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// KeyPurge indicates an expected call of KeyPurge.
+func (mr *MockIdempotencyStoreMockRecorder) KeyPurge(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KeyPurge", reflect.TypeOf((*MockIdempotencyStore)(nil).KeyPurge), ctx, before)
+}
+
+// KeyReplace mocks base method.
+func (m *MockIdempotencyStore) KeyReplace(ctx *gofr.Context, old, key model.IdempotencyKey) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KeyReplace", ctx, old, key)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// KeyReplace indicates an expected call of KeyReplace.
+func (mr *MockIdempotencyStoreMockRecorder) KeyReplace(ctx, old, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KeyReplace", reflect.TypeOf((*MockIdempotencyStore)(nil).KeyReplace), ctx, old, key)
+}
+
+// KeyUpdate mocks base method.
+func (m *MockIdempotencyStore) KeyUpdate(ctx *gofr.Context, key model.IdempotencyKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KeyUpdate", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// KeyUpdate indicates an expected call of KeyUpdate.
+func (mr *MockIdempotencyStoreMockRecorder) KeyUpdate(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KeyUpdate", reflect.TypeOf((*MockIdempotencyStore)(nil).KeyUpdate), ctx, key)
+}
+
+// MockOutboxStore is a mock of OutboxStore interface.
+type MockOutboxStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxStoreMockRecorder
+}
+
+// MockOutboxStoreMockRecorder is the mock recorder for MockOutboxStore.
+type MockOutboxStoreMockRecorder struct {
+	mock *MockOutboxStore
+}
+
+// NewMockOutboxStore creates a new mock instance.
+func NewMockOutboxStore(ctrl *gomock.Controller) *MockOutboxStore {
+	mock := &MockOutboxStore{ctrl: ctrl}
+	mock.recorder = &MockOutboxStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxStore) EXPECT() *MockOutboxStoreMockRecorder {
+	return m.recorder
+}
+
+// OutboxClaim mocks base method.
+func (m *MockOutboxStore) OutboxClaim(ctx *gofr.Context, limit int, lease time.Duration) ([]model.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboxClaim", ctx, limit, lease)
+	ret0, _ := ret[0].([]model.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OutboxClaim indicates an expected call of OutboxClaim.
+func (mr *MockOutboxStoreMockRecorder) OutboxClaim(ctx, limit, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboxClaim", reflect.TypeOf((*MockOutboxStore)(nil).OutboxClaim), ctx, limit, lease)
+}
+
+// OutboxDead mocks base method.
+func (m *MockOutboxStore) OutboxDead(ctx *gofr.Context, id int64, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboxDead", ctx, id, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OutboxDead indicates an expected call of OutboxDead.
+func (mr *MockOutboxStoreMockRecorder) OutboxDead(ctx, id, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboxDead", reflect.TypeOf((*MockOutboxStore)(nil).OutboxDead), ctx, id, reason)
+}
+
+// OutboxDelivered mocks base method.
+func (m *MockOutboxStore) OutboxDelivered(ctx *gofr.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboxDelivered", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OutboxDelivered indicates an expected call of OutboxDelivered.
+func (mr *MockOutboxStoreMockRecorder) OutboxDelivered(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboxDelivered", reflect.TypeOf((*MockOutboxStore)(nil).OutboxDelivered), ctx, id)
+}
+
+// OutboxRetry mocks base method.
+func (m *MockOutboxStore) OutboxRetry(ctx *gofr.Context, id int64, at time.Time, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboxRetry", ctx, id, at, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OutboxRetry indicates an expected call of OutboxRetry.
+func (mr *MockOutboxStoreMockRecorder) OutboxRetry(ctx, id, at, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboxRetry", reflect.TypeOf((*MockOutboxStore)(nil).OutboxRetry), ctx, id, at, reason)
+}
+
+// MockWebhookStore is a mock of WebhookStore interface.
+type MockWebhookStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookStoreMockRecorder
+}
+
+// MockWebhookStoreMockRecorder is the mock recorder for MockWebhookStore.
+type MockWebhookStoreMockRecorder struct {
+	mock *MockWebhookStore
+}
+
+// NewMockWebhookStore creates a new mock instance.
+func NewMockWebhookStore(ctrl *gomock.Controller) *MockWebhookStore {
+	mock := &MockWebhookStore{ctrl: ctrl}
+	mock.recorder = &MockWebhookStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookStore) EXPECT() *MockWebhookStoreMockRecorder {
+	return m.recorder
+}
+
+// DeliveryGet mocks base method.
+func (m *MockWebhookStore) DeliveryGet(ctx *gofr.Context, webhookID int, status model.DeliveryStatus, limit int) ([]model.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliveryGet", ctx, webhookID, status, limit)
+	ret0, _ := ret[0].([]model.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeliveryGet indicates an expected call of DeliveryGet.
+func (mr *MockWebhookStoreMockRecorder) DeliveryGet(ctx, webhookID, status, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliveryGet", reflect.TypeOf((*MockWebhookStore)(nil).DeliveryGet), ctx, webhookID, status, limit)
+}
+
+// DeliveryGetByID mocks base method.
+func (m *MockWebhookStore) DeliveryGetByID(ctx *gofr.Context, webhookID int, id int64) (model.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliveryGetByID", ctx, webhookID, id)
+	ret0, _ := ret[0].(model.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeliveryGetByID indicates an expected call of DeliveryGetByID.
+func (mr *MockWebhookStoreMockRecorder) DeliveryGetByID(ctx, webhookID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliveryGetByID", reflect.TypeOf((*MockWebhookStore)(nil).DeliveryGetByID), ctx, webhookID, id)
+}
+
+// DeliveryReplay mocks base method.
+func (m *MockWebhookStore) DeliveryReplay(ctx *gofr.Context, webhookID int, id int64) (model.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliveryReplay", ctx, webhookID, id)
+	ret0, _ := ret[0].(model.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeliveryReplay indicates an expected call of DeliveryReplay.
+func (mr *MockWebhookStoreMockRecorder) DeliveryReplay(ctx, webhookID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliveryReplay", reflect.TypeOf((*MockWebhookStore)(nil).DeliveryReplay), ctx, webhookID, id)
+}
+
+// WebhookCreate mocks base method.
+func (m *MockWebhookStore) WebhookCreate(ctx *gofr.Context, webhook model.Webhook) (model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WebhookCreate", ctx, webhook)
+	ret0, _ := ret[0].(model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WebhookCreate indicates an expected call of WebhookCreate.
+func (mr *MockWebhookStoreMockRecorder) WebhookCreate(ctx, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebhookCreate", reflect.TypeOf((*MockWebhookStore)(nil).WebhookCreate), ctx, webhook)
+}
+
+// WebhookDelete mocks base method.
+func (m *MockWebhookStore) WebhookDelete(ctx *gofr.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WebhookDelete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WebhookDelete indicates an expected call of WebhookDelete.
+func (mr *MockWebhookStoreMockRecorder) WebhookDelete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebhookDelete", reflect.TypeOf((*MockWebhookStore)(nil).WebhookDelete), ctx, id)
+}
+
+// WebhookGet mocks base method.
+func (m *MockWebhookStore) WebhookGet(ctx *gofr.Context) ([]model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WebhookGet", ctx)
+	ret0, _ := ret[0].([]model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WebhookGet indicates an expected call of WebhookGet.
+func (mr *MockWebhookStoreMockRecorder) WebhookGet(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebhookGet", reflect.TypeOf((*MockWebhookStore)(nil).WebhookGet), ctx)
+}
+
+// WebhookGetByID mocks base method.
+func (m *MockWebhookStore) WebhookGetByID(ctx *gofr.Context, id int) (model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WebhookGetByID", ctx, id)
+	ret0, _ := ret[0].(model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WebhookGetByID indicates an expected call of WebhookGetByID.
+func (mr *MockWebhookStoreMockRecorder) WebhookGetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebhookGetByID", reflect.TypeOf((*MockWebhookStore)(nil).WebhookGetByID), ctx, id)
+}
+
+// WebhookUpdate mocks base method.
+func (m *MockWebhookStore) WebhookUpdate(ctx *gofr.Context, webhook model.Webhook) (model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WebhookUpdate", ctx, webhook)
+	ret0, _ := ret[0].(model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WebhookUpdate indicates an expected call of WebhookUpdate.
+func (mr *MockWebhookStoreMockRecorder) WebhookUpdate(ctx, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebhookUpdate", reflect.TypeOf((*MockWebhookStore)(nil).WebhookUpdate), ctx, webhook)
+}
+
+// MockDeliveryStore is a mock of DeliveryStore interface.
+type MockDeliveryStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryStoreMockRecorder
+}
+
+// MockDeliveryStoreMockRecorder is the mock recorder for MockDeliveryStore.
+type MockDeliveryStoreMockRecorder struct {
+	mock *MockDeliveryStore
+}
+
+// NewMockDeliveryStore creates a new mock instance.
+func NewMockDeliveryStore(ctrl *gomock.Controller) *MockDeliveryStore {
+	mock := &MockDeliveryStore{ctrl: ctrl}
+	mock.recorder = &MockDeliveryStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveryStore) EXPECT() *MockDeliveryStoreMockRecorder {
+	return m.recorder
+}
+
+// DeliveryClaim mocks base method.
+func (m *MockDeliveryStore) DeliveryClaim(ctx *gofr.Context, limit int, lease time.Duration) ([]model.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliveryClaim", ctx, limit, lease)
+	ret0, _ := ret[0].([]model.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeliveryClaim indicates an expected call of DeliveryClaim.
+func (mr *MockDeliveryStoreMockRecorder) DeliveryClaim(ctx, limit, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliveryClaim", reflect.TypeOf((*MockDeliveryStore)(nil).DeliveryClaim), ctx, limit, lease)
+}
+
+// DeliveryFailed mocks base method.
+func (m *MockDeliveryStore) DeliveryFailed(ctx *gofr.Context, id int64, status *int, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliveryFailed", ctx, id, status, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeliveryFailed indicates an expected call of DeliveryFailed.
+func (mr *MockDeliveryStoreMockRecorder) DeliveryFailed(ctx, id, status, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliveryFailed", reflect.TypeOf((*MockDeliveryStore)(nil).DeliveryFailed), ctx, id, status, reason)
+}
+
+// DeliveryRetry mocks base method.
+func (m *MockDeliveryStore) DeliveryRetry(ctx *gofr.Context, id int64, at time.Time, status *int, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliveryRetry", ctx, id, at, status, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeliveryRetry indicates an expected call of DeliveryRetry.
+func (mr *MockDeliveryStoreMockRecorder) DeliveryRetry(ctx, id, at, status, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliveryRetry", reflect.TypeOf((*MockDeliveryStore)(nil).DeliveryRetry), ctx, id, at, status, reason)
+}
+
+// DeliverySucceeded mocks base method.
+func (m *MockDeliveryStore) DeliverySucceeded(ctx *gofr.Context, id int64, status int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverySucceeded", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeliverySucceeded indicates an expected call of DeliverySucceeded.
+func (mr *MockDeliveryStoreMockRecorder) DeliverySucceeded(ctx, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverySucceeded", reflect.TypeOf((*MockDeliveryStore)(nil).DeliverySucceeded), ctx, id, status)
+}
+
+// MockPublisher is a mock of Publisher interface.
+type MockPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublisherMockRecorder
+}
+
+// MockPublisherMockRecorder is the mock recorder for MockPublisher.
+type MockPublisherMockRecorder struct {
+	mock *MockPublisher
+}
+
+// NewMockPublisher creates a new mock instance.
+func NewMockPublisher(ctrl *gomock.Controller) *MockPublisher {
+	mock := &MockPublisher{ctrl: ctrl}
+	mock.recorder = &MockPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublisher) EXPECT() *MockPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockPublisher) Publish(ctx *gofr.Context, event model.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockPublisherMockRecorder) Publish(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockPublisher)(nil).Publish), ctx, event)
+}
+
+// MockCache is a mock of Cache interface.
+type MockCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockCacheMockRecorder
+}
+
+// MockCacheMockRecorder is the mock recorder for MockCache.
+type MockCacheMockRecorder struct {
+	mock *MockCache
+}
+
+// NewMockCache creates a new mock instance.
+func NewMockCache(ctrl *gomock.Controller) *MockCache {
+	mock := &MockCache{ctrl: ctrl}
+	mock.recorder = &MockCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCache) EXPECT() *MockCacheMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockCache) Add(ctx *gofr.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, key, value, ttl)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockCacheMockRecorder) Add(ctx, key, value, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockCache)(nil).Add), ctx, key, value, ttl)
+}
+
+// Delete mocks base method.
+func (m *MockCache) Delete(ctx *gofr.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCacheMockRecorder) Delete(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCache)(nil).Delete), ctx, key)
+}
+
+// Get mocks base method.
+func (m *MockCache) Get(ctx *gofr.Context, key string) ([]byte, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCacheMockRecorder) Get(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCache)(nil).Get), ctx, key)
+}
+
+// Set mocks base method.
+func (m *MockCache) Set(ctx *gofr.Context, key string, value []byte, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, key, value, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockCacheMockRecorder) Set(ctx, key, value, ttl interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmpUpdate", reflect.TypeOf((*MockEmpStore)(nil).EmpUpdate), ctx, employee)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockCache)(nil).Set), ctx, key, value, ttl)
 }
@@ -0,0 +1,133 @@
+package outbox
+
+import (
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/model"
+	"example/tracing"
+)
+
+// claim leases the due pending events whose employee has no earlier pending event, so that a relay never delivers an
+// event before the ones recorded before it for the same employee, even while another relay holds those.
+const claim = `update outbox set locked_until = $1 where id in (
+		select o.id from outbox o
+		where o.status = 'pending' and o.next_attempt_at <= $2 and (o.locked_until is null or o.locked_until <= $2)
+		and not exists (
+			select 1 from outbox p where p.employee_id = o.employee_id and p.status = 'pending' and p.id < o.id
+		)
+		order by o.id limit $3 for update skip locked
+	)
+	returning id,payload,attempts,next_attempt_at`
+
+type store struct {
+	now func() time.Time
+}
+
+func New() store {
+	return store{now: time.Now}
+}
+
+// timestamp returns the current time at the microsecond precision the database keeps.
+func (s store) timestamp() time.Time {
+	return s.now().UTC().Truncate(time.Microsecond)
+}
+
+// Insert adds the event to the outbox with tx, the transaction storing the change it records, due for delivery at.
+func Insert(ctx *gofr.Context, tx *sql.Tx, event model.Event, at time.Time) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	query := "insert into outbox(event_id,employee_id,type,payload,next_attempt_at,created_at) " +
+		"VALUES ($1,$2,$3,$4,$5,$5)"
+	tracing.Statement(ctx, query)
+
+	if _, err = tx.Exec(query, event.ID, event.Employee.ID, event.Type, string(payload), at); err != nil {
+		ctx.Logger.Errorf("failed to add event %s to the outbox: %v", event.ID, err)
+		return errors.Error("Internal DB Error")
+	}
+
+	return nil
+}
+
+// OutboxClaim leases up to limit due events for lease, oldest first. Leased events are not claimed again until the
+// lease expires, so the events of a relay that stopped before settling them are delivered by another.
+func (s store) OutboxClaim(ctx *gofr.Context, limit int, lease time.Duration) ([]model.OutboxEvent, error) {
+	now := s.timestamp()
+
+	tracing.Statement(ctx, claim)
+
+	rows, err := ctx.DB().DB.Query(claim, now.Add(lease), now, limit)
+	if err != nil {
+		ctx.Logger.Errorf("failed to claim outbox events: %v", err)
+		return nil, errors.DB{Err: errors.Error("Internal DB error")}
+	}
+
+	defer rows.Close()
+
+	var events []model.OutboxEvent
+
+	for rows.Next() {
+		var (
+			e       = model.OutboxEvent{Status: model.OutboxPending}
+			payload string
+		)
+
+		if err = rows.Scan(&e.ID, &payload, &e.Attempts, &e.NextAttemptAt); err != nil {
+			ctx.Logger.Errorf("failed to scan outbox event: %v", err)
+			return nil, errors.Error("Scan Error")
+		}
+
+		if err = json.Unmarshal([]byte(payload), &e.Event); err != nil {
+			ctx.Logger.Errorf("failed to decode outbox event %d: %v", e.ID, err)
+			return nil, errors.Error("Scan Error")
+		}
+
+		events = append(events, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+
+	return events, nil
+}
+
+// OutboxDelivered marks the event delivered.
+func (s store) OutboxDelivered(ctx *gofr.Context, id int64) error {
+	return s.exec(ctx, "update outbox set status = $1,delivered_at = $2,locked_until = null where id = $3",
+		model.OutboxDelivered, s.timestamp(), id)
+}
+
+// OutboxRetry records a failed delivery of the event, which is due again at.
+func (s store) OutboxRetry(ctx *gofr.Context, id int64, at time.Time, reason string) error {
+	return s.exec(ctx, "update outbox set attempts = attempts + 1,next_attempt_at = $1,last_error = $2,"+
+		"locked_until = null where id = $3", at.UTC().Truncate(time.Microsecond), reason, id)
+}
+
+// OutboxDead records a failed delivery of the event and gives up on it, letting the later events of its employee be
+// delivered.
+func (s store) OutboxDead(ctx *gofr.Context, id int64, reason string) error {
+	return s.exec(ctx, "update outbox set status = $1,attempts = attempts + 1,last_error = $2,locked_until = null "+
+		"where id = $3", model.OutboxDead, reason, id)
+}
+
+func (s store) exec(ctx *gofr.Context, query string, args ...interface{}) error {
+	tracing.Statement(ctx, query)
+
+	if _, err := ctx.DB().DB.Exec(query, args...); err != nil {
+		ctx.Logger.Errorf("failed to update outbox event: %v", err)
+		return errors.Error("Internal DB Error")
+	}
+
+	return nil
+}
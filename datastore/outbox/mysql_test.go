@@ -0,0 +1,147 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"developer.zopsmart.com/go/gofr/pkg/datastore"
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"developer.zopsmart.com/go/gofr/pkg/log"
+
+	"example/model"
+)
+
+func newContext(t *testing.T) (*gofr.Context, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("database error %s", err)
+	}
+
+	t.Cleanup(func() { db.Close() })
+
+	g := gofr.Gofr{DataStore: datastore.DataStore{ORM: db}, Logger: log.NewMockLogger(io.Discard)}
+	ctx := gofr.NewContext(nil, nil, &g)
+	ctx.Context = context.Background()
+
+	return ctx, mock
+}
+
+func TestInsert(t *testing.T) {
+	ctx, mock := newContext(t)
+
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	event := model.Event{ID: "e1", Type: model.EmployeeCreated, SchemaVersion: 1, OccurredAt: now, Principal: "ram",
+		Employee: model.Employee{ID: 2, Name: "Sai", Status: model.StatusActive, CreatedAt: now, UpdatedAt: now}}
+	payload, _ := json.Marshal(event)
+	exec := "insert into outbox(event_id,employee_id,type,payload,next_attempt_at,created_at) VALUES ($1,$2,$3,$4,$5,$5)"
+
+	testcases := []struct {
+		desc string
+		err  error
+		mock []interface{}
+	}{
+		{"success", nil, []interface{}{mock.ExpectBegin(), mock.ExpectExec(exec).
+			WithArgs("e1", 2, model.EmployeeCreated, string(payload), now).WillReturnResult(sqlmock.NewResult(1, 1))}},
+		{"failure", errors.Error("Internal DB Error"), []interface{}{mock.ExpectBegin(), mock.ExpectExec(exec).
+			WillReturnError(errors.Error("connection reset"))}},
+	}
+
+	for i, tc := range testcases {
+		tx, err := ctx.DB().DB.Begin()
+		if err != nil {
+			t.Fatalf("[Test %v]Failed. Expected no error but got %v", i+1, err)
+		}
+
+		err = Insert(ctx, tx, event, now)
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestStore_OutboxClaim(t *testing.T) {
+	ctx, mock := newContext(t)
+
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	s := store{now: func() time.Time { return now }}
+
+	columns := []string{"id", "payload", "attempts", "next_attempt_at"}
+	first := `{"id":"e1","type":"EmployeeCreated","schema_version":1,"employee":{"id":2}}`
+	second := `{"id":"e2","type":"EmployeeUpdated","schema_version":1,"employee":{"id":3}}`
+
+	testcases := []struct {
+		desc   string
+		output []model.OutboxEvent
+		err    error
+		mock   []interface{}
+	}{
+		{"success", []model.OutboxEvent{
+			{ID: 1, Status: model.OutboxPending, NextAttemptAt: now, Event: model.Event{ID: "e1",
+				Type: model.EmployeeCreated, SchemaVersion: 1, Employee: model.Employee{ID: 2}}},
+			{ID: 4, Status: model.OutboxPending, Attempts: 2, NextAttemptAt: now, Event: model.Event{ID: "e2",
+				Type: model.EmployeeUpdated, SchemaVersion: 1, Employee: model.Employee{ID: 3}}},
+		}, nil, []interface{}{mock.ExpectQuery(claim).WithArgs(now.Add(time.Minute), now, 10).WillReturnRows(
+			sqlmock.NewRows(columns).AddRow(4, second, 2, now).AddRow(1, first, 0, now))}},
+		{"none", nil, nil, []interface{}{mock.ExpectQuery(claim).WillReturnRows(sqlmock.NewRows(columns))}},
+		{"failure", nil, errors.DB{Err: errors.Error("Internal DB error")}, []interface{}{mock.ExpectQuery(claim).
+			WillReturnError(errors.Error("connection reset"))}},
+		{"malformed payload", nil, errors.Error("Scan Error"), []interface{}{mock.ExpectQuery(claim).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "{", 0, now))}},
+	}
+
+	for i, tc := range testcases {
+		resp, err := s.OutboxClaim(ctx, 10, time.Minute)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestStore_Settle(t *testing.T) {
+	ctx, mock := newContext(t)
+
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	s := store{now: func() time.Time { return now }}
+
+	delivered := "update outbox set status = $1,delivered_at = $2,locked_until = null where id = $3"
+	retry := "update outbox set attempts = attempts + 1,next_attempt_at = $1,last_error = $2,locked_until = null " +
+		"where id = $3"
+	dead := "update outbox set status = $1,attempts = attempts + 1,last_error = $2,locked_until = null where id = $3"
+
+	testcases := []struct {
+		desc   string
+		settle func() error
+		err    error
+		mock   []interface{}
+	}{
+		{"delivered", func() error { return s.OutboxDelivered(ctx, 1) }, nil, []interface{}{mock.ExpectExec(delivered).
+			WithArgs(model.OutboxDelivered, now, 1).WillReturnResult(sqlmock.NewResult(0, 1))}},
+		{"retry", func() error { return s.OutboxRetry(ctx, 2, now.Add(time.Second), "timeout") }, nil,
+			[]interface{}{mock.ExpectExec(retry).WithArgs(now.Add(time.Second), "timeout", 2).
+				WillReturnResult(sqlmock.NewResult(0, 1))}},
+		{"dead", func() error { return s.OutboxDead(ctx, 3, "timeout") }, nil, []interface{}{mock.ExpectExec(dead).
+			WithArgs(model.OutboxDead, "timeout", 3).WillReturnResult(sqlmock.NewResult(0, 1))}},
+		{"failure", func() error { return s.OutboxDelivered(ctx, 4) }, errors.Error("Internal DB Error"),
+			[]interface{}{mock.ExpectExec(delivered).WillReturnError(errors.Error("connection reset"))}},
+	}
+
+	for i, tc := range testcases {
+		if err := tc.settle(); !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package sqlerr
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	mssql "github.com/denisenkom/go-mssqldb"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+const (
+	pqForeignKeyViolation = "23503"
+	mysqlRowReferenced    = 1451
+	mysqlNoReferencedRow  = 1452
+	mssqlConstraintFailed = 547
+	sqliteForeignKeyFails = "FOREIGN KEY constraint failed"
+)
+
+// nolint:gochecknoglobals // compiled once, the patterns only read the messages of foreign key violations
+var (
+	mysqlForeignKey = regexp.MustCompile("FOREIGN KEY \\(`([^`]+)`\\)")
+	mssqlForeignKey = regexp.MustCompile(`FOREIGN KEY constraint "([^"]+)"`)
+)
+
+// Referenced reports whether err is a foreign key violation, as when deleting a row that other rows still reference.
+func Referenced(err error) bool {
+	var (
+		pqErr    *pq.Error
+		mysqlErr *mysql.MySQLError
+		mssqlErr mssql.Error
+	)
+
+	switch {
+	case errors.As(err, &pqErr):
+		return pqErr.Code == pqForeignKeyViolation
+	case errors.As(err, &mysqlErr):
+		return mysqlErr.Number == mysqlRowReferenced
+	case errors.As(err, &mssqlErr):
+		return mssqlErr.Number == mssqlConstraintFailed && strings.Contains(mssqlErr.Message, "REFERENCE constraint")
+	case err != nil:
+		return strings.Contains(err.Error(), sqliteForeignKeyFails)
+	}
+
+	return false
+}
+
+// AsReference returns the field, one of fields, whose value err reports as referencing no row, as when inserting or
+// updating a row with a foreign key to a row that does not exist, or false when err is not such a violation. sqlite
+// does not name the key, so the first of fields is returned for it.
+func AsReference(err error, fields ...string) (string, bool) {
+	var (
+		pqErr    *pq.Error
+		mysqlErr *mysql.MySQLError
+		mssqlErr mssql.Error
+		key      string
+	)
+
+	switch {
+	case errors.As(err, &pqErr):
+		if pqErr.Code != pqForeignKeyViolation {
+			return "", false
+		}
+
+		key = pqErr.Constraint
+		if m := pqKey.FindStringSubmatch(pqErr.Detail); m != nil {
+			key = m[1]
+		}
+	case errors.As(err, &mysqlErr):
+		if mysqlErr.Number != mysqlNoReferencedRow {
+			return "", false
+		}
+
+		key = submatch(mysqlForeignKey, mysqlErr.Message)
+	case errors.As(err, &mssqlErr):
+		if mssqlErr.Number != mssqlConstraintFailed || !strings.Contains(mssqlErr.Message, "FOREIGN KEY constraint") {
+			return "", false
+		}
+
+		key = submatch(mssqlForeignKey, mssqlErr.Message)
+	case err != nil && strings.Contains(err.Error(), sqliteForeignKeyFails):
+	default:
+		return "", false
+	}
+
+	if key == "" && len(fields) > 0 {
+		return fields[0], true
+	}
+
+	return field(key, fields), true
+}
@@ -0,0 +1,71 @@
+package sqlerr
+
+import (
+	"fmt"
+	"testing"
+
+	mssql "github.com/denisenkom/go-mssqldb"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+func TestReferenced(t *testing.T) {
+	testcases := []struct {
+		desc   string
+		err    error
+		output bool
+	}{
+		{"postgres", &pq.Error{Code: "23503", Constraint: "employee_department_id_fkey"}, true},
+		{"postgres unique", &pq.Error{Code: "23505", Constraint: "department_name_key"}, false},
+		{"mysql", &mysql.MySQLError{Number: 1451, Message: "Cannot delete or update a parent row"}, true},
+		{"mysql duplicate", &mysql.MySQLError{Number: 1062, Message: "Duplicate entry '1' for key 'PRIMARY'"}, false},
+		{"mssql", mssql.Error{Number: 547, Message: "The DELETE statement conflicted with the REFERENCE constraint " +
+			"\"FK__employee__department\"."}, true},
+		{"mssql check", mssql.Error{Number: 547, Message: "The INSERT statement conflicted with the CHECK constraint " +
+			"\"CK_employee_age\"."}, false},
+		{"sqlite", fmt.Errorf("delete: %w", fmt.Errorf("FOREIGN KEY constraint failed")), true},
+		{"other error", fmt.Errorf("connection refused"), false},
+		{"nil", nil, false},
+	}
+
+	for i, tc := range testcases {
+		if output := Referenced(tc.err); output != tc.output {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, output)
+		}
+	}
+}
+
+func TestAsReference(t *testing.T) {
+	testcases := []struct {
+		desc   string
+		err    error
+		output string
+		ok     bool
+	}{
+		{"postgres detail", &pq.Error{Code: "23503", Constraint: "employee_manager_id_fkey",
+			Detail: `Key (manager_id)=(99) is not present in table "employee".`}, "manager_id", true},
+		{"postgres constraint", &pq.Error{Code: "23503", Constraint: "employee_department_id_fkey"}, "department_id",
+			true},
+		{"postgres unique", &pq.Error{Code: "23505", Constraint: "employee_email_key"}, "", false},
+		{"mysql", &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint " +
+			"fails (`db`.`employee`, CONSTRAINT `employee_ibfk_1` FOREIGN KEY (`manager_id`) REFERENCES `employee` " +
+			"(`id`))"}, "manager_id", true},
+		{"mysql parent row", &mysql.MySQLError{Number: 1451, Message: "Cannot delete or update a parent row"}, "", false},
+		{"mssql", mssql.Error{Number: 547, Message: "The INSERT statement conflicted with the FOREIGN KEY constraint " +
+			"\"employee_manager_id_fkey\". The conflict occurred in database \"db\", table \"dbo.employee\", " +
+			"column 'id'."}, "manager_id", true},
+		{"mssql reference", mssql.Error{Number: 547, Message: "The DELETE statement conflicted with the REFERENCE " +
+			"constraint \"FK__employee__department\"."}, "", false},
+		{"sqlite", fmt.Errorf("insert: %w", fmt.Errorf("FOREIGN KEY constraint failed")), "manager_id", true},
+		{"other error", fmt.Errorf("connection refused"), "", false},
+		{"nil", nil, "", false},
+	}
+
+	for i, tc := range testcases {
+		output, ok := AsReference(tc.err, "manager_id", "department_id")
+
+		if output != tc.output || ok != tc.ok {
+			t.Errorf("[Test %v]Failed. Expected %v, %v but got %v, %v", i+1, tc.output, tc.ok, output, ok)
+		}
+	}
+}
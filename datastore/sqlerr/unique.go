@@ -0,0 +1,118 @@
+// Package sqlerr recognises driver specific errors of the SQL dialects the stores run on.
+package sqlerr
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"unicode"
+
+	mssql "github.com/denisenkom/go-mssqldb"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+
+	"example/domain"
+)
+
+const (
+	pqUniqueViolation     = "23505"
+	mysqlDuplicateEntry   = 1062
+	mssqlUniqueConstraint = 2627
+	mssqlUniqueIndex      = 2601
+	sqliteUniqueFailed    = "UNIQUE constraint failed: "
+)
+
+// nolint:gochecknoglobals // compiled once, the patterns only read the messages of unique violations
+var (
+	pqKey    = regexp.MustCompile(`^Key \(([^)]+)\)=`)
+	mysqlKey = regexp.MustCompile(`for key '([^']+)'`)
+	mssqlKey = regexp.MustCompile(`(?:constraint|unique index) '([^']+)'`)
+)
+
+// AsConflict returns the domain.Conflict naming the field of entity whose unique key err violates, or false when err
+// is not a unique violation. fields lists the unique fields of entity, primary key first.
+func AsConflict(err error, entity string, fields ...string) (domain.Conflict, bool) {
+	key, ok := uniqueKey(err)
+	if !ok {
+		return domain.Conflict{}, false
+	}
+
+	return domain.Conflict{Entity: entity, Field: field(key, fields)}, true
+}
+
+// uniqueKey returns the key a unique violation was reported on: the column for postgres and sqlite, and the
+// constraint or index name for mysql and mssql.
+func uniqueKey(err error) (string, bool) {
+	var (
+		pqErr    *pq.Error
+		mysqlErr *mysql.MySQLError
+		mssqlErr mssql.Error
+	)
+
+	switch {
+	case errors.As(err, &pqErr):
+		if pqErr.Code != pqUniqueViolation {
+			return "", false
+		}
+
+		if m := pqKey.FindStringSubmatch(pqErr.Detail); m != nil {
+			return m[1], true
+		}
+
+		return pqErr.Constraint, true
+	case errors.As(err, &mysqlErr):
+		if mysqlErr.Number != mysqlDuplicateEntry {
+			return "", false
+		}
+
+		return submatch(mysqlKey, mysqlErr.Message), true
+	case errors.As(err, &mssqlErr):
+		if mssqlErr.Number != mssqlUniqueConstraint && mssqlErr.Number != mssqlUniqueIndex {
+			return "", false
+		}
+
+		return submatch(mssqlKey, mssqlErr.Message), true
+	case err != nil && strings.Contains(err.Error(), sqliteUniqueFailed):
+		msg := err.Error()
+		cols := msg[strings.Index(msg, sqliteUniqueFailed)+len(sqliteUniqueFailed):]
+
+		return strings.Split(cols, ",")[0], true
+	}
+
+	return "", false
+}
+
+func submatch(re *regexp.Regexp, s string) string {
+	if m := re.FindStringSubmatch(s); m != nil {
+		return m[1]
+	}
+
+	return ""
+}
+
+// field resolves a key to one of fields. Primary keys (PRIMARY, *_pkey, PK_*) resolve to the first field and other
+// keys to the field that appears as whole words in the key, so employee.email and employee_email_key both give email.
+// Keys naming none of the fields are returned as they are.
+func field(key string, fields []string) string {
+	words := strings.FieldsFunc(strings.ToLower(key), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	if len(words) == 0 {
+		return "value"
+	}
+
+	if len(fields) > 0 && (key == "PRIMARY" || words[0] == "pk" || words[len(words)-1] == "pkey") {
+		return fields[0]
+	}
+
+	joined := "_" + strings.Join(words, "_") + "_"
+
+	for _, f := range fields {
+		if strings.Contains(joined, "_"+f+"_") {
+			return f
+		}
+	}
+
+	return key
+}
@@ -0,0 +1,54 @@
+package sqlerr
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	mssql "github.com/denisenkom/go-mssqldb"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+
+	"example/domain"
+)
+
+func TestAsConflict(t *testing.T) {
+	conflict := func(field string) domain.Conflict { return domain.Conflict{Entity: "employee", Field: field} }
+
+	testcases := []struct {
+		desc   string
+		err    error
+		output domain.Conflict
+		ok     bool
+	}{
+		{"postgres detail", &pq.Error{Code: "23505", Constraint: "employee_email_key",
+			Detail: "Key (email)=(ram@example.com) already exists."}, conflict("email"), true},
+		{"postgres primary key", &pq.Error{Code: "23505", Constraint: "employee_pkey"}, conflict("id"), true},
+		{"postgres foreign key", &pq.Error{Code: "23503", Constraint: "employee_manager_id_fkey"}, domain.Conflict{}, false},
+		{"mysql index", &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'ram@example.com' for key " +
+			"'employee.employee_email_key'"}, conflict("email"), true},
+		{"mysql primary key", &mysql.MySQLError{Number: 1062, Message: "Duplicate entry '1' for key 'PRIMARY'"},
+			conflict("id"), true},
+		{"mysql other", &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row"}, domain.Conflict{},
+			false},
+		{"mssql constraint", mssql.Error{Number: 2627, Message: "Violation of PRIMARY KEY constraint " +
+			"'PK__employee__3213E83F'. Cannot insert duplicate key in object 'dbo.employee'."}, conflict("id"), true},
+		{"mssql index", mssql.Error{Number: 2601, Message: "Cannot insert duplicate key row in object 'dbo.employee' " +
+			"with unique index 'employee_email_key'. The duplicate key value is (ram@example.com)."},
+			conflict("email"), true},
+		{"sqlite", fmt.Errorf("insert: %w", fmt.Errorf("UNIQUE constraint failed: employee.email")),
+			conflict("email"), true},
+		{"unknown key", &pq.Error{Code: "23505", Constraint: "employee_badge_key"},
+			conflict("employee_badge_key"), true},
+		{"other error", fmt.Errorf("connection refused"), domain.Conflict{}, false},
+		{"nil", nil, domain.Conflict{}, false},
+	}
+
+	for i, tc := range testcases {
+		resp, ok := AsConflict(tc.err, "employee", "id", "email")
+
+		if !reflect.DeepEqual(tc.output, resp) || tc.ok != ok {
+			t.Errorf("[Test %v]Failed. Expected %v, %v but got %v, %v", i+1, tc.output, tc.ok, resp, ok)
+		}
+	}
+}
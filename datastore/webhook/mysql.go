@@ -0,0 +1,375 @@
+// Package webhook keeps the webhook subscriptions and the deliveries of events to them.
+package webhook
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"github.com/lib/pq"
+
+	"example/domain"
+	"example/model"
+	"example/tracing"
+)
+
+const (
+	columns         = "id,url,secret,events,created_at,created_by,updated_at"
+	deliveryColumns = "id,webhook_id,event_id,event_type,status,attempts,response_status,last_error,next_attempt_at," +
+		"created_at,delivered_at"
+)
+
+// enqueue records the delivery of an event to every webhook subscribed to its type. An event relayed again is not
+// delivered twice.
+const enqueue = `insert into webhook_delivery(webhook_id,event_id,event_type,payload,next_attempt_at,created_at)
+	select id,$1,$2,$3,$4,$4 from webhook where cardinality(events) = 0 or $2 = any(events)
+	on conflict (webhook_id,event_id) do nothing`
+
+// claim leases the due pending deliveries with the url and secret of their webhook.
+const claim = `update webhook_delivery d set locked_until = $1 from webhook w where w.id = d.webhook_id and d.id in (
+		select id from webhook_delivery
+		where status = 'pending' and next_attempt_at <= $2 and (locked_until is null or locked_until <= $2)
+		order by next_attempt_at, id limit $3 for update skip locked
+	)
+	returning d.id,d.webhook_id,d.event_id,d.event_type,d.payload,d.attempts,d.next_attempt_at,d.created_at,w.url,
+	w.secret`
+
+type store struct {
+	now func() time.Time
+}
+
+func New() store {
+	return store{now: time.Now}
+}
+
+// timestamp returns the current time at the microsecond precision the database keeps.
+func (s store) timestamp() time.Time {
+	return s.now().UTC().Truncate(time.Microsecond)
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanWebhook(row scanner, w *model.Webhook) error {
+	var events pq.StringArray
+
+	if err := row.Scan(&w.ID, &w.URL, &w.Secret, &events, &w.CreatedAt, &w.CreatedBy, &w.UpdatedAt); err != nil {
+		return err
+	}
+
+	w.Events = make([]model.EventType, len(events))
+	for i := range events {
+		w.Events[i] = model.EventType(events[i])
+	}
+
+	return nil
+}
+
+func scanDelivery(row scanner, d *model.Delivery) error {
+	var (
+		status      sql.NullInt64
+		lastError   sql.NullString
+		deliveredAt sql.NullTime
+	)
+
+	err := row.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.Status, &d.Attempts, &status, &lastError,
+		&d.NextAttemptAt, &d.CreatedAt, &deliveredAt)
+	if err != nil {
+		return err
+	}
+
+	if status.Valid {
+		code := int(status.Int64)
+		d.ResponseStatus = &code
+	}
+
+	if deliveredAt.Valid {
+		d.DeliveredAt = &deliveredAt.Time
+	}
+
+	d.LastError = lastError.String
+
+	return nil
+}
+
+func eventNames(events []model.EventType) pq.StringArray {
+	names := make(pq.StringArray, len(events))
+	for i := range events {
+		names[i] = string(events[i])
+	}
+
+	return names
+}
+
+func (s store) WebhookGet(ctx *gofr.Context) ([]model.Webhook, error) {
+	query := "select " + columns + " from webhook order by id"
+	tracing.Statement(ctx, query)
+
+	rows, err := ctx.DB().DB.Query(query)
+	if err != nil {
+		return nil, errors.DB{Err: errors.Error("Internal DB error")}
+	}
+
+	defer rows.Close()
+
+	var webhooks []model.Webhook
+
+	for rows.Next() {
+		var w model.Webhook
+
+		if err = scanWebhook(rows, &w); err != nil {
+			return nil, errors.Error("Scan Error")
+		}
+
+		webhooks = append(webhooks, w)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return webhooks, nil
+}
+
+func (s store) WebhookGetByID(ctx *gofr.Context, id int) (model.Webhook, error) {
+	var w model.Webhook
+
+	query := "select " + columns + " from webhook where id = $1"
+	tracing.Statement(ctx, query)
+
+	err := scanWebhook(ctx.DB().DB.QueryRow(query, id), &w)
+
+	if err == sql.ErrNoRows {
+		return model.Webhook{}, errors.EntityNotFound{Entity: "webhook", ID: strconv.Itoa(id)}
+	}
+
+	if err != nil {
+		return model.Webhook{}, errors.Error("Scan Error")
+	}
+
+	return w, nil
+}
+
+func (s store) WebhookCreate(ctx *gofr.Context, webhook model.Webhook) (model.Webhook, error) {
+	webhook.CreatedAt = s.timestamp()
+	webhook.UpdatedAt = webhook.CreatedAt
+
+	query := "insert into webhook(url,secret,events,created_at,created_by,updated_at) VALUES ($1,$2,$3,$4,$5,$4) " +
+		"returning id"
+	tracing.Statement(ctx, query)
+
+	err := ctx.DB().DB.QueryRow(query, webhook.URL, webhook.Secret, eventNames(webhook.Events), webhook.CreatedAt,
+		webhook.CreatedBy).Scan(&webhook.ID)
+	if err != nil {
+		ctx.Logger.Errorf("failed to create webhook: %v", err)
+		return model.Webhook{}, errors.Error("Internal DB Error")
+	}
+
+	return webhook, nil
+}
+
+func (s store) WebhookUpdate(ctx *gofr.Context, webhook model.Webhook) (model.Webhook, error) {
+	query := "update webhook set url = $1,secret = $2,events = $3,updated_at = $4 where id = $5"
+	tracing.Statement(ctx, query)
+
+	res, err := ctx.DB().DB.Exec(query, webhook.URL, webhook.Secret, eventNames(webhook.Events), s.timestamp(),
+		webhook.ID)
+	if err != nil {
+		ctx.Logger.Errorf("failed to update webhook %d: %v", webhook.ID, err)
+		return model.Webhook{}, errors.Error("Internal DB Error")
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return model.Webhook{}, errors.EntityNotFound{Entity: "webhook", ID: strconv.Itoa(webhook.ID)}
+	}
+
+	return s.WebhookGetByID(ctx, webhook.ID)
+}
+
+// WebhookDelete deletes the webhook with its deliveries.
+func (s store) WebhookDelete(ctx *gofr.Context, id int) error {
+	query := "delete from webhook where id = $1"
+	tracing.Statement(ctx, query)
+
+	res, err := ctx.DB().DB.Exec(query, id)
+	if err != nil {
+		return errors.Error("Internal DB Error")
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return errors.EntityNotFound{Entity: "webhook", ID: strconv.Itoa(id)}
+	}
+
+	return nil
+}
+
+// DeliveryGet returns up to limit deliveries to the webhook, latest first, only those with status unless it is empty.
+func (s store) DeliveryGet(ctx *gofr.Context, webhookID int, status model.DeliveryStatus,
+	limit int) ([]model.Delivery, error) {
+	query := "select " + deliveryColumns + " from webhook_delivery where webhook_id = $1 and ($2 = '' or status = $2) " +
+		"order by id desc limit $3"
+	tracing.Statement(ctx, query)
+
+	rows, err := ctx.DB().DB.Query(query, webhookID, status, limit)
+	if err != nil {
+		return nil, errors.DB{Err: errors.Error("Internal DB error")}
+	}
+
+	defer rows.Close()
+
+	var deliveries []model.Delivery
+
+	for rows.Next() {
+		var d model.Delivery
+
+		if err = scanDelivery(rows, &d); err != nil {
+			return nil, errors.Error("Scan Error")
+		}
+
+		deliveries = append(deliveries, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return deliveries, nil
+}
+
+func (s store) DeliveryGetByID(ctx *gofr.Context, webhookID int, id int64) (model.Delivery, error) {
+	var d model.Delivery
+
+	query := "select " + deliveryColumns + " from webhook_delivery where id = $1 and webhook_id = $2"
+	tracing.Statement(ctx, query)
+
+	err := scanDelivery(ctx.DB().DB.QueryRow(query, id, webhookID), &d)
+
+	if err == sql.ErrNoRows {
+		return model.Delivery{}, errors.EntityNotFound{Entity: "delivery", ID: strconv.FormatInt(id, 10)}
+	}
+
+	if err != nil {
+		return model.Delivery{}, errors.Error("Scan Error")
+	}
+
+	return d, nil
+}
+
+// DeliveryReplay makes a failed delivery pending again, due now with its attempts reset. It returns a conflict when
+// the delivery is not failed, which happens when a concurrent replay changed it first.
+func (s store) DeliveryReplay(ctx *gofr.Context, webhookID int, id int64) (model.Delivery, error) {
+	query := "update webhook_delivery set status = $1,attempts = 0,next_attempt_at = $2,locked_until = null " +
+		"where id = $3 and webhook_id = $4 and status = $5"
+	tracing.Statement(ctx, query)
+
+	res, err := ctx.DB().DB.Exec(query, model.DeliveryPending, s.timestamp(), id, webhookID, model.DeliveryFailed)
+	if err != nil {
+		ctx.Logger.Errorf("failed to replay delivery %d: %v", id, err)
+		return model.Delivery{}, errors.Error("Internal DB Error")
+	}
+
+	d, err := s.DeliveryGetByID(ctx, webhookID, id)
+	if err != nil {
+		return model.Delivery{}, err
+	}
+
+	// a concurrent replay, or the worker, changed the delivery since the caller saw it failed
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return model.Delivery{}, domain.Conflict{Entity: "delivery", Reason: "delivery " + strconv.FormatInt(id, 10) +
+			" is " + string(d.Status) + ", only failed deliveries can be replayed"}
+	}
+
+	return d, nil
+}
+
+// Publish records the delivery of the event to the webhooks subscribed to it, for the delivery worker to send.
+func (s store) Publish(ctx *gofr.Context, event model.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	tracing.Statement(ctx, enqueue)
+
+	if _, err = ctx.DB().DB.Exec(enqueue, event.ID, event.Type, string(payload), s.timestamp()); err != nil {
+		ctx.Logger.Errorf("failed to record the deliveries of event %s: %v", event.ID, err)
+		return errors.Error("Internal DB Error")
+	}
+
+	return nil
+}
+
+// DeliveryClaim leases up to limit due deliveries for lease, the longest due first. Deliveries are not claimed again
+// until their lease expires, and are not ordered: a retried delivery may be sent after a later event.
+func (s store) DeliveryClaim(ctx *gofr.Context, limit int, lease time.Duration) ([]model.Delivery, error) {
+	now := s.timestamp()
+
+	tracing.Statement(ctx, claim)
+
+	rows, err := ctx.DB().DB.Query(claim, now.Add(lease), now, limit)
+	if err != nil {
+		ctx.Logger.Errorf("failed to claim webhook deliveries: %v", err)
+		return nil, errors.DB{Err: errors.Error("Internal DB error")}
+	}
+
+	defer rows.Close()
+
+	var deliveries []model.Delivery
+
+	for rows.Next() {
+		var (
+			d       = model.Delivery{Status: model.DeliveryPending}
+			payload string
+		)
+
+		err = rows.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &payload, &d.Attempts, &d.NextAttemptAt,
+			&d.CreatedAt, &d.URL, &d.Secret)
+		if err != nil {
+			ctx.Logger.Errorf("failed to scan webhook delivery: %v", err)
+			return nil, errors.Error("Scan Error")
+		}
+
+		d.Payload = []byte(payload)
+		deliveries = append(deliveries, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return deliveries, nil
+}
+
+// DeliverySucceeded records that the webhook answered the delivery with the 2xx status.
+func (s store) DeliverySucceeded(ctx *gofr.Context, id int64, status int) error {
+	return s.exec(ctx, "update webhook_delivery set status = $1,response_status = $2,last_error = null,"+
+		"delivered_at = $3,locked_until = null where id = $4", model.DeliverySucceeded, status, s.timestamp(), id)
+}
+
+// DeliveryRetry records a failed attempt, answered with status unless the webhook could not be reached, and makes
+// the delivery due again at.
+func (s store) DeliveryRetry(ctx *gofr.Context, id int64, at time.Time, status *int, reason string) error {
+	return s.exec(ctx, "update webhook_delivery set attempts = attempts + 1,response_status = $1,last_error = $2,"+
+		"next_attempt_at = $3,locked_until = null where id = $4", status, reason, at.UTC().Truncate(time.Microsecond), id)
+}
+
+// DeliveryFailed records a failed attempt and gives up on the delivery until it is replayed.
+func (s store) DeliveryFailed(ctx *gofr.Context, id int64, status *int, reason string) error {
+	return s.exec(ctx, "update webhook_delivery set status = $1,attempts = attempts + 1,response_status = $2,"+
+		"last_error = $3,locked_until = null where id = $4", model.DeliveryFailed, status, reason, id)
+}
+
+func (s store) exec(ctx *gofr.Context, query string, args ...interface{}) error {
+	tracing.Statement(ctx, query)
+
+	if _, err := ctx.DB().DB.Exec(query, args...); err != nil {
+		ctx.Logger.Errorf("failed to update webhook delivery: %v", err)
+		return errors.Error("Internal DB Error")
+	}
+
+	return nil
+}
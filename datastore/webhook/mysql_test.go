@@ -0,0 +1,370 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+
+	"developer.zopsmart.com/go/gofr/pkg/datastore"
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"developer.zopsmart.com/go/gofr/pkg/log"
+
+	"example/domain"
+	"example/model"
+)
+
+func newContext(t *testing.T) (*gofr.Context, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("database error %s", err)
+	}
+
+	t.Cleanup(func() { db.Close() })
+
+	g := gofr.Gofr{DataStore: datastore.DataStore{ORM: db}, Logger: log.NewMockLogger(io.Discard)}
+	ctx := gofr.NewContext(nil, nil, &g)
+	ctx.Context = context.Background()
+
+	return ctx, mock
+}
+
+func webhookRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "url", "secret", "events", "created_at", "created_by", "updated_at"})
+}
+
+func deliveryRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "webhook_id", "event_id", "event_type", "status", "attempts", "response_status",
+		"last_error", "next_attempt_at", "created_at", "delivered_at"})
+}
+
+func TestStore_WebhookGet(t *testing.T) {
+	ctx, mock := newContext(t)
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	query := "select " + columns + " from webhook order by id"
+
+	testcases := []struct {
+		desc   string
+		output []model.Webhook
+		err    error
+		mock   []interface{}
+	}{
+		{"success", []model.Webhook{
+			{ID: 1, URL: "https://example.com/hook", Secret: "0123456789abcdef", Events: []model.EventType{},
+				CreatedAt: now, CreatedBy: "ram", UpdatedAt: now},
+			{ID: 2, URL: "https://example.org", Secret: "fedcba9876543210", Events: []model.EventType{
+				model.EmployeeCreated}, CreatedAt: now, CreatedBy: "sai", UpdatedAt: now},
+		}, nil, []interface{}{mock.ExpectQuery(query).WillReturnRows(webhookRows().
+			AddRow(1, "https://example.com/hook", "0123456789abcdef", "{}", now, "ram", now).
+			AddRow(2, "https://example.org", "fedcba9876543210", "{EmployeeCreated}", now, "sai", now))}},
+		{"failure", nil, errors.DB{Err: errors.Error("Internal DB error")}, []interface{}{mock.ExpectQuery(query).
+			WillReturnError(errors.Error("connection reset"))}},
+		{"scan error", nil, errors.Error("Scan Error"), []interface{}{mock.ExpectQuery(query).WillReturnRows(
+			webhookRows().AddRow("x", "", "", "{}", now, "", now))}},
+	}
+
+	for i, tc := range testcases {
+		resp, err := New().WebhookGet(ctx)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestStore_WebhookGetByID(t *testing.T) {
+	ctx, mock := newContext(t)
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	query := "select " + columns + " from webhook where id = $1"
+
+	testcases := []struct {
+		desc   string
+		id     int
+		output model.Webhook
+		err    error
+		mock   []interface{}
+	}{
+		{"success", 1, model.Webhook{ID: 1, URL: "https://example.com", Secret: "0123456789abcdef",
+			Events: []model.EventType{model.EmployeeUpdated}, CreatedAt: now, UpdatedAt: now}, nil,
+			[]interface{}{mock.ExpectQuery(query).WithArgs(1).WillReturnRows(webhookRows().
+				AddRow(1, "https://example.com", "0123456789abcdef", "{EmployeeUpdated}", now, "", now))}},
+		{"not found", 2, model.Webhook{}, errors.EntityNotFound{Entity: "webhook", ID: "2"},
+			[]interface{}{mock.ExpectQuery(query).WithArgs(2).WillReturnRows(webhookRows())}},
+		{"failure", 3, model.Webhook{}, errors.Error("Scan Error"), []interface{}{mock.ExpectQuery(query).WithArgs(3).
+			WillReturnError(errors.Error("connection reset"))}},
+	}
+
+	for i, tc := range testcases {
+		resp, err := New().WebhookGetByID(ctx, tc.id)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestStore_WebhookWrite(t *testing.T) {
+	ctx, mock := newContext(t)
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	s := store{now: func() time.Time { return now }}
+
+	insert := "insert into webhook(url,secret,events,created_at,created_by,updated_at) VALUES ($1,$2,$3,$4,$5,$4) " +
+		"returning id"
+	update := "update webhook set url = $1,secret = $2,events = $3,updated_at = $4 where id = $5"
+	query := "select " + columns + " from webhook where id = $1"
+	input := model.Webhook{URL: "https://example.com", Secret: "0123456789abcdef",
+		Events: []model.EventType{model.EmployeeCreated}, CreatedBy: "ram"}
+	created := input
+	created.ID, created.CreatedAt, created.UpdatedAt = 3, now, now
+	updated := created
+	updated.ID = 4
+
+	testcases := []struct {
+		desc   string
+		write  func() (model.Webhook, error)
+		output model.Webhook
+		err    error
+		mock   []interface{}
+	}{
+		{"create", func() (model.Webhook, error) { return s.WebhookCreate(ctx, input) }, created, nil,
+			[]interface{}{mock.ExpectQuery(insert).WithArgs("https://example.com", "0123456789abcdef",
+				pq.StringArray{"EmployeeCreated"}, now, "ram").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))}},
+		{"create failure", func() (model.Webhook, error) { return s.WebhookCreate(ctx, input) }, model.Webhook{},
+			errors.Error("Internal DB Error"), []interface{}{mock.ExpectQuery(insert).
+				WillReturnError(errors.Error("connection reset"))}},
+		{"update", func() (model.Webhook, error) { return s.WebhookUpdate(ctx, updated) }, updated, nil,
+			[]interface{}{mock.ExpectExec(update).WithArgs("https://example.com", "0123456789abcdef",
+				pq.StringArray{"EmployeeCreated"}, now, 4).WillReturnResult(sqlmock.NewResult(0, 1)),
+				mock.ExpectQuery(query).WithArgs(4).WillReturnRows(webhookRows().AddRow(4, "https://example.com",
+					"0123456789abcdef", "{EmployeeCreated}", now, "ram", now))}},
+		{"update not found", func() (model.Webhook, error) { return s.WebhookUpdate(ctx, model.Webhook{ID: 5}) },
+			model.Webhook{}, errors.EntityNotFound{Entity: "webhook", ID: "5"}, []interface{}{mock.ExpectExec(update).
+				WillReturnResult(sqlmock.NewResult(0, 0))}},
+		{"update failure", func() (model.Webhook, error) { return s.WebhookUpdate(ctx, model.Webhook{ID: 6}) },
+			model.Webhook{}, errors.Error("Internal DB Error"), []interface{}{mock.ExpectExec(update).
+				WillReturnError(errors.Error("connection reset"))}},
+	}
+
+	for i, tc := range testcases {
+		resp, err := tc.write()
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestStore_WebhookDelete(t *testing.T) {
+	ctx, mock := newContext(t)
+	exec := "delete from webhook where id = $1"
+
+	testcases := []struct {
+		desc string
+		id   int
+		err  error
+		mock []interface{}
+	}{
+		{"success", 1, nil, []interface{}{mock.ExpectExec(exec).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))}},
+		{"not found", 2, errors.EntityNotFound{Entity: "webhook", ID: "2"}, []interface{}{mock.ExpectExec(exec).
+			WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 0))}},
+		{"failure", 3, errors.Error("Internal DB Error"), []interface{}{mock.ExpectExec(exec).WithArgs(3).
+			WillReturnError(errors.Error("connection reset"))}},
+	}
+
+	for i, tc := range testcases {
+		if err := New().WebhookDelete(ctx, tc.id); !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestStore_DeliveryLog(t *testing.T) {
+	ctx, mock := newContext(t)
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	s := store{now: func() time.Time { return now }}
+
+	list := "select " + deliveryColumns + " from webhook_delivery where webhook_id = $1 and ($2 = '' or status = $2) " +
+		"order by id desc limit $3"
+	query := "select " + deliveryColumns + " from webhook_delivery where id = $1 and webhook_id = $2"
+	replay := "update webhook_delivery set status = $1,attempts = 0,next_attempt_at = $2,locked_until = null " +
+		"where id = $3 and webhook_id = $4 and status = $5"
+
+	status := 200
+	succeeded := model.Delivery{ID: 2, WebhookID: 1, EventID: "e2", EventType: model.EmployeeUpdated,
+		Status: model.DeliverySucceeded, Attempts: 1, ResponseStatus: &status, NextAttemptAt: now, CreatedAt: now,
+		DeliveredAt: &now}
+	failed := model.Delivery{ID: 1, WebhookID: 1, EventID: "e1", EventType: model.EmployeeCreated,
+		Status: model.DeliveryFailed, Attempts: 8, LastError: "connection refused", NextAttemptAt: now, CreatedAt: now}
+	replayed := failed
+	replayed.Status, replayed.Attempts = model.DeliveryPending, 0
+
+	testcases := []struct {
+		desc   string
+		call   func() (interface{}, error)
+		output interface{}
+		err    error
+		mock   []interface{}
+	}{
+		{"list", func() (interface{}, error) { return s.DeliveryGet(ctx, 1, "", 100) },
+			[]model.Delivery{succeeded, failed}, nil, []interface{}{mock.ExpectQuery(list).WithArgs(1, "", 100).
+				WillReturnRows(deliveryRows().
+					AddRow(2, 1, "e2", "EmployeeUpdated", "succeeded", 1, 200, nil, now, now, now).
+					AddRow(1, 1, "e1", "EmployeeCreated", "failed", 8, nil, "connection refused", now, now, nil))}},
+		{"list failure", func() (interface{}, error) { return s.DeliveryGet(ctx, 1, model.DeliveryFailed, 10) },
+			[]model.Delivery(nil), errors.DB{Err: errors.Error("Internal DB error")}, []interface{}{
+				mock.ExpectQuery(list).WithArgs(1, "failed", 10).WillReturnError(errors.Error("connection reset"))}},
+		{"get", func() (interface{}, error) { return s.DeliveryGetByID(ctx, 1, 2) }, succeeded, nil,
+			[]interface{}{mock.ExpectQuery(query).WithArgs(2, 1).WillReturnRows(deliveryRows().
+				AddRow(2, 1, "e2", "EmployeeUpdated", "succeeded", 1, 200, nil, now, now, now))}},
+		{"get not found", func() (interface{}, error) { return s.DeliveryGetByID(ctx, 1, 3) }, model.Delivery{},
+			errors.EntityNotFound{Entity: "delivery", ID: "3"}, []interface{}{mock.ExpectQuery(query).WithArgs(3, 1).
+				WillReturnRows(deliveryRows())}},
+		{"replay", func() (interface{}, error) { return s.DeliveryReplay(ctx, 1, 1) }, replayed, nil,
+			[]interface{}{mock.ExpectExec(replay).WithArgs(model.DeliveryPending, now, 1, 1, model.DeliveryFailed).
+				WillReturnResult(sqlmock.NewResult(0, 1)), mock.ExpectQuery(query).WithArgs(1, 1).
+				WillReturnRows(deliveryRows().
+					AddRow(1, 1, "e1", "EmployeeCreated", "pending", 0, nil, "connection refused", now, now, nil))}},
+		{"replay not failed", func() (interface{}, error) { return s.DeliveryReplay(ctx, 1, 2) }, model.Delivery{},
+			domain.Conflict{Entity: "delivery", Reason: "delivery 2 is pending, only failed deliveries can be replayed"},
+			[]interface{}{mock.ExpectExec(replay).WillReturnResult(sqlmock.NewResult(0, 0)),
+				mock.ExpectQuery(query).WithArgs(2, 1).WillReturnRows(deliveryRows().
+					AddRow(2, 1, "e1", "EmployeeCreated", "pending", 0, nil, "", now, now, nil))}},
+		{"replay not found", func() (interface{}, error) { return s.DeliveryReplay(ctx, 1, 4) }, model.Delivery{},
+			errors.EntityNotFound{Entity: "delivery", ID: "4"}, []interface{}{mock.ExpectExec(replay).
+				WillReturnResult(sqlmock.NewResult(0, 0)), mock.ExpectQuery(query).WithArgs(4, 1).
+				WillReturnRows(deliveryRows())}},
+	}
+
+	for i, tc := range testcases {
+		resp, err := tc.call()
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestStore_Publish(t *testing.T) {
+	ctx, mock := newContext(t)
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	s := store{now: func() time.Time { return now }}
+
+	event := model.Event{ID: "e1", Type: model.EmployeeCreated, SchemaVersion: 1, Employee: model.Employee{ID: 2}}
+	payload, _ := json.Marshal(event)
+
+	testcases := []struct {
+		desc string
+		err  error
+		mock []interface{}
+	}{
+		{"success", nil, []interface{}{mock.ExpectExec(enqueue).WithArgs("e1", model.EmployeeCreated, string(payload),
+			now).WillReturnResult(sqlmock.NewResult(0, 2))}},
+		{"failure", errors.Error("Internal DB Error"), []interface{}{mock.ExpectExec(enqueue).
+			WillReturnError(errors.Error("connection reset"))}},
+	}
+
+	for i, tc := range testcases {
+		if err := s.Publish(ctx, event); !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestStore_DeliveryClaim(t *testing.T) {
+	ctx, mock := newContext(t)
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	s := store{now: func() time.Time { return now }}
+
+	rows := sqlmock.NewRows([]string{"id", "webhook_id", "event_id", "event_type", "payload", "attempts",
+		"next_attempt_at", "created_at", "url", "secret"})
+
+	testcases := []struct {
+		desc   string
+		output []model.Delivery
+		err    error
+		mock   []interface{}
+	}{
+		{"success", []model.Delivery{{ID: 1, WebhookID: 2, EventID: "e1", EventType: model.EmployeeCreated,
+			Status: model.DeliveryPending, Attempts: 1, NextAttemptAt: now, CreatedAt: now, URL: "https://example.com",
+			Secret: "0123456789abcdef", Payload: []byte(`{"id":"e1"}`)}}, nil, []interface{}{mock.ExpectQuery(claim).
+			WithArgs(now.Add(time.Minute), now, 20).WillReturnRows(rows.AddRow(1, 2, "e1", "EmployeeCreated",
+			`{"id":"e1"}`, 1, now, now, "https://example.com", "0123456789abcdef"))}},
+		{"failure", nil, errors.DB{Err: errors.Error("Internal DB error")}, []interface{}{mock.ExpectQuery(claim).
+			WillReturnError(errors.Error("connection reset"))}},
+	}
+
+	for i, tc := range testcases {
+		resp, err := s.DeliveryClaim(ctx, 20, time.Minute)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestStore_DeliveryOutcome(t *testing.T) {
+	ctx, mock := newContext(t)
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	s := store{now: func() time.Time { return now }}
+
+	succeeded := "update webhook_delivery set status = $1,response_status = $2,last_error = null,delivered_at = $3," +
+		"locked_until = null where id = $4"
+	retry := "update webhook_delivery set attempts = attempts + 1,response_status = $1,last_error = $2," +
+		"next_attempt_at = $3,locked_until = null where id = $4"
+	failed := "update webhook_delivery set status = $1,attempts = attempts + 1,response_status = $2,last_error = $3," +
+		"locked_until = null where id = $4"
+	status := 500
+
+	testcases := []struct {
+		desc   string
+		record func() error
+		err    error
+		mock   []interface{}
+	}{
+		{"succeeded", func() error { return s.DeliverySucceeded(ctx, 1, 204) }, nil, []interface{}{
+			mock.ExpectExec(succeeded).WithArgs(model.DeliverySucceeded, 204, now, 1).
+				WillReturnResult(sqlmock.NewResult(0, 1))}},
+		{"retry", func() error { return s.DeliveryRetry(ctx, 2, now.Add(time.Minute), &status, "webhook answered 500") },
+			nil, []interface{}{mock.ExpectExec(retry).WithArgs(500, "webhook answered 500", now.Add(time.Minute), 2).
+				WillReturnResult(sqlmock.NewResult(0, 1))}},
+		{"failed", func() error { return s.DeliveryFailed(ctx, 3, nil, "connection refused") }, nil, []interface{}{
+			mock.ExpectExec(failed).WithArgs(model.DeliveryFailed, nil, "connection refused", 3).
+				WillReturnResult(sqlmock.NewResult(0, 1))}},
+		{"failure", func() error { return s.DeliverySucceeded(ctx, 4, 200) }, errors.Error("Internal DB Error"),
+			[]interface{}{mock.ExpectExec(succeeded).WillReturnError(errors.Error("connection reset"))}},
+	}
+
+	for i, tc := range testcases {
+		if err := tc.record(); !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// Package domain defines the errors the services fail with, whichever store they run on. problem.From maps them to
+// the status every transport answers them with.
+package domain
+
+import "developer.zopsmart.com/go/gofr/pkg/errors"
+
+// NotFound reports that no entity has the id looked up.
+type NotFound = errors.EntityNotFound
+
+// Invalid reports the parameters of a request that do not hold acceptable values.
+type Invalid = errors.InvalidParam
+
+// Conflict reports a change refused because of what is stored: another entity already holds the same value of the
+// unique Field or, when Field is empty, the entity is not in a state the change applies to, as Reason explains.
+type Conflict struct {
+	Entity string
+	Field  string
+	Reason string
+}
+
+func (c Conflict) Error() string {
+	if c.Reason != "" {
+		return c.Reason
+	}
+
+	return c.Entity + " with the same " + c.Field + " already exists"
+}
+
+// Unavailable reports that a dependency of the service, such as its database, failed. Err is logged but never shown
+// to clients.
+type Unavailable struct {
+	Err error
+}
+
+func (u Unavailable) Error() string {
+	if u.Err == nil {
+		return "dependency unavailable"
+	}
+
+	return "dependency unavailable: " + u.Err.Error()
+}
+
+func (u Unavailable) Unwrap() error {
+	return u.Err
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"testing"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+)
+
+func TestErrors(t *testing.T) {
+	testcases := []struct {
+		desc string
+		err  error
+		msg  string
+	}{
+		{"conflict", Conflict{Entity: "employee", Field: "email"}, "employee with the same email already exists"},
+		{"conflict reason", Conflict{Entity: "department", Reason: "department 2 still has 1 employees"},
+			"department 2 still has 1 employees"},
+		{"unavailable", Unavailable{Err: errors.Error("connection refused")},
+			"dependency unavailable: connection refused"},
+		{"unavailable without cause", Unavailable{}, "dependency unavailable"},
+	}
+
+	for i, tc := range testcases {
+		if msg := tc.err.Error(); msg != tc.msg {
+			t.Errorf("[Test %v]Failed. Expected %q but got %q", i+1, tc.msg, msg)
+		}
+	}
+
+	if cause := (Unavailable{Err: errors.DB{}}).Unwrap(); cause != (errors.DB{}) {
+		t.Errorf("[Test %v]Failed. Expected %v but got %v", len(testcases)+1, errors.DB{}, cause)
+	}
+}
@@ -0,0 +1,168 @@
+package graph
+
+import (
+	"context"
+	"sync"
+
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/model"
+	"example/service"
+)
+
+type loaderKey struct{}
+
+// loader makes the service calls of one GraphQL request. Employees are loaded in batches: the managers of every
+// employee a resolver returns are queued, and loading any queued employee fetches all of them with a single
+// GetEmp, so resolving the manager of n employees costs one query rather than n. Departments are few, and are all
+// fetched with the first department resolved. Calls are serialized, since resolvers run concurrently and share the
+// request's *gofr.Context.
+type loader struct {
+	mu          sync.Mutex
+	ctx         *gofr.Context
+	employees   service.EmpService
+	departments service.DeptService
+
+	loaded map[int]*model.Employee
+	queued []int
+	depts  map[int]model.Department
+}
+
+func newLoader(c *gofr.Context, emp service.EmpService, dept service.DeptService) *loader {
+	return &loader{ctx: c, employees: emp, departments: dept, loaded: map[int]*model.Employee{}}
+}
+
+func loaderFrom(ctx context.Context) *loader {
+	l, _ := ctx.Value(loaderKey{}).(*loader)
+	return l
+}
+
+// call runs fn with the request's context, one call at a time.
+func (l *loader) call(fn func(c *gofr.Context) error) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return fn(l.ctx)
+}
+
+// list returns the employees matching filter, remembering them and queueing their managers.
+func (l *loader) list(filter model.Filter) ([]model.Employee, error) {
+	var resp []model.Employee
+
+	err := l.call(func(c *gofr.Context) (err error) {
+		resp, err = l.employees.GetEmp(c, filter)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	l.remember(resp...)
+	l.mu.Unlock()
+
+	return resp, nil
+}
+
+// remember records employees as loaded and queues their managers. l.mu must be held.
+func (l *loader) remember(employees ...model.Employee) {
+	for i := range employees {
+		e := employees[i]
+		l.loaded[e.ID] = &e
+	}
+
+	for i := range employees {
+		if m := employees[i].ManagerID; m != nil {
+			if _, ok := l.loaded[*m]; !ok {
+				l.loaded[*m] = nil
+				l.queued = append(l.queued, *m)
+			}
+		}
+	}
+}
+
+// employee returns the employee with the given id, fetching it along with every queued employee unless it was
+// loaded already. It returns nil when there is no such employee.
+func (l *loader) employee(id int) (*model.Employee, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e, known := l.loaded[id]
+	if e != nil {
+		return e, nil
+	}
+
+	if !known {
+		l.queued = append(l.queued, id)
+	} else if !contains(l.queued, id) {
+		return nil, nil
+	}
+
+	ids := l.queued
+	l.queued = nil
+
+	resp, err := l.employees.GetEmp(l.ctx, model.Filter{IDs: ids})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		l.loaded[id] = nil
+	}
+
+	l.remember(resp...)
+
+	return l.loaded[id], nil
+}
+
+// allDepartments returns every department, remembering them for department.
+func (l *loader) allDepartments() ([]model.Department, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.loadDepartments()
+}
+
+// department returns the department with the given id, or nil when there is none.
+func (l *loader) department(id int) (*model.Department, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.depts == nil {
+		if _, err := l.loadDepartments(); err != nil {
+			return nil, err
+		}
+	}
+
+	d, ok := l.depts[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return &d, nil
+}
+
+// loadDepartments fetches every department. l.mu must be held.
+func (l *loader) loadDepartments() ([]model.Department, error) {
+	resp, err := l.departments.GetDept(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	l.depts = make(map[int]model.Department, len(resp))
+	for _, d := range resp {
+		l.depts[d.ID] = d
+	}
+
+	return resp, nil
+}
+
+func contains(ids []int, id int) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+
+	return false
+}
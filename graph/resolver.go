@@ -0,0 +1,366 @@
+package graph
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"github.com/graph-gophers/graphql-go"
+
+	"example/model"
+)
+
+// resolver resolves the Query and Mutation types of the schema through the loader of the request.
+type resolver struct{}
+
+type employeeFilter struct {
+	Title        *string
+	Status       *string
+	DepartmentID *graphql.ID
+	ManagerID    *graphql.ID
+	AfterID      *graphql.ID
+	Limit        *int32
+}
+
+type employeeInput struct {
+	Name         string
+	Email        string
+	Title        *string
+	Status       *string
+	HireDate     *string
+	DateOfBirth  *string
+	DepartmentID *graphql.ID
+	ManagerID    *graphql.ID
+}
+
+func (*resolver) Employees(ctx context.Context, args struct{ Filter *employeeFilter }) ([]*employeeResolver, error) {
+	filter, err := args.Filter.filter()
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	resp, err := loaderFrom(ctx).list(filter)
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	return employees(resp), nil
+}
+
+func (*resolver) Employee(ctx context.Context, args struct{ ID graphql.ID }) (*employeeResolver, error) {
+	id, err := parseID(&args.ID, "id")
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	return loadEmployee(ctx, *id)
+}
+
+func (*resolver) Departments(ctx context.Context) ([]*departmentResolver, error) {
+	resp, err := loaderFrom(ctx).allDepartments()
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	depts := make([]*departmentResolver, len(resp))
+	for i := range resp {
+		depts[i] = &departmentResolver{d: resp[i]}
+	}
+
+	return depts, nil
+}
+
+func (*resolver) Department(ctx context.Context, args struct{ ID graphql.ID }) (*departmentResolver, error) {
+	id, err := parseID(&args.ID, "id")
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	return loadDepartment(ctx, *id)
+}
+
+func (*resolver) CreateEmployee(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input employeeInput
+}) (*employeeResolver, error) {
+	id, err := parseID(&args.ID, "id")
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	e, err := args.Input.employee()
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	e.ID = *id
+
+	return save(ctx, func(c *gofr.Context) (model.Employee, error) {
+		return loaderFrom(ctx).employees.CreateEmp(c, e)
+	})
+}
+
+func (*resolver) UpdateEmployee(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input employeeInput
+}) (*employeeResolver, error) {
+	id, err := parseID(&args.ID, "id")
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	e, err := args.Input.employee()
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	e.ID = *id
+
+	return save(ctx, func(c *gofr.Context) (model.Employee, error) {
+		return loaderFrom(ctx).employees.UpdateEmp(c, e)
+	})
+}
+
+// save runs a mutation and remembers the employee it returns.
+func save(ctx context.Context, fn func(c *gofr.Context) (model.Employee, error)) (*employeeResolver, error) {
+	l := loaderFrom(ctx)
+
+	var resp model.Employee
+
+	err := l.call(func(c *gofr.Context) (err error) {
+		if resp, err = fn(c); err == nil {
+			l.remember(resp)
+		}
+
+		return err
+	})
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	return &employeeResolver{e: resp}, nil
+}
+
+func loadEmployee(ctx context.Context, id int) (*employeeResolver, error) {
+	e, err := loaderFrom(ctx).employee(id)
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	if e == nil {
+		return nil, nil
+	}
+
+	return &employeeResolver{e: *e}, nil
+}
+
+func loadDepartment(ctx context.Context, id int) (*departmentResolver, error) {
+	d, err := loaderFrom(ctx).department(id)
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	if d == nil {
+		return nil, nil
+	}
+
+	return &departmentResolver{d: *d}, nil
+}
+
+type employeeResolver struct {
+	e model.Employee
+}
+
+func employees(resp []model.Employee) []*employeeResolver {
+	r := make([]*employeeResolver, len(resp))
+	for i := range resp {
+		r[i] = &employeeResolver{e: resp[i]}
+	}
+
+	return r
+}
+
+func (r *employeeResolver) ID() graphql.ID {
+	return graphql.ID(strconv.Itoa(r.e.ID))
+}
+
+func (r *employeeResolver) Name() string {
+	return r.e.Name
+}
+
+func (r *employeeResolver) Email() string {
+	return r.e.Email
+}
+
+func (r *employeeResolver) Title() string {
+	return r.e.Title
+}
+
+func (r *employeeResolver) Status() string {
+	return strings.ToUpper(string(r.e.Status))
+}
+
+func (r *employeeResolver) HireDate() *string {
+	return date(r.e.HireDate)
+}
+
+func (r *employeeResolver) DateOfBirth() *string {
+	return date(r.e.DateOfBirth)
+}
+
+func (r *employeeResolver) Age() int32 {
+	return int32(r.e.Age)
+}
+
+// Department is loaded with every other department of the request.
+func (r *employeeResolver) Department(ctx context.Context) (*departmentResolver, error) {
+	if r.e.DepartmentID == nil {
+		return nil, nil
+	}
+
+	return loadDepartment(ctx, *r.e.DepartmentID)
+}
+
+// Manager is loaded in a batch with the managers of the other employees resolved so far.
+func (r *employeeResolver) Manager(ctx context.Context) (*employeeResolver, error) {
+	if r.e.ManagerID == nil {
+		return nil, nil
+	}
+
+	return loadEmployee(ctx, *r.e.ManagerID)
+}
+
+func (r *employeeResolver) CreatedAt() string {
+	return r.e.CreatedAt.Format(time.RFC3339Nano)
+}
+
+func (r *employeeResolver) CreatedBy() string {
+	return r.e.CreatedBy
+}
+
+func (r *employeeResolver) UpdatedAt() string {
+	return r.e.UpdatedAt.Format(time.RFC3339Nano)
+}
+
+func (r *employeeResolver) UpdatedBy() string {
+	return r.e.UpdatedBy
+}
+
+type departmentResolver struct {
+	d model.Department
+}
+
+func (r *departmentResolver) ID() graphql.ID {
+	return graphql.ID(strconv.Itoa(r.d.ID))
+}
+
+func (r *departmentResolver) Name() string {
+	return r.d.Name
+}
+
+func (f *employeeFilter) filter() (model.Filter, error) {
+	var (
+		filter model.Filter
+		err    error
+	)
+
+	if f == nil {
+		return filter, nil
+	}
+
+	filter.Title = value(f.Title)
+	filter.Status = status(f.Status)
+
+	if filter.DepartmentID, err = parseID(f.DepartmentID, "departmentId"); err != nil {
+		return filter, err
+	}
+
+	if filter.ManagerID, err = parseID(f.ManagerID, "managerId"); err != nil {
+		return filter, err
+	}
+
+	if filter.AfterID, err = parseID(f.AfterID, "afterId"); err != nil {
+		return filter, err
+	}
+
+	if f.Limit != nil {
+		filter.Limit = int(*f.Limit)
+	}
+
+	return filter, nil
+}
+
+func (in employeeInput) employee() (model.Employee, error) {
+	e := model.Employee{Name: in.Name, Email: in.Email, Title: value(in.Title), Status: status(in.Status)}
+
+	var err error
+
+	if e.HireDate, err = parseDate(in.HireDate, "hireDate"); err != nil {
+		return e, err
+	}
+
+	if e.DateOfBirth, err = parseDate(in.DateOfBirth, "dateOfBirth"); err != nil {
+		return e, err
+	}
+
+	if e.DepartmentID, err = parseID(in.DepartmentID, "departmentId"); err != nil {
+		return e, err
+	}
+
+	if e.ManagerID, err = parseID(in.ManagerID, "managerId"); err != nil {
+		return e, err
+	}
+
+	return e, nil
+}
+
+func parseID(id *graphql.ID, name string) (*int, error) {
+	if id == nil {
+		return nil, nil
+	}
+
+	i, err := strconv.Atoi(string(*id))
+	if err != nil {
+		return nil, errors.InvalidParam{Param: []string{name}}
+	}
+
+	return &i, nil
+}
+
+func parseDate(s *string, name string) (model.Date, error) {
+	if s == nil || *s == "" {
+		return model.Date{}, nil
+	}
+
+	d, err := model.ParseDate(*s)
+	if err != nil {
+		return model.Date{}, errors.InvalidParam{Param: []string{name}}
+	}
+
+	return d, nil
+}
+
+func date(d model.Date) *string {
+	if d.IsZero() {
+		return nil
+	}
+
+	s := d.String()
+
+	return &s
+}
+
+func status(s *string) model.Status {
+	return model.Status(strings.ToLower(value(s)))
+}
+
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
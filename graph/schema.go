@@ -0,0 +1,108 @@
+// Package graph serves the employees and departments over GraphQL, resolving schema.graphql with the same services
+// as the HTTP handlers.
+package graph
+
+import (
+	"context"
+	_ "embed" // schema.graphql
+	"net/http"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"github.com/graph-gophers/graphql-go"
+
+	"example/problem"
+	"example/service"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// fields renames the employee fields the services reject to their name in the schema.
+var fields = map[string]string{
+	"date_of_birth": "dateOfBirth",
+	"hire_date":     "hireDate",
+	"department_id": "departmentId",
+	"manager_id":    "managerId",
+	"after_id":      "afterId",
+}
+
+type schema struct {
+	schema      *graphql.Schema
+	employees   service.EmpService
+	departments service.DeptService
+}
+
+// New parses the schema and resolves it with the employee and department services.
+// nolint:revive // schema should not be used without proper initialization with required dependency
+func New(emp service.EmpService, dept service.DeptService) (schema, error) {
+	s, err := graphql.ParseSchema(schemaSDL, &resolver{})
+	if err != nil {
+		return schema{}, err
+	}
+
+	return schema{schema: s, employees: emp, departments: dept}, nil
+}
+
+// Exec runs a GraphQL request. Its resolvers share a loader, which batches the employees and departments they load.
+func (s schema) Exec(c *gofr.Context, query, operation string, variables map[string]interface{}) *graphql.Response {
+	ctx := context.WithValue(c.Context, loaderKey{}, newLoader(c, s.employees, s.departments))
+
+	return s.schema.Exec(ctx, query, operation, variables)
+}
+
+// Error is a failed service call, reported to GraphQL clients with the problem details the HTTP API answers it with
+// as extensions.
+type Error struct {
+	problem.Problem
+}
+
+func (e Error) Error() string {
+	if e.Detail != "" {
+		return e.Detail
+	}
+
+	return e.Title
+}
+
+// Extensions returns the type and status of the problem, and the fields it rejected.
+func (e Error) Extensions() map[string]interface{} {
+	ext := map[string]interface{}{"type": e.Type, "status": e.Status}
+	if len(e.InvalidParams) > 0 {
+		ext["invalid_params"] = e.InvalidParams
+	}
+
+	return ext
+}
+
+// fail maps a service error to the Error clients get, naming rejected fields as the schema does.
+func fail(err error) error {
+	if e, ok := err.(errors.InvalidParam); ok {
+		params := make([]string, len(e.Param))
+
+		for i, p := range e.Param {
+			if name, ok := fields[p]; ok {
+				p = name
+			}
+
+			params[i] = p
+		}
+
+		err = errors.InvalidParam{Param: params}
+	}
+
+	return Error{Problem: problem.From(err)}
+}
+
+// Status returns the highest status of the errors in resp, or 200 when it has none.
+func Status(resp *graphql.Response) int {
+	status := http.StatusOK
+
+	for _, e := range resp.Errors {
+		if p, ok := e.ResolverError.(Error); ok && p.Status > status {
+			status = p.Status
+		}
+	}
+
+	return status
+}
@@ -0,0 +1,142 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/domain"
+	"example/model"
+	"example/service/mocks"
+)
+
+func exec(t *testing.T, s schema, query string, variables map[string]interface{}) (string, string) {
+	t.Helper()
+
+	ctx := gofr.NewContext(nil, nil, gofr.New())
+	ctx.Context = context.Background()
+
+	resp := s.Exec(ctx, query, "", variables)
+
+	errs := ""
+	if len(resp.Errors) > 0 {
+		b, _ := json.Marshal(resp.Errors)
+		errs = string(b)
+	}
+
+	return string(resp.Data), errs
+}
+
+func TestSchema_Batching(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	emp := mocks.NewMockEmpService(ctrl)
+	dept := mocks.NewMockDeptService(ctrl)
+
+	s, err := New(emp, dept)
+	if err != nil {
+		t.Fatalf("[Test %v]Failed. Expected the schema to parse but got %v", 1, err)
+	}
+
+	one, two, three, hr := 1, 2, 3, 4
+	listed := []model.Employee{
+		{ID: 2, Name: "Sai", ManagerID: &one, DepartmentID: &hr},
+		{ID: 5, Name: "Ram", ManagerID: &three, DepartmentID: &hr},
+		{ID: 6, Name: "Gopal", ManagerID: &two},
+	}
+
+	gomock.InOrder(
+		emp.EXPECT().GetEmp(gomock.Any(), model.Filter{Status: model.StatusActive, Limit: 3}).Return(listed, nil),
+		emp.EXPECT().GetEmp(gomock.Any(), model.Filter{IDs: []int{1, 3}}).
+			Return([]model.Employee{{ID: 1, Name: "Kiran"}, {ID: 3, Name: "Ravi", ManagerID: &one}}, nil),
+	)
+	dept.EXPECT().GetDept(gomock.Any()).Return([]model.Department{{ID: 4, Name: "HR"}}, nil)
+
+	data, errs := exec(t, s, `{ employees(filter: {status: ACTIVE, limit: 3}) {
+		id name department { name } manager { name manager { name } } } }`, nil)
+
+	expected := `{"employees":[` +
+		`{"id":"2","name":"Sai","department":{"name":"HR"},"manager":{"name":"Kiran","manager":null}},` +
+		`{"id":"5","name":"Ram","department":{"name":"HR"},"manager":{"name":"Ravi","manager":{"name":"Kiran"}}},` +
+		`{"id":"6","name":"Gopal","department":null,"manager":{"name":"Sai","manager":{"name":"Kiran"}}}]}`
+
+	if data != expected || errs != "" {
+		t.Errorf("[Test %v]Failed. Expected %v but got %v %v", 2, expected, data, errs)
+	}
+}
+
+func TestSchema_Employee(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	emp := mocks.NewMockEmpService(ctrl)
+	dept := mocks.NewMockDeptService(ctrl)
+	s, err := New(emp, dept)
+	if err != nil {
+		t.Fatalf("[Test %v]Failed. Expected the schema to parse but got %v", 1, err)
+	}
+
+	dob := model.NewDate(2000, 3, 1)
+
+	testcases := []struct {
+		desc      string
+		query     string
+		variables map[string]interface{}
+		data      string
+		errs      string
+		mock      []*gomock.Call
+	}{
+		{"found", `{ employee(id: "7") { name status dateOfBirth hireDate age } }`, nil,
+			`{"employee":{"name":"Ram","status":"ON_LEAVE","dateOfBirth":"2000-03-01","hireDate":null,"age":22}}`, "",
+			[]*gomock.Call{emp.EXPECT().GetEmp(gomock.Any(), model.Filter{IDs: []int{7}}).Return([]model.Employee{
+				{ID: 7, Name: "Ram", Status: model.StatusOnLeave, DateOfBirth: dob, Age: 22}}, nil)}},
+		{"not found", `{ employee(id: "8") { name } }`, nil, `{"employee":null}`, "", []*gomock.Call{
+			emp.EXPECT().GetEmp(gomock.Any(), model.Filter{IDs: []int{8}}).Return(nil, nil)}},
+		{"invalid id", `{ employee(id: "x") { name } }`, nil, `{"employee":null}`, invalid("employee", "id"), nil},
+		{"create", `mutation($in: EmployeeInput!) { createEmployee(id: "9", input: $in) { id manager { name } } }`,
+			map[string]interface{}{"in": map[string]interface{}{"name": "Ram", "email": "ram@example.com",
+				"dateOfBirth": "2000-03-01", "managerId": "1", "status": "ACTIVE"}},
+			`{"createEmployee":{"id":"9","manager":{"name":"Kiran"}}}`, "", []*gomock.Call{
+				emp.EXPECT().CreateEmp(gomock.Any(), model.Employee{ID: 9, Name: "Ram", Email: "ram@example.com",
+					DateOfBirth: dob, ManagerID: intPtr(1), Status: model.StatusActive}).
+					Return(model.Employee{ID: 9, Name: "Ram", ManagerID: intPtr(1)}, nil),
+				emp.EXPECT().GetEmp(gomock.Any(), model.Filter{IDs: []int{1}}).
+					Return([]model.Employee{{ID: 1, Name: "Kiran"}}, nil),
+			}},
+		{"update rejected", `mutation { updateEmployee(id: "9", input: {name: "Ram", email: "x"}) { id } }`, nil, "null",
+			invalid("updateEmployee", "email", "dateOfBirth"), []*gomock.Call{
+				emp.EXPECT().UpdateEmp(gomock.Any(), model.Employee{ID: 9, Name: "Ram", Email: "x"}).
+					Return(model.Employee{}, errors.InvalidParam{Param: []string{"email", "date_of_birth"}})}},
+		{"invalid date", `mutation { createEmployee(id: "9", input: {name: "Ram", email: "x", hireDate: "May"}) { id } }`,
+			nil, "null", invalid("createEmployee", "hireDate"), nil},
+		{"invalid create id", `mutation { createEmployee(id: "x", input: {name: "Ram", email: "x"}) { id } }`, nil, "null",
+			invalid("createEmployee", "id"), nil},
+		{"failure", `{ departments { id } }`, nil, "null",
+			`[{"message":"a dependency of the service is unavailable, retry later","path":["departments"],` +
+				`"extensions":{"status":503,"type":"/problems/service-unavailable"}}]`, []*gomock.Call{
+				dept.EXPECT().GetDept(gomock.Any()).Return(nil, domain.Unavailable{Err: errors.DB{}})}},
+	}
+
+	for i, tc := range testcases {
+		data, errs := exec(t, s, tc.query, tc.variables)
+
+		if data != tc.data || errs != tc.errs {
+			t.Errorf("[Test %v]Failed. Expected %v %v but got %v %v", i+1, tc.data, tc.errs, data, errs)
+		}
+	}
+}
+
+// invalid returns the errors of a response whose field at path failed with an InvalidParam for params.
+func invalid(path string, params ...string) string {
+	p, _ := json.Marshal(params)
+
+	return fmt.Sprintf(`[{"message":%q,"path":[%q],"extensions":{"invalid_params":%s,"status":400,`+
+		`"type":"/problems/bad-request"}}]`, errors.InvalidParam{Param: params}.Error(), path, p)
+}
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/requestctx"
+)
+
+// Admin lets only the given principals call the handlers it wraps, answering 403 to any other principal
+// authenticated by middleware.Oauth.
+func Admin(principals ...string) func(gofr.Handler) gofr.Handler {
+	admins := make(map[string]bool, len(principals))
+	for _, p := range principals {
+		admins[p] = true
+	}
+
+	return func(fn gofr.Handler) gofr.Handler {
+		return func(c *gofr.Context) (interface{}, error) {
+			if p := requestctx.Principal(c.Request().Context()); p == "" || !admins[p] {
+				return nil, &errors.Response{StatusCode: http.StatusForbidden, Code: "Forbidden",
+					Reason: "only admins are allowed to " + c.Request().Method + " " + c.Request().URL.Path}
+			}
+
+			return fn(c)
+		}
+	}
+}
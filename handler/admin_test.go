@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"developer.zopsmart.com/go/gofr/pkg/gofr/request"
+	"developer.zopsmart.com/go/gofr/pkg/gofr/responder"
+
+	"example/requestctx"
+)
+
+func TestAdmin(t *testing.T) {
+	app := gofr.New()
+	admin := Admin("ram", "sita")
+	fn := admin(func(c *gofr.Context) (interface{}, error) { return "ok", nil })
+	forbidden := &errors.Response{StatusCode: http.StatusForbidden, Code: "Forbidden",
+		Reason: "only admins are allowed to GET /webhooks"}
+
+	testcases := []struct {
+		desc      string
+		principal string
+		output    interface{}
+		err       error
+	}{
+		{"admin", "ram", "ok", nil},
+		{"other admin", "sita", "ok", nil},
+		{"not an admin", "shyam", nil, forbidden},
+		{"anonymous", "", nil, forbidden},
+	}
+
+	for i, tc := range testcases {
+		r := httptest.NewRequest(http.MethodGet, "/webhooks", nil)
+		r = r.WithContext(requestctx.WithPrincipal(r.Context(), tc.principal))
+		ctx := gofr.NewContext(responder.NewContextualResponder(httptest.NewRecorder(), r), request.NewHTTPRequest(r), app)
+
+		resp, err := fn(ctx)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
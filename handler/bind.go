@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+)
+
+// tooLarge is the error http.MaxBytesReader fails with once a body exceeds its limit.
+const tooLarge = "http: request body too large"
+
+// bind decodes the JSON body of the request into v. Unlike c.Bind it rejects unknown fields and data after the
+// JSON value, naming the offending field in an InvalidParam where there is one, and answers 413 when the body is
+// over the size limit set by middleware.MaxBodySize.
+func bind(c *gofr.Context, v interface{}) error {
+	r := c.Request()
+	if r.Body == nil {
+		return errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return bindError(err, body, v)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(v); err != nil {
+		return bindError(err, body, v)
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		if err != nil {
+			return bindError(err, body, v)
+		}
+
+		return errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	return nil
+}
+
+func bindError(err error, body []byte, v interface{}) error {
+	var field string
+
+	switch e := err.(type) {
+	case *json.UnmarshalTypeError:
+		field = e.Field
+		if field == "" {
+			field = typeErrorField(body, v, e.Type)
+		}
+	default:
+		msg := err.Error()
+
+		if strings.Contains(msg, tooLarge) {
+			return &errors.Response{
+				StatusCode: http.StatusRequestEntityTooLarge,
+				Code:       "Request Entity Too Large",
+				Reason:     "request body is too large",
+			}
+		}
+
+		if strings.HasPrefix(msg, `json: unknown field "`) {
+			field = strings.TrimSuffix(strings.TrimPrefix(msg, `json: unknown field "`), `"`)
+		}
+	}
+
+	if field == "" {
+		field = "body"
+	}
+
+	return errors.InvalidParam{Param: []string{field}}
+}
+
+// typeErrorField returns the top level field of v, of type t, whose value in body does not decode. encoding/json
+// leaves the field out of the type errors returned by UnmarshalJSON methods, such as the one of model.Date.
+func typeErrorField(body []byte, v interface{}, t reflect.Type) string {
+	var values map[string]json.RawMessage
+
+	if err := json.Unmarshal(body, &values); err != nil {
+		return ""
+	}
+
+	s := reflect.TypeOf(v)
+	for s.Kind() == reflect.Ptr {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return ""
+	}
+
+	for i := 0; i < s.NumField(); i++ {
+		f := s.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+
+		raw, ok := values[name]
+		if !ok || f.Type != t {
+			continue
+		}
+
+		if err := json.Unmarshal(raw, reflect.New(t).Interface()); err != nil {
+			return name
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"developer.zopsmart.com/go/gofr/pkg/gofr/request"
+	"developer.zopsmart.com/go/gofr/pkg/gofr/responder"
+
+	"example/middleware"
+	"example/model"
+)
+
+func TestBind(t *testing.T) {
+	app := gofr.New()
+	limit := middleware.MaxBodySize(32)
+
+	testcases := []struct {
+		desc   string
+		body   string
+		output model.Department
+		err    error
+	}{
+		{"valid", `{"id":1,"name":"Engineering"}`, model.Department{ID: 1, Name: "Engineering"}, nil},
+		{"unknown field", `{"id":1,"nmae":"Engineering"}`, model.Department{ID: 1}, errors.InvalidParam{Param: []string{"nmae"}}},
+		{"wrong type", `{"id":"one"}`, model.Department{}, errors.InvalidParam{Param: []string{"id"}}},
+		{"trailing data", `{"id":1} {"id":2}`, model.Department{ID: 1}, errors.InvalidParam{Param: []string{"body"}}},
+		{"trailing garbage", `{"id":1}]`, model.Department{ID: 1}, errors.InvalidParam{Param: []string{"body"}}},
+		{"syntax error", `{"id":1,}`, model.Department{}, errors.InvalidParam{Param: []string{"body"}}},
+		{"empty", ``, model.Department{}, errors.InvalidParam{Param: []string{"body"}}},
+		{"too large", `{"id":1,"name":"` + strings.Repeat("a", 64) + `"}`, model.Department{},
+			&errors.Response{StatusCode: http.StatusRequestEntityTooLarge, Code: "Request Entity Too Large",
+				Reason: "request body is too large"}},
+	}
+
+	for i, tc := range testcases {
+		var (
+			dept model.Department
+			err  error
+		)
+
+		r := httptest.NewRequest(http.MethodPost, "/departments", strings.NewReader(tc.body))
+		r.ContentLength = -1 // streamed, so that the limit is hit while decoding
+		w := httptest.NewRecorder()
+
+		limit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			err = bind(gofr.NewContext(responder.NewContextualResponder(w, r), request.NewHTTPRequest(r), app), &dept)
+		})).ServeHTTP(w, r)
+
+		if !reflect.DeepEqual(tc.output, dept) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, dept)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
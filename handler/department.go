@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"strconv"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/model"
+	"example/service"
+)
+
+type department struct {
+	service service.DeptService
+}
+
+// nolint:revive // handlers should not be used without proper initialization with required dependency
+func NewDepartment(s service.DeptService) department {
+	return department{service: s}
+}
+
+func pathID(c *gofr.Context) (int, error) {
+	i := c.PathParam("id")
+
+	if i == "" {
+		return 0, errors.InvalidParam{Param: []string{"id"}}
+	}
+
+	id, err := strconv.Atoi(i)
+
+	if err != nil {
+		return 0, errors.InvalidParam{Param: []string{"id"}}
+	}
+
+	return id, nil
+}
+
+func (d department) Get(c *gofr.Context) (interface{}, error) {
+	resp, err := d.service.GetDept(c)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (d department) GetByID(c *gofr.Context) (interface{}, error) {
+	id, err := pathID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.service.GetDeptByID(c, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (d department) Create(c *gofr.Context) (interface{}, error) {
+	var dept model.Department
+
+	if err := bind(c, &dept); err != nil {
+		return nil, err
+	}
+
+	resp, err := d.service.CreateDept(c, dept)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (d department) Update(c *gofr.Context) (interface{}, error) {
+	var dept model.Department
+
+	id, err := pathID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = bind(c, &dept); err != nil {
+		return nil, err
+	}
+
+	dept.ID = id
+
+	resp, err := d.service.UpdateDept(c, dept)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (d department) Delete(c *gofr.Context) (interface{}, error) {
+	id, err := pathID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = d.service.DeleteDept(c, id); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (d department) GetEmployees(c *gofr.Context) (interface{}, error) {
+	id, err := pathID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.service.GetDeptEmployees(c, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
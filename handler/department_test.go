@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"developer.zopsmart.com/go/gofr/pkg/gofr/request"
+	"developer.zopsmart.com/go/gofr/pkg/gofr/responder"
+
+	"example/domain"
+	"example/model"
+	"example/service/mocks"
+)
+
+func newDeptContext(app *gofr.Gofr, method, id string, body []byte) *gofr.Context {
+	r := httptest.NewRequest(method, "/departments/{id}", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	ctx := gofr.NewContext(responder.NewContextualResponder(w, r), request.NewHTTPRequest(r), app)
+	ctx.SetPathParams(map[string]string{"id": id})
+
+	return ctx
+}
+
+func TestDepartment_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockDeptService(ctrl)
+	h := NewDepartment(m)
+	app := gofr.New()
+
+	testcases := []struct {
+		desc   string
+		output interface{}
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"success", []model.Department{{ID: 1, Name: "Engineering"}}, nil, []*gomock.Call{
+			m.EXPECT().GetDept(gomock.Any()).Return([]model.Department{{ID: 1, Name: "Engineering"}}, nil),
+		}},
+		{"failure", nil, domain.Unavailable{Err: errors.DB{}}, []*gomock.Call{
+			m.EXPECT().GetDept(gomock.Any()).Return(nil, domain.Unavailable{Err: errors.DB{}}),
+		}},
+	}
+
+	for i, tc := range testcases {
+		resp, err := h.Get(newDeptContext(app, http.MethodGet, "", nil))
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestDepartment_GetByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockDeptService(ctrl)
+	h := department{service: m}
+	app := gofr.New()
+	notFound := errors.EntityNotFound{Entity: "department", ID: "2"}
+
+	testcases := []struct {
+		desc   string
+		id     string
+		output interface{}
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"success", "1", model.Department{ID: 1, Name: "Engineering"}, nil, []*gomock.Call{
+			m.EXPECT().GetDeptByID(gomock.Any(), 1).Return(model.Department{ID: 1, Name: "Engineering"}, nil),
+		}},
+		{"not found", "2", nil, notFound, []*gomock.Call{
+			m.EXPECT().GetDeptByID(gomock.Any(), 2).Return(model.Department{}, notFound),
+		}},
+		{"invalid id", "hr", nil, errors.InvalidParam{Param: []string{"id"}}, nil},
+	}
+
+	for i, tc := range testcases {
+		resp, err := h.GetByID(newDeptContext(app, http.MethodGet, tc.id, nil))
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestDepartment_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockDeptService(ctrl)
+	h := department{service: m}
+	app := gofr.New()
+
+	testcases := []struct {
+		desc   string
+		req    []byte
+		output interface{}
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"success", []byte(`{"id":1,"name":"Engineering"}`), model.Department{ID: 1, Name: "Engineering"}, nil,
+			[]*gomock.Call{m.EXPECT().CreateDept(gomock.Any(), model.Department{ID: 1, Name: "Engineering"}).
+				Return(model.Department{ID: 1, Name: "Engineering"}, nil)}},
+		{"invalid", []byte(`{"id":2}`), nil, errors.InvalidParam{Param: []string{"name"}}, []*gomock.Call{
+			m.EXPECT().CreateDept(gomock.Any(), model.Department{ID: 2}).
+				Return(model.Department{}, errors.InvalidParam{Param: []string{"name"}})}},
+		{"unmarshal error", []byte(``), nil, errors.InvalidParam{Param: []string{"body"}}, nil},
+	}
+
+	for i, tc := range testcases {
+		resp, err := h.Create(newDeptContext(app, http.MethodPost, "", tc.req))
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestDepartment_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockDeptService(ctrl)
+	h := department{service: m}
+	app := gofr.New()
+
+	testcases := []struct {
+		desc   string
+		id     string
+		req    []byte
+		output interface{}
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"success", "1", []byte(`{"name":"Platform"}`), model.Department{ID: 1, Name: "Platform"}, nil, []*gomock.Call{
+			m.EXPECT().UpdateDept(gomock.Any(), model.Department{ID: 1, Name: "Platform"}).
+				Return(model.Department{ID: 1, Name: "Platform"}, nil)}},
+		{"failure", "2", []byte(`{"name":"Sales"}`), nil, domain.Unavailable{Err: errors.DB{}}, []*gomock.Call{
+			m.EXPECT().UpdateDept(gomock.Any(), model.Department{ID: 2, Name: "Sales"}).
+				Return(model.Department{}, domain.Unavailable{Err: errors.DB{}})}},
+		{"invalid id", "", []byte(`{"name":"Sales"}`), nil, errors.InvalidParam{Param: []string{"id"}}, nil},
+		{"unmarshal error", "3", []byte(``), nil, errors.InvalidParam{Param: []string{"body"}}, nil},
+	}
+
+	for i, tc := range testcases {
+		resp, err := h.Update(newDeptContext(app, http.MethodPut, tc.id, tc.req))
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestDepartment_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockDeptService(ctrl)
+	h := department{service: m}
+	app := gofr.New()
+	conflict := domain.Conflict{Entity: "department",
+		Reason: "department 2 still has employees"}
+
+	testcases := []struct {
+		desc string
+		id   string
+		err  error
+		mock []*gomock.Call
+	}{
+		{"success", "1", nil, []*gomock.Call{m.EXPECT().DeleteDept(gomock.Any(), 1).Return(nil)}},
+		{"has employees", "2", conflict, []*gomock.Call{m.EXPECT().DeleteDept(gomock.Any(), 2).Return(conflict)}},
+		{"invalid id", "x", errors.InvalidParam{Param: []string{"id"}}, nil},
+	}
+
+	for i, tc := range testcases {
+		_, err := h.Delete(newDeptContext(app, http.MethodDelete, tc.id, nil))
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestDepartment_GetEmployees(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockDeptService(ctrl)
+	h := department{service: m}
+	app := gofr.New()
+	one := 1
+
+	testcases := []struct {
+		desc   string
+		id     string
+		output interface{}
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"success", "1", []model.Employee{{ID: 4, DepartmentID: &one}}, nil, []*gomock.Call{
+			m.EXPECT().GetDeptEmployees(gomock.Any(), 1).Return([]model.Employee{{ID: 4, DepartmentID: &one}}, nil),
+		}},
+		{"failure", "2", nil, domain.Unavailable{Err: errors.DB{}}, []*gomock.Call{
+			m.EXPECT().GetDeptEmployees(gomock.Any(), 2).Return(nil, domain.Unavailable{Err: errors.DB{}}),
+		}},
+	}
+
+	for i, tc := range testcases {
+		resp, err := h.GetEmployees(newDeptContext(app, http.MethodGet, tc.id, nil))
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"developer.zopsmart.com/go/gofr/pkg/gofr/request"
+	"github.com/graph-gophers/graphql-go"
+
+	"example/graph"
+	"example/middleware"
+	"example/problem"
+	"example/requestctx"
+)
+
+// executor runs GraphQL requests, see graph.New.
+type executor interface {
+	Exec(c *gofr.Context, query, operation string, variables map[string]interface{}) *graphql.Response
+}
+
+type graphQL struct {
+	schema executor
+	app    *gofr.Gofr
+}
+
+// NewGraphQL returns the handler of the GraphQL endpoint. It is a plain http handler, meant to be mounted with
+// middleware.Mount, since GraphQL responses are not wrapped in the data envelope of gofr responses.
+// nolint:revive // handlers should not be used without proper initialization with required dependency
+func NewGraphQL(s executor, app *gofr.Gofr) graphQL {
+	return graphQL{schema: s, app: app}
+}
+
+// ServeHTTP runs the query, operationName and variables of a POSTed JSON request. Failed resolvers are reported in
+// the errors of the response, which is always 200 once the request could be read.
+func (g graphQL) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Query         string                 `json:"query"`
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Query == "" {
+		status := http.StatusBadRequest
+		if err != nil && strings.Contains(err.Error(), tooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+
+		problem.Write(w, r, problem.New(status, "expected a JSON body with a query"))
+
+		return
+	}
+
+	c := gofr.NewContext(nil, request.NewHTTPRequest(r), g.app)
+	c.Context = r.Context()
+	c.Logger = middleware.NewRequestLogger(g.app.Logger, requestctx.RequestID(r.Context()))
+
+	resp := g.schema.Exec(c, req.Query, req.OperationName, req.Variables)
+	if graph.Status(resp) >= http.StatusInternalServerError {
+		c.Logger.Errorf("graphql %s failed: %v", req.OperationName, resp.Errors)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"github.com/graph-gophers/graphql-go"
+
+	"example/middleware"
+)
+
+type fakeExecutor struct {
+	query, operation string
+	variables        map[string]interface{}
+}
+
+func (f *fakeExecutor) Exec(_ *gofr.Context, query, operation string,
+	variables map[string]interface{}) *graphql.Response {
+	f.query, f.operation, f.variables = query, operation, variables
+
+	return &graphql.Response{Data: json.RawMessage(`{"employee":{"name":"Ram"}}`)}
+}
+
+func TestGraphQL(t *testing.T) {
+	testcases := []struct {
+		desc   string
+		body   string
+		limit  int64
+		status int
+		resp   string
+		query  string
+	}{
+		{"query", `{"query":"{ employee(id: 1) { name } }","operationName":"q","variables":{"id":1}}`, 1024,
+			http.StatusOK, `{"data":{"employee":{"name":"Ram"}}}`, "{ employee(id: 1) { name } }"},
+		{"no query", `{"variables":{}}`, 1024, http.StatusBadRequest, `"status":400`, ""},
+		{"malformed", `{"query":`, 1024, http.StatusBadRequest, `"status":400`, ""},
+		{"too large", `{"query":"{ employees { id name email title status } }"}`, 10, http.StatusRequestEntityTooLarge,
+			`"status":413`, ""},
+	}
+
+	for i, tc := range testcases {
+		f := &fakeExecutor{}
+		h := middleware.MaxBodySize(tc.limit)(NewGraphQL(f, gofr.New()))
+		w := httptest.NewRecorder()
+
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(tc.body)))
+
+		if w.Code != tc.status || !strings.Contains(w.Body.String(), tc.resp) || f.query != tc.query {
+			t.Errorf("[Test %v]Failed. Expected %v %v for %q but got %v %v for %q", i+1, tc.status, tc.resp, tc.query,
+				w.Code, w.Body.String(), f.query)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"developer.zopsmart.com/go/gofr/pkg/gofr/request"
+
+	"example/model"
+	"example/service"
+)
+
+type health struct {
+	service service.HealthService
+	app     *gofr.Gofr
+}
+
+// NewHealth returns the liveness and readiness probes. They are plain http handlers, meant to be mounted with
+// middleware.Mount, so that they answer the orchestrator without an api key.
+// nolint:revive // handlers should not be used without proper initialization with required dependency
+func NewHealth(s service.HealthService, app *gofr.Gofr) health {
+	return health{service: s, app: app}
+}
+
+// Live answers 200 for as long as the process serves requests.
+func (h health) Live(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, h.service.Live(h.context(r)))
+}
+
+// Ready answers 200 when every dependency is up, and 503 with the failing dependencies otherwise or while draining.
+func (h health) Ready(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, h.service.Ready(h.context(r)))
+}
+
+func (h health) context(r *http.Request) *gofr.Context {
+	c := gofr.NewContext(nil, request.NewHTTPRequest(r), h.app)
+	c.Context = r.Context()
+
+	return c
+}
+
+func writeHealth(w http.ResponseWriter, resp model.Health) {
+	status := http.StatusOK
+	if resp.Status != model.HealthUp {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(resp)
+}
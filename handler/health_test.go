@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/model"
+	"example/service/mocks"
+)
+
+func TestHealth_Ready(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockHealthService(ctrl)
+	h := NewHealth(m, gofr.New())
+
+	testcases := []struct {
+		desc   string
+		health model.Health
+		status int
+		body   string
+	}{
+		{"up", model.Health{Status: model.HealthUp, Dependencies: map[string]model.DependencyHealth{
+			"database": {Status: model.HealthUp, LatencyMS: 1.5}}}, http.StatusOK,
+			`{"status":"up","dependencies":{"database":{"status":"up","latency_ms":1.5}}}`},
+		{"down", model.Health{Status: model.HealthDown, Dependencies: map[string]model.DependencyHealth{
+			"database": {Status: model.HealthDown, LatencyMS: 2000, Error: "timed out after 2s"}}},
+			http.StatusServiceUnavailable,
+			`{"status":"down","dependencies":{"database":{"status":"down","latency_ms":2000,"error":"timed out after 2s"}}}`},
+		{"draining", model.Health{Status: model.HealthDraining}, http.StatusServiceUnavailable, `{"status":"draining"}`},
+	}
+
+	for i, tc := range testcases {
+		m.EXPECT().Ready(gomock.Any()).Return(tc.health)
+
+		w := httptest.NewRecorder()
+		h.Ready(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+		if w.Code != tc.status || strings.TrimSpace(w.Body.String()) != tc.body {
+			t.Errorf("[Test %v]Failed.Expected %v %s but Got %v %s", i+1, tc.status, tc.body, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestHealth_Live(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockHealthService(ctrl)
+	h := NewHealth(m, gofr.New())
+
+	m.EXPECT().Live(gomock.Any()).Return(model.Health{Status: model.HealthUp})
+
+	w := httptest.NewRecorder()
+	h.Live(w, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+
+	if w.Code != http.StatusOK || strings.TrimSpace(w.Body.String()) != `{"status":"up"}` {
+		t.Errorf("[Test 1]Failed.Expected 200 but Got %v %s", w.Code, w.Body.String())
+	}
+}
@@ -1,8 +1,13 @@
 package handler
 
 import (
+	"encoding/hex"
+	"encoding/json"
+	"hash/fnv"
 	"strconv"
+	"time"
 
+	"example/middleware"
 	"example/model"
 	"example/service"
 
@@ -11,87 +16,207 @@ import (
 )
 
 type handler struct {
-	service service.EmpService
+	service     service.EmpService
+	idempotency service.IdempotencyService
+	version     version
 }
 
+// New returns the employee handlers of version 1 of the API, which represents employees as model.Employee.
 // nolint:revive // handlers should not be used without proper initialization with required dependency
-func New(h service.EmpService) handler {
-	return handler{service: h}
+func New(h service.EmpService, i service.IdempotencyService) handler {
+	return handler{service: h, idempotency: i, version: v1}
 }
 
 func (h handler) Get(c *gofr.Context) (interface{}, error) {
-	resp, err := h.service.GetEmp(c)
-
-	if err != nil {
-		return nil, errors.Error("Connect Failed")
+	filter := model.Filter{
+		Title:  c.Param("title"),
+		Status: model.Status(c.Param("status")),
 	}
 
-	return resp, nil
-}
+	var err error
 
-func (h handler) GetByID(c *gofr.Context) (interface{}, error) {
-	i := c.PathParam("id")
+	if filter.DepartmentID, err = queryID(c, "department_id"); err != nil {
+		return nil, err
+	}
 
-	if i == "" {
-		return nil, errors.InvalidParam{Param: []string{"id"}}
+	if filter.ManagerID, err = queryID(c, "manager_id"); err != nil {
+		return nil, err
 	}
 
-	id, err := strconv.Atoi(i)
+	if filter.AfterID, err = queryID(c, "after_id"); err != nil {
+		return nil, err
+	}
 
+	limit, err := queryID(c, "limit")
 	if err != nil {
-		return nil, errors.Error("Failed to Convert id")
+		return nil, err
 	}
 
-	resp, err := h.service.GetEmpByID(c, id)
+	if limit != nil {
+		filter.Limit = *limit
+	}
+
+	resp, err := h.service.GetEmp(c, filter)
 
 	if err != nil {
-		return model.Employee{}, errors.Error("Connect Failed")
+		return nil, err
 	}
 
-	return resp, nil
+	body := h.version.renderAll(resp)
+	setListValidators(c, body)
+
+	return body, nil
 }
 
-func (h handler) Update(c *gofr.Context) (interface{}, error) {
-	var emp model.Employee
+func (h handler) GetByID(c *gofr.Context) (interface{}, error) {
+	id, err := pathID(c)
+	if err != nil {
+		return nil, err
+	}
 
-	i := c.PathParam("id")
+	resp, err := h.service.GetEmpByID(c, id)
 
-	if i == "" {
-		return nil, errors.InvalidParam{Param: []string{"id"}}
+	if err != nil {
+		return model.Employee{}, err
 	}
 
-	id, err := strconv.Atoi(i)
+	body := h.version.render(resp)
+	setValidators(c, body, lastModified(resp, time.Now()))
 
+	return body, nil
+}
+
+func (h handler) Update(c *gofr.Context) (interface{}, error) {
+	id, err := pathID(c)
 	if err != nil {
-		return nil, errors.Error("Failed to Convert to id")
+		return nil, err
 	}
 
-	if err = c.Bind(&emp); err != nil {
-		return nil, errors.InvalidParam{Param: []string{"body"}}
+	emp, err := h.version.bind(c)
+	if err != nil {
+		return nil, err
 	}
 
 	emp.ID = id
 
 	resp, err := h.service.UpdateEmp(c, emp)
 	if err != nil {
-		return nil, errors.Error("Connect Failed")
+		return nil, h.version.error(err)
 	}
 
-	return resp, nil
+	return h.version.render(resp), nil
 }
 
+// Create runs at most once per Idempotency-Key, see idempotent.
 func (h handler) Create(c *gofr.Context) (interface{}, error) {
-	var emp model.Employee
+	return idempotent(c, h.idempotency, h.create)
+}
 
-	if err := c.Bind(&emp); err != nil {
-		return nil, errors.InvalidParam{Param: []string{"body"}}
+func (h handler) create(c *gofr.Context) (interface{}, error) {
+	emp, err := h.version.bind(c)
+	if err != nil {
+		return nil, err
 	}
 
 	resp, err := h.service.CreateEmp(c, emp)
 
 	if err != nil {
-		return nil, errors.Error("Connect Failed")
+		return nil, h.version.error(err)
+	}
+
+	return h.version.render(resp), nil
+}
+
+// Reports returns the direct reports of the employee.
+func (h handler) Reports(c *gofr.Context) (interface{}, error) {
+	return h.hierarchy(c, h.service.GetReports)
+}
+
+// Subtree returns everyone reporting to the employee, directly or indirectly.
+func (h handler) Subtree(c *gofr.Context) (interface{}, error) {
+	return h.hierarchy(c, h.service.GetSubtree)
+}
+
+// Chain returns the management chain of the employee up to the root.
+func (h handler) Chain(c *gofr.Context) (interface{}, error) {
+	return h.hierarchy(c, h.service.GetChain)
+}
+
+func (h handler) hierarchy(c *gofr.Context, get func(*gofr.Context, int) ([]model.Employee, error)) (interface{}, error) {
+	id, err := pathID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := get(c, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body := h.version.renderAll(resp)
+	setListValidators(c, body)
+
+	return body, nil
+}
+
+// queryID parses the optional id query parameter called name.
+func queryID(c *gofr.Context, name string) (*int, error) {
+	v := c.Param(name)
+	if v == "" {
+		return nil, nil
+	}
+
+	id, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, errors.InvalidParam{Param: []string{name}}
+	}
+
+	return &id, nil
+}
+
+// setValidators sets the ETag and Last-Modified of a response holding one employee, rendered as body.
+func setValidators(c *gofr.Context, body interface{}, modified time.Time) {
+	middleware.SetValidators(c.Request(), etag(body), modified)
+}
+
+// setListValidators sets the ETag of a response holding a list of employees, rendered as body. Lists have no
+// Last-Modified: the latest updated_at of the employees listed does not change when one of them leaves the list, so
+// it could tell a client holding the list before that it is still current.
+func setListValidators(c *gofr.Context, body interface{}) {
+	middleware.SetValidators(c.Request(), etag(body), time.Time{})
+}
+
+// etag hashes the serialized body, so that it changes with the fields derived when the employee is read, such as
+// age, and not only when the employee is updated.
+func etag(body interface{}) string {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return ""
+	}
+
+	h := fnv.New64a()
+	h.Write(b)
+
+	return `W/"` + hex.EncodeToString(h.Sum(nil)) + `"`
+}
+
+// lastModified returns when the representation of emp last changed at now: when it was updated, or on the last
+// birthday since, which changed the derived age.
+func lastModified(emp model.Employee, now time.Time) time.Time {
+	born := emp.DateOfBirth
+	if born.IsZero() {
+		return emp.UpdatedAt
+	}
+
+	birthday := time.Date(now.Year(), born.Month(), born.Day(), 0, 0, 0, 0, time.UTC)
+	if birthday.After(now) {
+		birthday = birthday.AddDate(-1, 0, 0)
+	}
+
+	if birthday.After(emp.UpdatedAt) {
+		return birthday
 	}
 
-	return resp, nil
+	return emp.UpdatedAt
 }
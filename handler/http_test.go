@@ -2,10 +2,13 @@ package handler
 
 import (
 	"bytes"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/bmizerany/assert"
 	"github.com/golang/mock/gomock"
@@ -15,6 +18,8 @@ import (
 	"developer.zopsmart.com/go/gofr/pkg/gofr/request"
 	"developer.zopsmart.com/go/gofr/pkg/gofr/responder"
 
+	"example/domain"
+	"example/middleware"
 	"example/model"
 	"example/service/mocks"
 )
@@ -22,26 +27,43 @@ import (
 func TestHandler_Get(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	m := mocks.NewMockEmpService(ctrl)
-	h := handler{service: m}
-	_ = New(m)
+	h := New(m, nil)
+	_ = New(m, mocks.NewMockIdempotencyService(ctrl))
 	app := gofr.New()
 
+	one := 1
+
 	testcases := []struct {
 		desc   string
+		query  string
 		output interface{}
 		err    error
 		mock   []*gomock.Call
 	}{
-		{"success", []model.Employee{{ID: 1, Age: 21, Name: "Ram"}}, nil, []*gomock.Call{
-			m.EXPECT().GetEmp(gomock.Any()).Return([]model.Employee{{ID: 1, Age: 21, Name: "Ram"}}, nil),
+		{"success", "", []model.Employee{{ID: 1, Age: 21, Name: "Ram"}}, nil, []*gomock.Call{
+			m.EXPECT().GetEmp(gomock.Any(), model.Filter{}).Return([]model.Employee{{ID: 1, Age: 21, Name: "Ram"}}, nil),
+		}},
+		{"Failure", "", nil, domain.Unavailable{Err: errors.DB{}}, []*gomock.Call{
+			m.EXPECT().GetEmp(gomock.Any(), model.Filter{}).Return(nil, domain.Unavailable{Err: errors.DB{}}),
+		}},
+		{"filtered", "?department_id=1&title=Engineer&status=active&manager_id=1",
+			[]model.Employee{{ID: 2, Name: "Sai"}}, nil, []*gomock.Call{
+				m.EXPECT().GetEmp(gomock.Any(), model.Filter{DepartmentID: &one, Title: "Engineer", Status: model.StatusActive,
+					ManagerID: &one}).Return([]model.Employee{{ID: 2, Name: "Sai"}}, nil),
+			}},
+		{"paged", "?after_id=1&limit=2", []model.Employee{{ID: 2, Name: "Sai"}}, nil, []*gomock.Call{
+			m.EXPECT().GetEmp(gomock.Any(), model.Filter{AfterID: &one, Limit: 2}).Return([]model.Employee{{ID: 2, Name: "Sai"}}, nil),
 		}},
-		{"Failure", nil, errors.Error("Connect Failed"), []*gomock.Call{
-			m.EXPECT().GetEmp(gomock.Any()).Return(nil, errors.Error("Connect Failed")),
+		{"invalid limit", "?limit=ten", nil, errors.InvalidParam{Param: []string{"limit"}}, nil},
+		{"invalid department", "?department_id=hr", nil, errors.InvalidParam{Param: []string{"department_id"}}, nil},
+		{"invalid manager", "?manager_id=one", nil, errors.InvalidParam{Param: []string{"manager_id"}}, nil},
+		{"invalid status", "?status=retired", nil, errors.InvalidParam{Param: []string{"status"}}, []*gomock.Call{
+			m.EXPECT().GetEmp(gomock.Any(), model.Filter{Status: "retired"}).Return(nil, errors.InvalidParam{Param: []string{"status"}}),
 		}},
 	}
 
 	for _, tc := range testcases {
-		r := httptest.NewRequest(http.MethodGet, "/emp", nil)
+		r := httptest.NewRequest(http.MethodGet, "/emp"+tc.query, nil)
 		w := httptest.NewRecorder()
 		req := request.NewHTTPRequest(r)
 		res := responder.NewContextualResponder(w, r)
@@ -59,7 +81,7 @@ func TestHandler_Get(t *testing.T) {
 func TestHandler_GetByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	m := mocks.NewMockEmpService(ctrl)
-	h := handler{service: m}
+	h := New(m, nil)
 	app := gofr.New()
 
 	testcases := []struct {
@@ -69,14 +91,17 @@ func TestHandler_GetByID(t *testing.T) {
 		err    error
 		mock   []*gomock.Call
 	}{
-		{"Failure", "31", model.Employee{}, errors.Error("Connect Failed"), []*gomock.Call{
-			m.EXPECT().GetEmpByID(gomock.Any(), gomock.Any()).Return(model.Employee{}, errors.Error("Connect Failed")),
+		{"Failure", "31", model.Employee{}, domain.Unavailable{Err: errors.DB{}}, []*gomock.Call{
+			m.EXPECT().GetEmpByID(gomock.Any(), gomock.Any()).Return(model.Employee{}, domain.Unavailable{Err: errors.DB{}}),
 		}},
 		{"Success", "2", model.Employee{ID: 2, Age: 22, Name: "Ram"}, nil, []*gomock.Call{
 			m.EXPECT().GetEmpByID(gomock.Any(), gomock.Any()).Return(model.Employee{ID: 2, Age: 22, Name: "Ram"}, nil),
 		}},
 		{"ID_Empty", "", nil, errors.InvalidParam{Param: []string{"id"}}, nil},
-		{"ID_Invalid", "jeh", nil, errors.Error("Failed to Convert id"), nil},
+		{"ID_Invalid", "jeh", nil, errors.InvalidParam{Param: []string{"id"}}, nil},
+		{"Not found", "7", model.Employee{}, errors.EntityNotFound{Entity: "employee", ID: "7"}, []*gomock.Call{
+			m.EXPECT().GetEmpByID(gomock.Any(), 7).Return(model.Employee{}, errors.EntityNotFound{Entity: "employee", ID: "7"}),
+		}},
 	}
 
 	for i, tc := range testcases {
@@ -104,7 +129,7 @@ func TestHandler_GetByID(t *testing.T) {
 func TestHandler_Update(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	m := mocks.NewMockEmpService(ctrl)
-	h := handler{service: m}
+	h := New(m, nil)
 	app := gofr.New()
 
 	testcases := []struct {
@@ -118,11 +143,11 @@ func TestHandler_Update(t *testing.T) {
 		{"ID EMPTY", "", []byte(`("id":1,"age":21,"name":"Ram")`), nil,
 			errors.InvalidParam{Param: []string{"id"}}, nil},
 		{"ID INVALID", "sd", []byte(`("id":2,"age":22,"name":"sai")`), nil,
-			errors.Error("Failed to Convert to id"), nil},
+			errors.InvalidParam{Param: []string{"id"}}, nil},
 		{"", "2", []byte(``), nil, errors.InvalidParam{Param: []string{"body"}}, nil},
 		{desc: "Failure", id: "3", req: []byte(`{"id":3,"age":23,"name":"gopal"}`), output: nil,
-			err: errors.Error("Connect Failed"), mock: []*gomock.Call{m.EXPECT().UpdateEmp(gomock.Any(), gomock.Any()).
-				Return(model.Employee{}, errors.Error("Connect Failed"))}},
+			err: domain.Unavailable{Err: errors.DB{}}, mock: []*gomock.Call{m.EXPECT().UpdateEmp(gomock.Any(), gomock.Any()).
+				Return(model.Employee{}, domain.Unavailable{Err: errors.DB{}})}},
 		{desc: "Success", id: "4", req: []byte(`{"id":4,"age":24,"name":"harish"}`),
 			output: model.Employee{ID: 4, Age: 24, Name: "harish"}, mock: []*gomock.Call{
 				m.EXPECT().UpdateEmp(gomock.Any(), gomock.Any()).Return(model.Employee{ID: 4, Age: 24, Name: "harish"}, nil)}},
@@ -155,7 +180,7 @@ func TestHandler_Update(t *testing.T) {
 func TestHandler_Create(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	m := mocks.NewMockEmpService(ctrl)
-	h := handler{service: m}
+	h := New(m, nil)
 	app := gofr.New()
 
 	testcases := []struct {
@@ -166,13 +191,22 @@ func TestHandler_Create(t *testing.T) {
 		mock   []*gomock.Call
 	}{
 		{"Unmarshal error", []byte(``), nil, errors.InvalidParam{Param: []string{"body"}}, nil},
-		{"Failure", []byte(`{"id":1,"age":20,"name":"sai"}`), nil, errors.Error("Connect Failed"),
+		{"Malformed date", []byte(`{"name":"sai","hire_date":"01/06/2021"}`), nil,
+			errors.InvalidParam{Param: []string{"hire_date"}}, nil},
+		{"Failure", []byte(`{"id":1,"age":20,"name":"sai"}`), nil, domain.Unavailable{Err: errors.DB{}},
 			[]*gomock.Call{m.EXPECT().CreateEmp(gomock.Any(), gomock.Any()).Return(model.Employee{},
-				errors.Error("Connect Failed")),
+				domain.Unavailable{Err: errors.DB{}}),
 			}},
 		{desc: "Success", req: []byte(`{"id":2,"age":21,"name":"ram"}`), output: model.Employee{ID: 2, Age: 21, Name: "ram"},
 			mock: []*gomock.Call{m.EXPECT().CreateEmp(gomock.Any(), gomock.Any()).
 				Return(model.Employee{ID: 2, Age: 21, Name: "ram"}, nil)}},
+		{desc: "Invalid", req: []byte(`{"id":3,"name":"kiran","email":"kiran"}`), err: errors.InvalidParam{Param: []string{"email"}},
+			mock: []*gomock.Call{m.EXPECT().CreateEmp(gomock.Any(), gomock.Any()).
+				Return(model.Employee{}, errors.InvalidParam{Param: []string{"email"}})}},
+		{desc: "Conflict", req: []byte(`{"id":4,"name":"gopal","email":"ram@example.com"}`),
+			err: domain.Conflict{Entity: "employee", Field: "email"},
+			mock: []*gomock.Call{m.EXPECT().CreateEmp(gomock.Any(), gomock.Any()).
+				Return(model.Employee{}, domain.Conflict{Entity: "employee", Field: "email"})}},
 	}
 
 	for i, tc := range testcases {
@@ -195,3 +229,204 @@ func TestHandler_Create(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_Hierarchy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockEmpService(ctrl)
+	h := New(m, nil)
+	app := gofr.New()
+	notFound := errors.EntityNotFound{Entity: "employee", ID: "9"}
+
+	testcases := []struct {
+		desc   string
+		get    func(*gofr.Context) (interface{}, error)
+		id     string
+		output interface{}
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"reports", h.Reports, "1", []model.Employee{{ID: 2}}, nil, []*gomock.Call{
+			m.EXPECT().GetReports(gomock.Any(), 1).Return([]model.Employee{{ID: 2}}, nil),
+		}},
+		{"subtree", h.Subtree, "1", []model.Employee{{ID: 2}, {ID: 3}}, nil, []*gomock.Call{
+			m.EXPECT().GetSubtree(gomock.Any(), 1).Return([]model.Employee{{ID: 2}, {ID: 3}}, nil),
+		}},
+		{"chain", h.Chain, "3", []model.Employee{{ID: 2}, {ID: 1}}, nil, []*gomock.Call{
+			m.EXPECT().GetChain(gomock.Any(), 3).Return([]model.Employee{{ID: 2}, {ID: 1}}, nil),
+		}},
+		{"not found", h.Chain, "9", nil, notFound, []*gomock.Call{
+			m.EXPECT().GetChain(gomock.Any(), 9).Return(nil, notFound),
+		}},
+		{"failure", h.Subtree, "4", nil, domain.Unavailable{Err: errors.DB{}}, []*gomock.Call{
+			m.EXPECT().GetSubtree(gomock.Any(), 4).Return(nil, domain.Unavailable{Err: errors.DB{}}),
+		}},
+		{"invalid id", h.Reports, "one", nil, errors.InvalidParam{Param: []string{"id"}}, nil},
+	}
+
+	for i, tc := range testcases {
+		r := httptest.NewRequest(http.MethodGet, "/emp/{id}/chain", nil)
+		w := httptest.NewRecorder()
+		req := request.NewHTTPRequest(r)
+		res := responder.NewContextualResponder(w, r)
+		ctx := gofr.NewContext(res, req, app)
+		ctx.SetPathParams(map[string]string{"id": tc.id})
+
+		resp, err := tc.get(ctx)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestHandler_CreateIdempotent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockEmpService(ctrl)
+	i := mocks.NewMockIdempotencyService(ctrl)
+	h := New(m, i)
+	app := gofr.New()
+	replay := json.RawMessage(`{"id":2,"name":"ram"}`)
+	reused := &errors.Response{StatusCode: http.StatusUnprocessableEntity, Code: "Unprocessable Entity",
+		Reason: "Idempotency-Key a was already used for a different request"}
+
+	testcases := []struct {
+		desc   string
+		key    string
+		limit  int64
+		output interface{}
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"first request", "a", 0, model.Employee{ID: 2, Name: "ram"}, nil, []*gomock.Call{
+			i.EXPECT().Do(gomock.Any(), "a", gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ *gofr.Context, _, _ string, fn func() (interface{}, error)) (interface{}, error) { return fn() }),
+			m.EXPECT().CreateEmp(gomock.Any(), model.Employee{ID: 2, Name: "ram"}).Return(model.Employee{ID: 2, Name: "ram"}, nil),
+		}},
+		{"retry", "a", 0, replay, nil, []*gomock.Call{
+			i.EXPECT().Do(gomock.Any(), "a", gomock.Any(), gomock.Any()).Return(replay, nil),
+		}},
+		{"reused key", "a", 0, nil, reused, []*gomock.Call{
+			i.EXPECT().Do(gomock.Any(), "a", gomock.Any(), gomock.Any()).Return(nil, reused),
+		}},
+		{"key too long", strings.Repeat("k", 256), 0, nil, errors.InvalidParam{Param: []string{"Idempotency-Key"}}, nil},
+		{"too large", "b", 8, nil, &errors.Response{StatusCode: http.StatusRequestEntityTooLarge,
+			Code: "Request Entity Too Large", Reason: "request body is too large"}, nil},
+	}
+
+	for j, tc := range testcases {
+		r := httptest.NewRequest(http.MethodPost, "/emp", bytes.NewReader([]byte(`{"id":2,"name":"ram"}`)))
+		r.Header.Set("Idempotency-Key", tc.key)
+		w := httptest.NewRecorder()
+
+		if tc.limit > 0 {
+			r.Body = http.MaxBytesReader(w, r.Body, tc.limit)
+		}
+
+		req := request.NewHTTPRequest(r)
+		res := responder.NewContextualResponder(w, r)
+		ctx := gofr.NewContext(res, req, app)
+
+		resp, err := h.Create(ctx)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", j+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", j+1, tc.err, err)
+		}
+	}
+}
+
+func TestHandler_Validators(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockEmpService(ctrl)
+	h := New(m, nil)
+	app := gofr.New()
+
+	updated := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	emp := model.Employee{ID: 1, Name: "Ram", UpdatedAt: updated}
+	lastModified := updated.Format(http.TimeFormat)
+
+	testcases := []struct {
+		desc         string
+		target       string
+		handler      gofr.Handler
+		lastModified string
+		mock         []*gomock.Call
+	}{
+		{"employee", "/emp/1", h.GetByID, lastModified, []*gomock.Call{
+			m.EXPECT().GetEmpByID(gomock.Any(), 1).Return(emp, nil),
+		}},
+		{"list", "/emp?limit=1", h.Get, "", []*gomock.Call{
+			m.EXPECT().GetEmp(gomock.Any(), model.Filter{Limit: 1}).Return([]model.Employee{emp}, nil),
+		}},
+		{"hierarchy", "/emp/1/subtree", h.Subtree, "", []*gomock.Call{
+			m.EXPECT().GetSubtree(gomock.Any(), 1).Return([]model.Employee{emp}, nil),
+		}},
+	}
+
+	for i, tc := range testcases {
+		w := httptest.NewRecorder()
+
+		middleware.ConditionalGET(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := gofr.NewContext(responder.NewContextualResponder(w, r), request.NewHTTPRequest(r), app)
+			ctx.SetPathParams(map[string]string{"id": "1"})
+
+			if _, err := tc.handler(ctx); err != nil {
+				t.Errorf("[Test %v]Failed.Expected no error but Got %v", i+1, err)
+			}
+
+			w.WriteHeader(http.StatusOK)
+		})).ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.target, nil))
+
+		if w.Header().Get("ETag") == "" || w.Header().Get("Last-Modified") != tc.lastModified {
+			t.Errorf("[Test %v]Failed.Expected an ETag and Last-Modified %q but Got %v", i+1, tc.lastModified, w.Header())
+		}
+	}
+}
+
+func TestETag(t *testing.T) {
+	updated := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	emp := model.Employee{ID: 1, Name: "Ram", Age: 30, UpdatedAt: updated}
+	older := emp
+	older.Age = 31
+
+	if etag(emp) == etag(older) {
+		t.Errorf("[Test 1]Failed. Expected the ETag to change with the derived age but got %v", etag(emp))
+	}
+
+	if etag(emp) != etag(emp) || etag(emp) == etag(model.NewEmployeeV2(emp)) {
+		t.Errorf("[Test 2]Failed. Expected the ETag to follow the representation but got %v", etag(emp))
+	}
+}
+
+func TestLastModified(t *testing.T) {
+	updated := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+	testcases := []struct {
+		desc   string
+		born   model.Date
+		now    time.Time
+		output time.Time
+	}{
+		{"no date of birth", model.Date{}, updated.AddDate(1, 0, 0), updated},
+		{"birthday before the update", model.NewDate(1990, time.February, 1), updated.AddDate(0, 6, 0), updated},
+		{"birthday since the update", model.NewDate(1990, time.June, 1), updated.AddDate(0, 6, 0),
+			time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{"birthday later this year", model.NewDate(1990, time.December, 1), time.Date(2023, time.May, 1, 0, 0, 0, 0,
+			time.UTC), time.Date(2022, time.December, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for i, tc := range testcases {
+		resp := lastModified(model.Employee{ID: 1, DateOfBirth: tc.born, UpdatedAt: updated}, tc.now)
+
+		if !resp.Equal(tc.output) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/service"
+)
+
+const (
+	idempotencyHeader = "Idempotency-Key"
+	maxIdempotencyKey = 255
+)
+
+// idempotent runs create directly when the request has no Idempotency-Key header. Otherwise it runs create through
+// s, which answers retries of the same request with the original response. Requests are told apart by a hash of
+// their method, path and body.
+func idempotent(c *gofr.Context, s service.IdempotencyService, create func(*gofr.Context) (interface{}, error)) (interface{}, error) {
+	r := c.Request()
+	key := r.Header.Get(idempotencyHeader)
+
+	if key == "" || s == nil {
+		return create(c)
+	}
+
+	if len(key) > maxIdempotencyKey {
+		return nil, errors.InvalidParam{Param: []string{idempotencyHeader}}
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, bindError(err, body, nil)
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	h.Write(body)
+
+	resp, err := s.Do(c, key, hex.EncodeToString(h.Sum(nil)), func() (interface{}, error) {
+		return create(c)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"net/http"
+
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/middleware"
+	"example/problem"
+	"example/requestctx"
+)
+
+// Logging tags every line logged while fn, and the service and store calls it makes, handle a request with the
+// request id set by middleware.RequestID, and logs the errors fn fails with that are not the client's fault.
+func Logging(fn gofr.Handler) gofr.Handler {
+	return func(c *gofr.Context) (interface{}, error) {
+		c.Logger = middleware.NewRequestLogger(c.Logger, requestctx.RequestID(c.Request().Context()))
+
+		resp, err := fn(c)
+		if err != nil && problem.From(err).Status >= http.StatusInternalServerError {
+			c.Logger.Errorf("%s %s failed: %v", c.Request().Method, c.Request().URL.Path, err)
+		}
+
+		return resp, err
+	}
+}
@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/middleware"
+)
+
+// Problems records the error fn fails with, so that middleware.Problems answers it with RFC 7807 problem details
+// instead of the default gofr error body.
+func Problems(fn gofr.Handler) gofr.Handler {
+	return func(c *gofr.Context) (interface{}, error) {
+		resp, err := fn(c)
+		if err != nil {
+			middleware.SetError(c.Request(), err)
+		}
+
+		return resp, err
+	}
+}
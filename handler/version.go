@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/domain"
+	"example/model"
+	"example/service"
+)
+
+// version adapts the employee handlers to the representation of employees in one version of the API, so that every
+// version is served by the same service.EmpService.
+type version struct {
+	bind      func(c *gofr.Context) (model.Employee, error)
+	render    func(model.Employee) interface{}
+	renderAll func([]model.Employee) interface{}
+	// fields renames the fields the service rejects to their name in the representation.
+	fields map[string]string
+}
+
+var v1 = version{
+	bind: func(c *gofr.Context) (model.Employee, error) {
+		var e model.Employee
+
+		err := bind(c, &e)
+
+		return e, err
+	},
+	render:    func(e model.Employee) interface{} { return e },
+	renderAll: func(e []model.Employee) interface{} { return e },
+}
+
+var v2 = version{
+	bind: func(c *gofr.Context) (model.Employee, error) {
+		var e model.EmployeeV2
+
+		err := bind(c, &e)
+
+		return e.Employee(), err
+	},
+	render: func(e model.Employee) interface{} { return model.NewEmployeeV2(e) },
+	renderAll: func(e []model.Employee) interface{} {
+		resp := make([]model.EmployeeV2, len(e))
+		for i := range e {
+			resp[i] = model.NewEmployeeV2(e[i])
+		}
+
+		return resp
+	},
+	fields: map[string]string{"department_id": "department", "manager_id": "manager"},
+}
+
+// NewV2 returns the employee handlers of version 2 of the API, which represents employees as model.EmployeeV2.
+// nolint:revive // handlers should not be used without proper initialization with required dependency
+func NewV2(h service.EmpService, i service.IdempotencyService) handler {
+	return handler{service: h, idempotency: i, version: v2}
+}
+
+// error names the fields rejected by err as the representation of the version does.
+func (v version) error(err error) error {
+	switch e := err.(type) {
+	case errors.InvalidParam:
+		params := make([]string, len(e.Param))
+
+		for i, p := range e.Param {
+			params[i] = v.field(p)
+		}
+
+		return errors.InvalidParam{Param: params}
+	case domain.Conflict:
+		e.Field = v.field(e.Field)
+
+		return e
+	default:
+		return err
+	}
+}
+
+// field returns the name of the service field f in the representation of the version.
+func (v version) field(f string) string {
+	if name, ok := v.fields[f]; ok {
+		return name
+	}
+
+	return f
+}
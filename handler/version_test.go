@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"developer.zopsmart.com/go/gofr/pkg/gofr/request"
+	"developer.zopsmart.com/go/gofr/pkg/gofr/responder"
+
+	"example/domain"
+	"example/model"
+	"example/service/mocks"
+)
+
+func TestHandlerV2(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockEmpService(ctrl)
+	h := NewV2(m, nil)
+	app := gofr.New()
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	dept, manager := 4, 1
+	stored := model.Employee{ID: 2, Name: "Ram", DepartmentID: &dept, ManagerID: &manager, Status: model.StatusActive,
+		CreatedAt: now, CreatedBy: "sai", UpdatedAt: now, UpdatedBy: "sai"}
+	rendered := model.EmployeeV2{ID: 2, Name: "Ram", Department: &model.Ref{ID: 4}, Manager: &model.Ref{ID: 1},
+		Status: model.StatusActive, Audit: model.Audit{CreatedAt: now, CreatedBy: "sai", UpdatedAt: now, UpdatedBy: "sai"}}
+
+	testcases := []struct {
+		desc   string
+		call   func(*gofr.Context) (interface{}, error)
+		body   string
+		output interface{}
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"get", h.GetByID, "", rendered, nil, []*gomock.Call{
+			m.EXPECT().GetEmpByID(gomock.Any(), 2).Return(stored, nil),
+		}},
+		{"list", h.Get, "", []model.EmployeeV2{rendered}, nil, []*gomock.Call{
+			m.EXPECT().GetEmp(gomock.Any(), model.Filter{}).Return([]model.Employee{stored}, nil),
+		}},
+		{"empty list", h.Chain, "", []model.EmployeeV2{}, nil, []*gomock.Call{
+			m.EXPECT().GetChain(gomock.Any(), 2).Return(nil, nil),
+		}},
+		{"create", h.Create, `{"name":"Ram","department":{"id":4},"manager":{"id":1}}`, rendered, nil, []*gomock.Call{
+			m.EXPECT().CreateEmp(gomock.Any(), model.Employee{Name: "Ram", DepartmentID: &dept, ManagerID: &manager}).
+				Return(stored, nil),
+		}},
+		{"update renames invalid fields", h.Update, `{"name":"Ram","department":{"id":9}}`, nil,
+			errors.InvalidParam{Param: []string{"email", "department"}}, []*gomock.Call{
+				m.EXPECT().UpdateEmp(gomock.Any(), gomock.Any()).
+					Return(model.Employee{}, errors.InvalidParam{Param: []string{"email", "department_id"}}),
+			}},
+		{"update renames conflicting field", h.Update, `{"name":"Ram","manager":{"id":9}}`, nil,
+			domain.Conflict{Entity: "employee", Field: "manager", Reason: "too deep"}, []*gomock.Call{
+				m.EXPECT().UpdateEmp(gomock.Any(), gomock.Any()).
+					Return(model.Employee{}, domain.Conflict{Entity: "employee", Field: "manager_id", Reason: "too deep"}),
+			}},
+		{"version 1 field", h.Create, `{"name":"Ram","department_id":4}`, nil,
+			errors.InvalidParam{Param: []string{"department_id"}}, nil},
+	}
+
+	for i, tc := range testcases {
+		r := httptest.NewRequest(http.MethodPost, "/v2/emp", bytes.NewReader([]byte(tc.body)))
+		w := httptest.NewRecorder()
+		ctx := gofr.NewContext(responder.NewContextualResponder(w, r), request.NewHTTPRequest(r), app)
+		ctx.SetPathParams(map[string]string{"id": "2"})
+
+		resp, err := tc.call(ctx)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"strconv"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/model"
+	"example/service"
+)
+
+type webhook struct {
+	service service.WebhookService
+}
+
+// nolint:revive // handlers should not be used without proper initialization with required dependency
+func NewWebhook(s service.WebhookService) webhook {
+	return webhook{service: s}
+}
+
+func (h webhook) Get(c *gofr.Context) (interface{}, error) {
+	resp, err := h.service.GetWebhooks(c)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (h webhook) GetByID(c *gofr.Context) (interface{}, error) {
+	id, err := pathID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.service.GetWebhookByID(c, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (h webhook) Create(c *gofr.Context) (interface{}, error) {
+	var w model.Webhook
+
+	if err := bind(c, &w); err != nil {
+		return nil, err
+	}
+
+	resp, err := h.service.CreateWebhook(c, w)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (h webhook) Update(c *gofr.Context) (interface{}, error) {
+	var w model.Webhook
+
+	id, err := pathID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = bind(c, &w); err != nil {
+		return nil, err
+	}
+
+	w.ID = id
+
+	resp, err := h.service.UpdateWebhook(c, w)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (h webhook) Delete(c *gofr.Context) (interface{}, error) {
+	id, err := pathID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.service.DeleteWebhook(c, id); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// Deliveries returns the delivery log of the webhook, filtered by the optional status and limit query parameters.
+func (h webhook) Deliveries(c *gofr.Context) (interface{}, error) {
+	id, err := pathID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := queryID(c, "limit")
+	if err != nil {
+		return nil, err
+	}
+
+	n := 0
+	if limit != nil {
+		n = *limit
+	}
+
+	resp, err := h.service.GetDeliveries(c, id, model.DeliveryStatus(c.Param("status")), n)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Replay sends a failed delivery of the webhook again.
+func (h webhook) Replay(c *gofr.Context) (interface{}, error) {
+	id, err := pathID(c)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveryID, err := strconv.ParseInt(c.PathParam("deliveryID"), 10, 64)
+	if err != nil {
+		return nil, errors.InvalidParam{Param: []string{"deliveryID"}}
+	}
+
+	resp, err := h.service.ReplayDelivery(c, id, deliveryID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
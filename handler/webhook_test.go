@@ -0,0 +1,288 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"developer.zopsmart.com/go/gofr/pkg/gofr/request"
+	"developer.zopsmart.com/go/gofr/pkg/gofr/responder"
+
+	"example/domain"
+	"example/model"
+	"example/service/mocks"
+)
+
+func newWebhookContext(app *gofr.Gofr, method, target string, params map[string]string, body []byte) *gofr.Context {
+	r := httptest.NewRequest(method, target, bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	ctx := gofr.NewContext(responder.NewContextualResponder(w, r), request.NewHTTPRequest(r), app)
+	ctx.SetPathParams(params)
+
+	return ctx
+}
+
+func TestWebhook_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockWebhookService(ctrl)
+	h := NewWebhook(m)
+	app := gofr.New()
+
+	testcases := []struct {
+		desc   string
+		output interface{}
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"success", []model.Webhook{{ID: 1, URL: "https://example.com"}}, nil, []*gomock.Call{
+			m.EXPECT().GetWebhooks(gomock.Any()).Return([]model.Webhook{{ID: 1, URL: "https://example.com"}}, nil),
+		}},
+		{"failure", nil, domain.Unavailable{Err: errors.DB{}}, []*gomock.Call{
+			m.EXPECT().GetWebhooks(gomock.Any()).Return(nil, domain.Unavailable{Err: errors.DB{}}),
+		}},
+	}
+
+	for i, tc := range testcases {
+		resp, err := h.Get(newWebhookContext(app, http.MethodGet, "/webhooks", nil, nil))
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestWebhook_GetByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockWebhookService(ctrl)
+	h := webhook{service: m}
+	app := gofr.New()
+	notFound := errors.EntityNotFound{Entity: "webhook", ID: "2"}
+
+	testcases := []struct {
+		desc   string
+		id     string
+		output interface{}
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"success", "1", model.Webhook{ID: 1}, nil, []*gomock.Call{
+			m.EXPECT().GetWebhookByID(gomock.Any(), 1).Return(model.Webhook{ID: 1}, nil),
+		}},
+		{"not found", "2", nil, notFound, []*gomock.Call{
+			m.EXPECT().GetWebhookByID(gomock.Any(), 2).Return(model.Webhook{}, notFound),
+		}},
+		{"invalid id", "x", nil, errors.InvalidParam{Param: []string{"id"}}, nil},
+	}
+
+	for i, tc := range testcases {
+		resp, err := h.GetByID(newWebhookContext(app, http.MethodGet, "/webhooks/{id}", map[string]string{"id": tc.id},
+			nil))
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestWebhook_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockWebhookService(ctrl)
+	h := webhook{service: m}
+	app := gofr.New()
+
+	input := model.Webhook{URL: "https://example.com", Secret: "0123456789abcdef",
+		Events: []model.EventType{model.EmployeeCreated}}
+
+	testcases := []struct {
+		desc   string
+		req    []byte
+		output interface{}
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"success", []byte(`{"url":"https://example.com","secret":"0123456789abcdef","events":["EmployeeCreated"]}`),
+			model.Webhook{ID: 1, URL: "https://example.com"}, nil, []*gomock.Call{
+				m.EXPECT().CreateWebhook(gomock.Any(), input).Return(model.Webhook{ID: 1, URL: "https://example.com"}, nil),
+			}},
+		{"invalid", []byte(`{"url":"x"}`), nil, errors.InvalidParam{Param: []string{"url"}}, []*gomock.Call{
+			m.EXPECT().CreateWebhook(gomock.Any(), model.Webhook{URL: "x"}).
+				Return(model.Webhook{}, errors.InvalidParam{Param: []string{"url"}}),
+		}},
+		{"unmarshal error", []byte(``), nil, errors.InvalidParam{Param: []string{"body"}}, nil},
+	}
+
+	for i, tc := range testcases {
+		resp, err := h.Create(newWebhookContext(app, http.MethodPost, "/webhooks", nil, tc.req))
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestWebhook_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockWebhookService(ctrl)
+	h := webhook{service: m}
+	app := gofr.New()
+
+	testcases := []struct {
+		desc   string
+		id     string
+		req    []byte
+		output interface{}
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"success", "1", []byte(`{"url":"https://example.org"}`), model.Webhook{ID: 1, URL: "https://example.org"}, nil,
+			[]*gomock.Call{m.EXPECT().UpdateWebhook(gomock.Any(), model.Webhook{ID: 1, URL: "https://example.org"}).
+				Return(model.Webhook{ID: 1, URL: "https://example.org"}, nil)}},
+		{"failure", "2", []byte(`{"url":"https://example.org"}`), nil, domain.Unavailable{Err: errors.DB{}}, []*gomock.Call{
+			m.EXPECT().UpdateWebhook(gomock.Any(), model.Webhook{ID: 2, URL: "https://example.org"}).
+				Return(model.Webhook{}, domain.Unavailable{Err: errors.DB{}})}},
+		{"invalid id", "", []byte(`{}`), nil, errors.InvalidParam{Param: []string{"id"}}, nil},
+		{"unmarshal error", "3", []byte(``), nil, errors.InvalidParam{Param: []string{"body"}}, nil},
+	}
+
+	for i, tc := range testcases {
+		resp, err := h.Update(newWebhookContext(app, http.MethodPut, "/webhooks/{id}", map[string]string{"id": tc.id},
+			tc.req))
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestWebhook_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockWebhookService(ctrl)
+	h := webhook{service: m}
+	app := gofr.New()
+	notFound := errors.EntityNotFound{Entity: "webhook", ID: "2"}
+
+	testcases := []struct {
+		desc string
+		id   string
+		err  error
+		mock []*gomock.Call
+	}{
+		{"success", "1", nil, []*gomock.Call{m.EXPECT().DeleteWebhook(gomock.Any(), 1).Return(nil)}},
+		{"not found", "2", notFound, []*gomock.Call{m.EXPECT().DeleteWebhook(gomock.Any(), 2).Return(notFound)}},
+		{"invalid id", "x", errors.InvalidParam{Param: []string{"id"}}, nil},
+	}
+
+	for i, tc := range testcases {
+		_, err := h.Delete(newWebhookContext(app, http.MethodDelete, "/webhooks/{id}", map[string]string{"id": tc.id},
+			nil))
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestWebhook_Deliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockWebhookService(ctrl)
+	h := webhook{service: m}
+	app := gofr.New()
+	deliveries := []model.Delivery{{ID: 3, WebhookID: 1, Status: model.DeliveryFailed}}
+
+	testcases := []struct {
+		desc   string
+		target string
+		id     string
+		output interface{}
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"success", "/webhooks/1/deliveries", "1", deliveries, nil, []*gomock.Call{
+			m.EXPECT().GetDeliveries(gomock.Any(), 1, model.DeliveryStatus(""), 0).Return(deliveries, nil),
+		}},
+		{"filtered", "/webhooks/1/deliveries?status=failed&limit=10", "1", deliveries, nil, []*gomock.Call{
+			m.EXPECT().GetDeliveries(gomock.Any(), 1, model.DeliveryFailed, 10).Return(deliveries, nil),
+		}},
+		{"invalid status", "/webhooks/1/deliveries?status=lost", "1", nil,
+			errors.InvalidParam{Param: []string{"status"}}, []*gomock.Call{
+				m.EXPECT().GetDeliveries(gomock.Any(), 1, model.DeliveryStatus("lost"), 0).
+					Return(nil, errors.InvalidParam{Param: []string{"status"}}),
+			}},
+		{"invalid limit", "/webhooks/1/deliveries?limit=ten", "1", nil, errors.InvalidParam{Param: []string{"limit"}},
+			nil},
+		{"invalid id", "/webhooks/x/deliveries", "x", nil, errors.InvalidParam{Param: []string{"id"}}, nil},
+	}
+
+	for i, tc := range testcases {
+		resp, err := h.Deliveries(newWebhookContext(app, http.MethodGet, tc.target, map[string]string{"id": tc.id}, nil))
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestWebhook_Replay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockWebhookService(ctrl)
+	h := webhook{service: m}
+	app := gofr.New()
+	pending := model.Delivery{ID: 3, WebhookID: 1, Status: model.DeliveryPending}
+	conflict := domain.Conflict{Entity: "delivery",
+		Reason: "delivery 4 is pending, only failed deliveries can be replayed"}
+
+	testcases := []struct {
+		desc     string
+		delivery string
+		output   interface{}
+		err      error
+		mock     []*gomock.Call
+	}{
+		{"success", "3", pending, nil, []*gomock.Call{
+			m.EXPECT().ReplayDelivery(gomock.Any(), 1, int64(3)).Return(pending, nil),
+		}},
+		{"not failed", "4", nil, conflict, []*gomock.Call{
+			m.EXPECT().ReplayDelivery(gomock.Any(), 1, int64(4)).Return(model.Delivery{}, conflict),
+		}},
+		{"invalid delivery id", "x", nil, errors.InvalidParam{Param: []string{"deliveryID"}}, nil},
+	}
+
+	for i, tc := range testcases {
+		resp, err := h.Replay(newWebhookContext(app, http.MethodPost, "/webhooks/{id}/deliveries/{deliveryID}/replay",
+			map[string]string{"id": "1", "deliveryID": tc.delivery}, nil))
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
@@ -1,28 +1,467 @@
 package main
 
 import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
 	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"google.golang.org/grpc"
 
+	"example/datastore"
+	"example/datastore/cache"
+	"example/datastore/department"
 	"example/datastore/employee"
+	"example/datastore/events"
+	"example/datastore/idempotency"
+	"example/datastore/outbox"
+	"example/datastore/webhook"
+	"example/graph"
 	"example/handler"
+	"example/metrics"
 	"example/middleware"
+	"example/migrations"
+	"example/model"
+	"example/rpc"
+	"example/service"
+	"example/service/departments"
 	"example/service/employees"
+	"example/service/health"
+	idempotencyService "example/service/idempotency"
+	outboxService "example/service/outbox"
+	"example/service/webhooks"
+	"example/tracing"
 )
 
 func main() {
 	app := gofr.New()
-	app.Server.UseMiddleware(middleware.Oauth)
 
-	store := employee.New()
-	service := employees.New(store)
-	h := handler.New(service)
+	shutdown := newTracing(app)
+	defer shutdown()
+
+	if err := metrics.Register(app); err != nil {
+		app.Logger.Fatalf("failed to register metrics: %v", err)
+	}
+
+	store := newStore(app)
+	hs := newHealth(app, store)
+	probes := handler.NewHealth(hs, app)
+
+	deptStore := department.New()
+	service := employees.New(store, deptStore)
+	deptService := departments.New(deptStore, store)
+	idempotent := newIdempotency(app)
+	v1 := handler.New(service, idempotent)
+	d := handler.NewDepartment(deptService)
+
+	// requests are rate limited in two layers with their own limits: the client IP before authentication, so that
+	// requests failing it count too, and the api key after it, so that clients sharing an IP are limited apart
+	ipLimit, keyLimit := newRateLimits(app)
+
+	app.Server.UseMiddleware(middleware.RequestID, middleware.Mount("/health/live", http.HandlerFunc(probes.Live)),
+		middleware.Mount("/health/ready", http.HandlerFunc(probes.Ready)), middleware.AccessLog(app.Logger),
+		newDeprecation(app), newOpenAPI(app), middleware.Problems, ipLimit, middleware.Oauth, keyLimit,
+		newMaxBodySize(app), middleware.ConditionalGET,
+		middleware.Mount("/graphql", newGraphQL(app, service, deptService), http.MethodPost))
 
-	app.GET("/emp", h.Get)
-	app.GET("/emp/{id}", h.GetByID)
-	app.PUT("/emp/{id}", h.Update)
-	app.POST("/emp", h.Create)
+	employeeRoutes(app, "/emp", v1)
+	employeeRoutes(app, "/v1/emp", v1)
+	employeeRoutes(app, "/v2/emp", handler.NewV2(service, idempotent))
+
+	app.GET("/departments", route(d.Get))
+	app.GET("/departments/{id}", route(d.GetByID))
+	app.PUT("/departments/{id}", route(d.Update))
+	app.POST("/departments", route(d.Create))
+	app.DELETE("/departments/{id}", route(d.Delete))
+	app.GET("/departments/{id}/employees", route(d.GetEmployees))
+
+	webhookStore := webhook.New()
+	wh := handler.NewWebhook(webhooks.New(webhookStore))
+	admin := newAdmin(app)
+
+	app.GET("/webhooks", route(admin(wh.Get)))
+	app.GET("/webhooks/{id}", route(admin(wh.GetByID)))
+	app.PUT("/webhooks/{id}", route(admin(wh.Update)))
+	app.POST("/webhooks", route(admin(wh.Create)))
+	app.DELETE("/webhooks/{id}", route(admin(wh.Delete)))
+	app.GET("/webhooks/{id}/deliveries", route(admin(wh.Deliveries)))
+	app.POST("/webhooks/{id}/deliveries/{deliveryID}/replay", route(admin(wh.Replay)))
+
+	grpcServer := serveGRPC(app, service)
+	relayOutbox(app, events.NewFanout(newPublisher(app), webhookStore))
+	deliverWebhooks(app, webhookStore)
+
+	drainOnSignal(app, hs, grpcServer)
 
 	app.Server.HTTP.Port = 9090
 	app.EnableSwaggerUI()
 	app.Start()
 }
+
+// newHealth checks readiness against the employee database and its schema, failing checks that take longer than
+// HEALTH_CHECK_TIMEOUT.
+func newHealth(app *gofr.Gofr, store datastore.EmpStore) service.HealthService {
+	timeout, err := time.ParseDuration(app.Config.GetOrDefault("HEALTH_CHECK_TIMEOUT", "2s"))
+	if err != nil {
+		app.Logger.Fatalf("invalid HEALTH_CHECK_TIMEOUT: %v", err)
+	}
+
+	return health.New(timeout, health.Database(store), health.Migrations(migrations.All()))
+}
+
+// drainOnSignal reports the service not ready once it is asked to stop and, after SHUTDOWN_DELAY gave the
+// orchestrator time to stop routing requests to it, shuts the HTTP and gRPC servers down gracefully: they stop
+// accepting connections and wait for the requests in flight to complete, after which main returns.
+func drainOnSignal(app *gofr.Gofr, hs service.HealthService, grpcServer *grpc.Server) {
+	delay, err := time.ParseDuration(app.Config.GetOrDefault("SHUTDOWN_DELAY", "10s"))
+	if err != nil {
+		app.Logger.Fatalf("invalid SHUTDOWN_DELAY: %v", err)
+	}
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGTERM, os.Interrupt)
+
+	go func() {
+		sig := <-stop
+
+		app.Logger.Infof("received %v, draining for %v before shutting down", sig, delay)
+		hs.Drain()
+		time.Sleep(delay)
+
+		grpcServer.GracefulStop()
+		app.Server.Done()
+	}()
+}
+
+// newTracing exports the spans of the service and store calls to TRACING_EXPORTER (stdout, otlp or none), sending
+// them to the collector at TRACING_OTLP_ENDPOINT for otlp. The returned function flushes the pending spans.
+func newTracing(app *gofr.Gofr) func() {
+	shutdown, err := tracing.Setup(app.Config.GetOrDefault("APP_NAME", "example"),
+		app.Config.GetOrDefault("TRACING_EXPORTER", "none"),
+		app.Config.GetOrDefault("TRACING_OTLP_ENDPOINT", "localhost:4317"), os.Stdout)
+	if err != nil {
+		app.Logger.Fatalf("invalid tracing configuration: %v", err)
+	}
+
+	return func() {
+		if err := shutdown(context.Background()); err != nil {
+			app.Logger.Errorf("failed to flush spans: %v", err)
+		}
+	}
+}
+
+// newGraphQL serves the employees and departments over GraphQL with the services behind the HTTP handlers.
+func newGraphQL(app *gofr.Gofr, emp service.EmpService, dept service.DeptService) http.Handler {
+	schema, err := graph.New(emp, dept)
+	if err != nil {
+		app.Logger.Fatalf("invalid GraphQL schema: %v", err)
+	}
+
+	return handler.NewGraphQL(schema, app)
+}
+
+// serveGRPC serves the employees over gRPC on GRPC_PORT with the service behind the HTTP handlers.
+func serveGRPC(app *gofr.Gofr, emp service.EmpService) *grpc.Server {
+	port := app.Config.GetOrDefault("GRPC_PORT", "10000")
+
+	lis, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		app.Logger.Fatalf("failed to listen for gRPC on port %s: %v", port, err)
+	}
+
+	s := rpc.NewServer(app, emp)
+
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			app.Logger.Errorf("gRPC server stopped: %v", err)
+		}
+	}()
+
+	return s
+}
+
+// employeeHandler is implemented by the employee handlers of every API version.
+type employeeHandler interface {
+	Get(*gofr.Context) (interface{}, error)
+	GetByID(*gofr.Context) (interface{}, error)
+	Update(*gofr.Context) (interface{}, error)
+	Create(*gofr.Context) (interface{}, error)
+	Reports(*gofr.Context) (interface{}, error)
+	Subtree(*gofr.Context) (interface{}, error)
+	Chain(*gofr.Context) (interface{}, error)
+}
+
+// employeeRoutes registers the employee routes of an API version under prefix.
+func employeeRoutes(app *gofr.Gofr, prefix string, h employeeHandler) {
+	app.GET(prefix, route(h.Get))
+	app.GET(prefix+"/{id}", route(h.GetByID))
+	app.PUT(prefix+"/{id}", route(h.Update))
+	app.POST(prefix, route(h.Create))
+	app.GET(prefix+"/{id}/reports", route(h.Reports))
+	app.GET(prefix+"/{id}/subtree", route(h.Subtree))
+	app.GET(prefix+"/{id}/chain", route(h.Chain))
+}
+
+// newDeprecation marks version 1 of the API, served under /v1 and its /emp alias, as deprecated since
+// API_V1_DEPRECATED and withdrawn at API_V1_SUNSET, both dates such as 2027-04-01. Version 1 is not marked while
+// API_V1_SUNSET is unset.
+func newDeprecation(app *gofr.Gofr) func(http.Handler) http.Handler {
+	sunsetAt := app.Config.Get("API_V1_SUNSET")
+	if sunsetAt == "" {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	sunset, err := model.ParseDate(sunsetAt)
+	if err != nil {
+		app.Logger.Fatalf("invalid API_V1_SUNSET: %v", err)
+	}
+
+	deprecated, err := model.ParseDate(app.Config.GetOrDefault("API_V1_DEPRECATED", sunsetAt))
+	if err != nil {
+		app.Logger.Fatalf("invalid API_V1_DEPRECATED: %v", err)
+	}
+
+	v1 := middleware.Deprecation("/v1", "/v2", deprecated.Time, sunset.Time)
+	alias := middleware.Deprecation("/emp", "/v2/emp", deprecated.Time, sunset.Time)
+
+	return func(next http.Handler) http.Handler {
+		return v1(alias(next))
+	}
+}
+
+// newAdmin restricts handlers to the comma separated principals in WEBHOOK_ADMINS, as webhooks send employee data
+// to the addresses they are registered with.
+func newAdmin(app *gofr.Gofr) func(gofr.Handler) gofr.Handler {
+	var admins []string
+
+	for _, p := range strings.Split(app.Config.GetOrDefault("WEBHOOK_ADMINS", ""), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			admins = append(admins, p)
+		}
+	}
+
+	if len(admins) == 0 {
+		app.Logger.Warnf("WEBHOOK_ADMINS is empty, nobody is allowed to manage webhooks")
+	}
+
+	return handler.Admin(admins...)
+}
+
+// route wraps every handler so that its logs carry the request id and its errors are answered as problem details.
+func route(fn gofr.Handler) gofr.Handler {
+	return handler.Logging(handler.Problems(fn))
+}
+
+// newOpenAPI validates requests and responses against api/openapi.json when APP_ENV is development or test.
+func newOpenAPI(app *gofr.Gofr) func(http.Handler) http.Handler {
+	if env := app.Config.GetOrDefault("APP_ENV", "production"); env != "development" && env != "test" {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	doc, err := os.ReadFile("api/openapi.json")
+	if err != nil {
+		app.Logger.Fatalf("failed to read the OpenAPI document: %v", err)
+	}
+
+	validate, err := middleware.OpenAPI(doc)
+	if err != nil {
+		app.Logger.Fatalf("invalid OpenAPI document: %v", err)
+	}
+
+	return validate
+}
+
+// newMaxBodySize caps request bodies at MAX_BODY_SIZE bytes.
+func newMaxBodySize(app *gofr.Gofr) func(http.Handler) http.Handler {
+	size := app.Config.GetOrDefault("MAX_BODY_SIZE", "1048576")
+
+	limit, err := strconv.ParseInt(size, 10, 64)
+	if err != nil || limit <= 0 {
+		app.Logger.Fatalf("invalid MAX_BODY_SIZE %q", size)
+	}
+
+	return middleware.MaxBodySize(limit)
+}
+
+// newRateLimits returns the ip and api key rate limiting layers, limiting requests to RATE_LIMIT_IP_DEFAULT and
+// RATE_LIMIT_KEY_DEFAULT, or to the first matching RATE_LIMIT_IP_ROUTES and RATE_LIMIT_KEY_ROUTES entry, with the
+// buckets of both kept by RATE_LIMIT_BACKEND (memory or redis).
+func newRateLimits(app *gofr.Gofr) (ip, key func(http.Handler) http.Handler) {
+	var limiter middleware.Limiter
+
+	switch backend := app.Config.GetOrDefault("RATE_LIMIT_BACKEND", "memory"); backend {
+	case "memory":
+		limiter = middleware.NewMemoryLimiter()
+	case "redis":
+		limiter = middleware.NewRedisLimiter(app.Redis)
+	default:
+		app.Logger.Fatalf("unknown RATE_LIMIT_BACKEND %q", backend)
+	}
+
+	routes, def := rateLimits(app, "RATE_LIMIT_IP", "20:40")
+	ip = middleware.RateLimitIP(limiter, routes, def)
+
+	routes, def = rateLimits(app, "RATE_LIMIT_KEY", "10:20")
+	key = middleware.RateLimitKey(limiter, routes, def)
+
+	return ip, key
+}
+
+// rateLimits reads the limit of a rate limiting layer from prefix_DEFAULT and the limits of its routes from
+// prefix_ROUTES.
+func rateLimits(app *gofr.Gofr, prefix, def string) ([]middleware.Route, middleware.Limit) {
+	limit, err := middleware.ParseLimit(app.Config.GetOrDefault(prefix+"_DEFAULT", def))
+	if err != nil {
+		app.Logger.Fatalf("invalid %s_DEFAULT: %v", prefix, err)
+	}
+
+	routes, err := middleware.ParseRoutes(app.Config.GetOrDefault(prefix+"_ROUTES", ""))
+	if err != nil {
+		app.Logger.Fatalf("invalid %s_ROUTES: %v", prefix, err)
+	}
+
+	return routes, limit
+}
+
+// newIdempotency remembers the responses of requests sent with an Idempotency-Key for IDEMPOTENCY_TTL, purging the
+// older ones every IDEMPOTENCY_PURGE_INTERVAL. A request holds its key for at most IDEMPOTENCY_LOCK before another
+// request with the key may take it over.
+func newIdempotency(app *gofr.Gofr) service.IdempotencyService {
+	s := idempotencyService.New(idempotency.New(), duration(app, "IDEMPOTENCY_TTL", "24h"),
+		duration(app, "IDEMPOTENCY_LOCK", "1m"))
+
+	ctx := gofr.NewContext(nil, nil, app)
+	ctx.Context = context.Background()
+
+	go s.Purge(ctx, duration(app, "IDEMPOTENCY_PURGE_INTERVAL", "1h"))
+
+	return s
+}
+
+// relayOutbox publishes the events stored with employee changes with p, polling every OUTBOX_INTERVAL for up to
+// OUTBOX_BATCH due events. A failed delivery is retried after OUTBOX_BACKOFF, doubled for every further failure up
+// to OUTBOX_MAX_BACKOFF, until OUTBOX_MAX_ATTEMPTS deliveries failed and the event is dead.
+func relayOutbox(app *gofr.Gofr, p datastore.Publisher) {
+	policy := outboxService.Policy{Batch: count(app, "OUTBOX_BATCH", "100"),
+		MaxAttempts: count(app, "OUTBOX_MAX_ATTEMPTS", "10"), Lease: duration(app, "OUTBOX_LEASE", "30s"),
+		Backoff: duration(app, "OUTBOX_BACKOFF", "1s"), MaxBackoff: duration(app, "OUTBOX_MAX_BACKOFF", "10m")}
+	interval := duration(app, "OUTBOX_INTERVAL", "1s")
+
+	ctx := gofr.NewContext(nil, nil, app)
+	ctx.Context = context.Background()
+
+	go outboxService.New(outbox.New(), p, policy).Run(ctx, interval)
+}
+
+// deliverWebhooks sends the webhook deliveries recorded by the outbox relay, polling every WEBHOOK_INTERVAL for up
+// to WEBHOOK_BATCH due deliveries, each answered within WEBHOOK_TIMEOUT, and refuses to start unless WEBHOOK_LEASE is
+// longer than a batch of timed out requests. A failed attempt is retried after WEBHOOK_BACKOFF, doubled for every
+// further failure up to WEBHOOK_MAX_BACKOFF, until WEBHOOK_MAX_ATTEMPTS attempts failed and the delivery waits to be
+// replayed.
+func deliverWebhooks(app *gofr.Gofr, s datastore.DeliveryStore) {
+	policy := webhooks.Policy{Batch: count(app, "WEBHOOK_BATCH", "20"),
+		MaxAttempts: count(app, "WEBHOOK_MAX_ATTEMPTS", "8"), Lease: duration(app, "WEBHOOK_LEASE", "5m"),
+		Timeout: duration(app, "WEBHOOK_TIMEOUT", "10s"), Backoff: duration(app, "WEBHOOK_BACKOFF", "10s"),
+		MaxBackoff: duration(app, "WEBHOOK_MAX_BACKOFF", "1h")}
+	interval := duration(app, "WEBHOOK_INTERVAL", "1s")
+
+	if err := policy.Validate(); err != nil {
+		app.Logger.Fatalf("invalid WEBHOOK_LEASE: %v", err)
+	}
+
+	ctx := gofr.NewContext(nil, nil, app)
+	ctx.Context = context.Background()
+
+	go webhooks.NewWorker(s, policy).Run(ctx, interval)
+}
+
+// count returns the positive number configured for key, or def when it is unset.
+func count(app *gofr.Gofr, key, def string) int {
+	n, err := strconv.Atoi(app.Config.GetOrDefault(key, def))
+	if err != nil || n <= 0 {
+		app.Logger.Fatalf("invalid %s %q", key, app.Config.Get(key))
+	}
+
+	return n
+}
+
+// duration returns the duration configured for key, or def when it is unset.
+func duration(app *gofr.Gofr, key, def string) time.Duration {
+	d, err := time.ParseDuration(app.Config.GetOrDefault(key, def))
+	if err != nil {
+		app.Logger.Fatalf("invalid %s: %v", key, err)
+	}
+
+	return d
+}
+
+// newPublisher publishes the changes to employees with EVENTS_BACKEND: kafka produces them to KAFKA_TOPIC on the
+// comma separated KAFKA_BROKERS, file appends them to EVENTS_FILE, memory keeps them in memory and none drops them.
+func newPublisher(app *gofr.Gofr) datastore.Publisher {
+	switch backend := app.Config.GetOrDefault("EVENTS_BACKEND", "none"); backend {
+	case "kafka":
+		brokers := strings.Split(app.Config.GetOrDefault("KAFKA_BROKERS", "localhost:9092"), ",")
+
+		p, err := events.NewKafka(brokers, app.Config.GetOrDefault("KAFKA_TOPIC", "employee-events"))
+		if err != nil {
+			app.Logger.Fatalf("failed to connect to Kafka: %v", err)
+		}
+
+		return p
+	case "file":
+		p, err := events.NewFile(app.Config.GetOrDefault("EVENTS_FILE", "events.jsonl"))
+		if err != nil {
+			app.Logger.Fatalf("invalid EVENTS_FILE: %v", err)
+		}
+
+		return p
+	case "memory":
+		return events.NewMemory()
+	case "none":
+		return events.NewDiscard()
+	default:
+		app.Logger.Fatalf("unknown EVENTS_BACKEND %q", backend)
+	}
+
+	return events.NewDiscard()
+}
+
+// newStore wraps the employee store with the cache selected by CACHE_BACKEND (lru, redis or none), and records the
+// employee count every EMPLOYEE_COUNT_INTERVAL.
+func newStore(app *gofr.Gofr) datastore.EmpStore {
+	store := employee.New()
+
+	ctx := gofr.NewContext(nil, nil, app)
+	ctx.Context = context.Background()
+
+	go store.Count(ctx, duration(app, "EMPLOYEE_COUNT_INTERVAL", "1m"))
+
+	ttl, err := time.ParseDuration(app.Config.GetOrDefault("CACHE_TTL", "5m"))
+	if err != nil {
+		app.Logger.Fatalf("invalid CACHE_TTL: %v", err)
+	}
+
+	switch backend := app.Config.GetOrDefault("CACHE_BACKEND", "none"); backend {
+	case "lru":
+		size, err := strconv.Atoi(app.Config.GetOrDefault("CACHE_SIZE", "1000"))
+		if err != nil {
+			app.Logger.Fatalf("invalid CACHE_SIZE: %v", err)
+		}
+
+		return cache.New(store, cache.NewLRU(size), ttl)
+	case "redis":
+		return cache.New(store, cache.NewRedis(), ttl)
+	case "none":
+		return store
+	default:
+		app.Logger.Fatalf("unknown CACHE_BACKEND %q", backend)
+	}
+
+	return store
+}
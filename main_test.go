@@ -0,0 +1,198 @@
+package main
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// registeredRoutes returns "METHOD path" for every route main.go registers with app.GET, app.PUT, app.POST,
+// app.DELETE or mounts with middleware.Mount, for the http.MethodX methods it is mounted for or GET, the method of
+// the probes mounted for every method. Routes registered under a prefix parameter by
+// a route group function, such as employeeRoutes, are expanded for every call of the function with a literal prefix.
+func registeredRoutes(t *testing.T) []string {
+	f, err := parser.ParseFile(token.NewFileSet(), "main.go", nil, 0)
+	if err != nil {
+		t.Fatalf("failed to parse main.go: %v", err)
+	}
+
+	var routes []string
+
+	groups := map[string][]string{}
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		if fn, ok := n.(*ast.FuncDecl); ok {
+			ast.Inspect(fn, func(n ast.Node) bool {
+				if method, suffix, ok := groupRoute(n); ok {
+					groups[fn.Name.Name] = append(groups[fn.Name.Name], method+" "+suffix)
+				}
+
+				return true
+			})
+		}
+
+		return true
+	})
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+
+		if fn, ok := call.Fun.(*ast.Ident); ok && len(groups[fn.Name]) > 0 && len(call.Args) > 1 {
+			if prefix, ok := stringLit(call.Args[1]); ok {
+				for _, r := range groups[fn.Name] {
+					method, suffix := split(r)
+					routes = append(routes, method+" "+prefix+suffix)
+				}
+			}
+
+			return true
+		}
+
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		path, ok := stringLit(call.Args[0])
+		if !ok {
+			return true
+		}
+
+		switch x, _ := sel.X.(*ast.Ident); {
+		case x != nil && x.Name == "app" && isMethod(sel.Sel.Name):
+			routes = append(routes, sel.Sel.Name+" "+path)
+		case x != nil && x.Name == "middleware" && sel.Sel.Name == "Mount":
+			for _, m := range mountMethods(call) {
+				routes = append(routes, m+" "+path)
+			}
+		}
+
+		return true
+	})
+
+	sort.Strings(routes)
+
+	return routes
+}
+
+// groupRoute reports the method and path suffix of an app.GET, app.PUT, app.POST or app.DELETE call registering a
+// route at a prefix parameter, written as prefix or prefix+"/suffix".
+func groupRoute(n ast.Node) (method, suffix string, ok bool) {
+	call, ok := n.(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return "", "", false
+	}
+
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", "", false
+	}
+
+	if x, _ := sel.X.(*ast.Ident); x == nil || x.Name != "app" || !isMethod(sel.Sel.Name) {
+		return "", "", false
+	}
+
+	switch arg := call.Args[0].(type) {
+	case *ast.Ident:
+		return sel.Sel.Name, "", true
+	case *ast.BinaryExpr:
+		if _, ok := arg.X.(*ast.Ident); ok && arg.Op == token.ADD {
+			suffix, ok = stringLit(arg.Y)
+			return sel.Sel.Name, suffix, ok
+		}
+	}
+
+	return "", "", false
+}
+
+// mountMethods returns the methods a middleware.Mount call names with http.MethodX constants, or GET when it names
+// none.
+func mountMethods(call *ast.CallExpr) []string {
+	var methods []string
+
+	for _, arg := range call.Args[2:] {
+		if sel, ok := arg.(*ast.SelectorExpr); ok && strings.HasPrefix(sel.Sel.Name, "Method") {
+			methods = append(methods, strings.ToUpper(strings.TrimPrefix(sel.Sel.Name, "Method")))
+		}
+	}
+
+	if len(methods) == 0 {
+		methods = []string{"GET"}
+	}
+
+	return methods
+}
+
+func isMethod(name string) bool {
+	return name == "GET" || name == "PUT" || name == "POST" || name == "DELETE"
+}
+
+func stringLit(e ast.Expr) (string, bool) {
+	lit, ok := e.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+
+	s, err := strconv.Unquote(lit.Value)
+
+	return s, err == nil
+}
+
+func split(route string) (method, path string) {
+	i := strings.Index(route, " ")
+
+	return route[:i], route[i+1:]
+}
+
+// documentedRoutes returns "METHOD path" for every operation of api/openapi.json.
+func documentedRoutes(t *testing.T) []string {
+	b, err := os.ReadFile("api/openapi.json")
+	if err != nil {
+		t.Fatalf("failed to read the OpenAPI document: %v", err)
+	}
+
+	var spec struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+
+	if err = json.Unmarshal(b, &spec); err != nil {
+		t.Fatalf("failed to decode the OpenAPI document: %v", err)
+	}
+
+	var routes []string
+
+	for path, ops := range spec.Paths {
+		for method := range ops {
+			if method != "parameters" {
+				routes = append(routes, strings.ToUpper(method)+" "+path)
+			}
+		}
+	}
+
+	sort.Strings(routes)
+
+	return routes
+}
+
+func TestOpenAPIMatchesRoutes(t *testing.T) {
+	registered, documented := registeredRoutes(t), documentedRoutes(t)
+
+	if len(registered) == 0 {
+		t.Fatal("Failed. No routes found in main.go")
+	}
+
+	if !reflect.DeepEqual(registered, documented) {
+		t.Errorf("Failed. Routes registered in main.go and documented in api/openapi.json differ.\nregistered: %v\n"+
+			"documented: %v", registered, documented)
+	}
+}
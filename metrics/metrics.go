@@ -0,0 +1,103 @@
+// Package metrics defines the employee domain metrics and records them through the gofr metric registry, which
+// serves them on the metrics endpoint.
+package metrics
+
+import (
+	"time"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/domain"
+)
+
+const (
+	// Operations counts employee service calls by operation and outcome.
+	Operations = "employee_operations_total"
+	// StoreDuration observes the latency of employee store calls by method.
+	StoreDuration = "employee_store_duration_seconds"
+	// Employees is the number of employees stored.
+	Employees = "employee_count"
+)
+
+// Outcomes of an operation, as recorded in the outcome label of Operations.
+const (
+	Success  = "success"
+	Invalid  = "invalid"
+	NotFound = "not_found"
+	Conflict = "conflict"
+	Failure  = "error"
+)
+
+// nolint:gochecknoglobals // buckets, in seconds, sized for single row queries up to slow recursive ones
+var storeBuckets = []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5}
+
+// Registry creates metrics, such as *gofr.Gofr.
+type Registry interface {
+	NewCounter(name, help string, labels ...string) error
+	NewGauge(name, help string, labels ...string) error
+	NewHistogram(name, help string, buckets []float64, labels ...string) error
+}
+
+// Register creates the employee metrics in r.
+func Register(r Registry) error {
+	if err := r.NewCounter(Operations, "Employee operations by operation and outcome.", "operation", "outcome"); err != nil {
+		return err
+	}
+
+	if err := r.NewHistogram(StoreDuration, "Latency of employee store calls in seconds.", storeBuckets,
+		"method"); err != nil {
+		return err
+	}
+
+	return r.NewGauge(Employees, "Number of employees stored.")
+}
+
+// Operation counts a call to the service operation that returned err.
+func Operation(ctx *gofr.Context, operation string, err error) {
+	if ctx.Gofr == nil || ctx.Metric == nil {
+		return
+	}
+
+	if err := ctx.Metric.IncCounter(Operations, operation, Outcome(err)); err != nil {
+		ctx.Logger.Warnf("failed to count %s operation: %v", operation, err)
+	}
+}
+
+// Store observes the latency of a call to the store method that started at start.
+func Store(ctx *gofr.Context, method string, start time.Time) {
+	if ctx.Gofr == nil || ctx.Metric == nil {
+		return
+	}
+
+	if err := ctx.Metric.ObserveHistogram(StoreDuration, time.Since(start).Seconds(), method); err != nil {
+		ctx.Logger.Warnf("failed to observe %s latency: %v", method, err)
+	}
+}
+
+// EmployeeCount records n as the number of employees stored.
+func EmployeeCount(ctx *gofr.Context, n int) {
+	if ctx.Gofr == nil || ctx.Metric == nil {
+		return
+	}
+
+	if err := ctx.Metric.SetGauge(Employees, float64(n)); err != nil {
+		ctx.Logger.Warnf("failed to record employee count: %v", err)
+	}
+}
+
+// Outcome classifies err into the outcomes recorded by Operation.
+func Outcome(err error) string {
+	switch err.(type) {
+	case nil:
+		return Success
+	case domain.Invalid, errors.MissingParam:
+		return Invalid
+	case domain.NotFound:
+		return NotFound
+	case domain.Conflict:
+		return Conflict
+	default:
+		return Failure
+	}
+}
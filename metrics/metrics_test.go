@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"developer.zopsmart.com/go/gofr/pkg/log"
+
+	"example/domain"
+)
+
+type registry struct {
+	created []string
+	err     error
+}
+
+func (r *registry) NewCounter(name, _ string, labels ...string) error {
+	r.created = append(r.created, fmt.Sprint("counter ", name, labels))
+	return r.err
+}
+
+func (r *registry) NewGauge(name, _ string, labels ...string) error {
+	r.created = append(r.created, fmt.Sprint("gauge ", name, labels))
+	return r.err
+}
+
+func (r *registry) NewHistogram(name, _ string, _ []float64, labels ...string) error {
+	r.created = append(r.created, fmt.Sprint("histogram ", name, labels))
+	return r.err
+}
+
+type metric struct {
+	recorded []string
+}
+
+func (m *metric) IncCounter(name string, labels ...string) error {
+	m.recorded = append(m.recorded, fmt.Sprint(name, labels))
+	return nil
+}
+
+func (m *metric) ObserveHistogram(name string, _ float64, labels ...string) error {
+	m.recorded = append(m.recorded, fmt.Sprint(name, labels))
+	return nil
+}
+
+func (m *metric) SetGauge(name string, value float64, labels ...string) error {
+	m.recorded = append(m.recorded, fmt.Sprint(name, labels, value))
+	return nil
+}
+
+func TestRegister(t *testing.T) {
+	testcases := []struct {
+		desc    string
+		err     error
+		created []string
+	}{
+		{"success", nil, []string{"counter employee_operations_total[operation outcome]",
+			"histogram employee_store_duration_seconds[method]", "gauge employee_count[]"}},
+		{"failure", errors.Error("already registered"), []string{"counter employee_operations_total[operation outcome]"}},
+	}
+
+	for i, tc := range testcases {
+		r := &registry{err: tc.err}
+
+		if err := Register(r); !reflect.DeepEqual(tc.err, err) || !reflect.DeepEqual(tc.created, r.created) {
+			t.Errorf("[Test %v]Failed.Expected %v %v but Got %v %v", i+1, tc.err, tc.created, err, r.created)
+		}
+	}
+}
+
+func TestOutcome(t *testing.T) {
+	testcases := []struct {
+		err     error
+		outcome string
+	}{
+		{nil, Success},
+		{errors.InvalidParam{Param: []string{"email"}}, Invalid},
+		{errors.MissingParam{Param: []string{"name"}}, Invalid},
+		{errors.EntityNotFound{Entity: "employee", ID: "1"}, NotFound},
+		{domain.Conflict{Entity: "employee", Field: "email"}, Conflict},
+		{domain.Unavailable{Err: errors.DB{}}, Failure},
+		{errors.Error("Connect Failed"), Failure},
+	}
+
+	for i, tc := range testcases {
+		if outcome := Outcome(tc.err); outcome != tc.outcome {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.outcome, outcome)
+		}
+	}
+}
+
+func TestRecord(t *testing.T) {
+	m := &metric{}
+	ctx := gofr.NewContext(nil, nil, &gofr.Gofr{Metric: m, Logger: log.NewMockLogger(io.Discard)})
+
+	Operation(ctx, "create", domain.Conflict{Entity: "employee", Field: "email"})
+	Store(ctx, "EmpCreate", time.Now())
+	EmployeeCount(ctx, 3)
+
+	expected := []string{"employee_operations_total[create conflict]", "employee_store_duration_seconds[EmpCreate]",
+		"employee_count[] 3"}
+
+	if !reflect.DeepEqual(expected, m.recorded) {
+		t.Errorf("[Test 1]Failed.Expected %v but Got %v", expected, m.recorded)
+	}
+
+	// without a metric registry nothing is recorded and nothing panics
+	Operation(gofr.NewContext(nil, nil, &gofr.Gofr{}), "create", nil)
+}
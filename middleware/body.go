@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+
+	"example/problem"
+)
+
+// MaxBodySize answers 413 to requests declaring a body larger than limit bytes, and caps the body of the others so
+// that reading past limit fails.
+func MaxBodySize(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > limit {
+				problem.Write(w, r, problem.New(http.StatusRequestEntityTooLarge, "request body is too large"))
+				return
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
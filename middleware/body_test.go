@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMaxBodySize(t *testing.T) {
+	var read string
+
+	handler := MaxBodySize(8)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			read = "error"
+			return
+		}
+
+		read = string(b)
+	}))
+
+	testcases := []struct {
+		desc          string
+		body          string
+		contentLength int64
+		status        int
+		read          string
+	}{
+		{"within limit", "12345678", 8, http.StatusOK, "12345678"},
+		{"declared too large", "123456789", 9, http.StatusRequestEntityTooLarge, ""},
+		{"streamed too large", "123456789", -1, http.StatusOK, "error"},
+	}
+
+	for i, tc := range testcases {
+		read = ""
+		r := httptest.NewRequest(http.MethodPost, "/emp", strings.NewReader(tc.body))
+		r.ContentLength = tc.contentLength
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		if w.Code != tc.status || read != tc.read {
+			t.Errorf("[Test %v]Failed. Expected %v %q but got %v %q", i+1, tc.status, tc.read, w.Code, read)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type validatorsKey struct{}
+
+type validators struct {
+	etag         string
+	lastModified time.Time
+}
+
+// SetValidators records the ETag and Last-Modified values of the response being served for r.
+// It is a no-op when r did not pass through ConditionalGET.
+func SetValidators(r *http.Request, etag string, lastModified time.Time) {
+	if v, ok := r.Context().Value(validatorsKey{}).(*validators); ok {
+		v.etag = etag
+		v.lastModified = lastModified
+	}
+}
+
+// ConditionalGET adds ETag and Last-Modified headers to successful GET responses whose handler called
+// SetValidators, and answers 304 Not Modified when If-None-Match or If-Modified-Since show the client is up to date.
+func ConditionalGET(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		v := &validators{}
+		cw := &conditionalWriter{ResponseWriter: w, r: r, v: v}
+
+		next.ServeHTTP(cw, r.WithContext(context.WithValue(r.Context(), validatorsKey{}, v)))
+	})
+}
+
+type conditionalWriter struct {
+	http.ResponseWriter
+	r           *http.Request
+	v           *validators
+	wroteHeader bool
+	notModified bool
+}
+
+func (cw *conditionalWriter) WriteHeader(status int) {
+	if cw.wroteHeader {
+		return
+	}
+
+	cw.wroteHeader = true
+
+	if status != http.StatusOK || (cw.v.etag == "" && cw.v.lastModified.IsZero()) {
+		cw.ResponseWriter.WriteHeader(status)
+		return
+	}
+
+	h := cw.Header()
+
+	if cw.v.etag != "" {
+		h.Set("ETag", cw.v.etag)
+	}
+
+	if !cw.v.lastModified.IsZero() {
+		h.Set("Last-Modified", cw.v.lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if cw.isNotModified() {
+		cw.notModified = true
+
+		h.Del("Content-Type")
+		h.Del("Content-Length")
+		cw.ResponseWriter.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	cw.ResponseWriter.WriteHeader(status)
+}
+
+func (cw *conditionalWriter) Write(b []byte) (int, error) {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+
+	if cw.notModified {
+		return len(b), nil
+	}
+
+	return cw.ResponseWriter.Write(b)
+}
+
+// isNotModified follows RFC 7232: If-None-Match takes precedence and If-Modified-Since is only used without it.
+func (cw *conditionalWriter) isNotModified() bool {
+	if inm := cw.r.Header.Get("If-None-Match"); inm != "" {
+		if cw.v.etag == "" {
+			return false
+		}
+
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(cw.v.etag, "W/") {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	ims, err := http.ParseTime(cw.r.Header.Get("If-Modified-Since"))
+	if err != nil || cw.v.lastModified.IsZero() {
+		return false
+	}
+
+	return !cw.v.lastModified.Truncate(time.Second).After(ims)
+}
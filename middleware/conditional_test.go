@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestConditionalGET(t *testing.T) {
+	modified := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+	handler := ConditionalGET(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		SetValidators(r, `W/"abc"`, modified)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+
+	testcases := []struct {
+		desc   string
+		method string
+		header map[string]string
+		status int
+		body   string
+	}{
+		{"no condition", http.MethodGet, nil, http.StatusOK, `{"data":[]}`},
+		{"etag match", http.MethodGet, map[string]string{"If-None-Match": `"xyz", W/"abc"`}, http.StatusNotModified, ""},
+		{"etag mismatch", http.MethodGet, map[string]string{"If-None-Match": `W/"xyz"`}, http.StatusOK, `{"data":[]}`},
+		{"etag takes precedence", http.MethodGet, map[string]string{"If-None-Match": `W/"xyz"`,
+			"If-Modified-Since": modified.Format(http.TimeFormat)}, http.StatusOK, `{"data":[]}`},
+		{"not modified since", http.MethodGet, map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)},
+			http.StatusNotModified, ""},
+		{"modified since", http.MethodGet, map[string]string{"If-Modified-Since": modified.Add(-time.Hour).Format(http.TimeFormat)},
+			http.StatusOK, `{"data":[]}`},
+		{"not a GET", http.MethodPost, map[string]string{"If-None-Match": `W/"abc"`}, http.StatusOK, `{"data":[]}`},
+	}
+
+	for i, tc := range testcases {
+		r := httptest.NewRequest(tc.method, "/emp", nil)
+		for k, v := range tc.header {
+			r.Header.Set(k, v)
+		}
+
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		if w.Code != tc.status || w.Body.String() != tc.body {
+			t.Errorf("[Test %v]Failed. Expected %v %q but got %v %q", i+1, tc.status, tc.body, w.Code, w.Body.String())
+		}
+
+		if tc.method == http.MethodGet && w.Header().Get("ETag") != `W/"abc"` {
+			t.Errorf("[Test %v]Failed. Expected ETag header but got %q", i+1, w.Header().Get("ETag"))
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Deprecation marks the requests under prefix as using a deprecated version of the API. Their responses carry
+// the Deprecation header with the date the version was deprecated (RFC 9745), the Sunset header with the date it
+// stops being served (RFC 8594), and a Link to the same resource under successor, the prefix of the version that
+// replaces it.
+func Deprecation(prefix, successor string, deprecated, sunset time.Time) func(http.Handler) http.Handler {
+	deprecation := "@" + strconv.FormatInt(deprecated.Unix(), 10)
+	sunsetDate := sunset.UTC().Format(http.TimeFormat)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if path := r.URL.Path; path == prefix || strings.HasPrefix(path, prefix+"/") {
+				h := w.Header()
+				h.Set("Deprecation", deprecation)
+				h.Set("Sunset", sunsetDate)
+				h.Add("Link", `<`+successor+strings.TrimPrefix(path, prefix)+`>; rel="successor-version"`)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDeprecation(t *testing.T) {
+	deprecated := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC)
+	handler := Deprecation("/emp", "/v2/emp", deprecated, sunset)(http.NotFoundHandler())
+
+	testcases := []struct {
+		path        string
+		deprecation string
+		sunset      string
+		link        string
+	}{
+		{"/emp", "@1790812800", "Thu, 01 Apr 2027 00:00:00 GMT", `</v2/emp>; rel="successor-version"`},
+		{"/emp/3/chain", "@1790812800", "Thu, 01 Apr 2027 00:00:00 GMT", `</v2/emp/3/chain>; rel="successor-version"`},
+		{"/employees", "", "", ""},
+		{"/v2/emp", "", "", ""},
+	}
+
+	for i, tc := range testcases {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+		h := w.Header()
+		if h.Get("Deprecation") != tc.deprecation || h.Get("Sunset") != tc.sunset || h.Get("Link") != tc.link {
+			t.Errorf("[Test %v]Failed. Expected %v, %v and %v but got %v, %v and %v", i+1, tc.deprecation, tc.sunset,
+				tc.link, h.Get("Deprecation"), h.Get("Sunset"), h.Get("Link"))
+		}
+	}
+}
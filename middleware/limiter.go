@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+)
+
+// sweepEvery is the number of takes between sweeps of the buckets that have refilled completely.
+const sweepEvery = 1024
+
+type bucket struct {
+	limit  Limit
+	tokens float64
+	last   time.Time
+}
+
+type memoryLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	takes   int
+	now     func() time.Time
+}
+
+// NewMemoryLimiter keeps buckets in process memory, so every instance of the service limits on its own.
+func NewMemoryLimiter() *memoryLimiter {
+	return &memoryLimiter{buckets: make(map[string]*bucket), now: time.Now}
+}
+
+func (m *memoryLimiter) Take(_ context.Context, key string, l Limit) (Decision, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+
+	m.takes++
+	if m.takes%sweepEvery == 0 {
+		m.sweep(now)
+	}
+
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{limit: l, tokens: float64(l.Burst), last: now}
+		m.buckets[key] = b
+	}
+
+	b.limit = l
+	b.tokens = math.Min(float64(l.Burst), b.tokens+now.Sub(b.last).Seconds()*l.Rate)
+	b.last = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+
+	return decide(l, b.tokens, allowed), nil
+}
+
+// sweep drops the buckets that would be full by now, since a missing bucket starts out full.
+func (m *memoryLimiter) sweep(now time.Time) {
+	for k, b := range m.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*b.limit.Rate >= float64(b.limit.Burst) {
+			delete(m.buckets, k)
+		}
+	}
+}
+
+// decide describes a bucket left with tokens after a take.
+func decide(l Limit, tokens float64, allowed bool) Decision {
+	d := Decision{
+		Allowed:   allowed,
+		Remaining: int(tokens),
+		Reset:     fromSeconds((float64(l.Burst) - tokens) / l.Rate),
+	}
+
+	if !allowed {
+		d.RetryAfter = fromSeconds((1 - tokens) / l.Rate)
+	}
+
+	return d
+}
+
+func fromSeconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// takeScript refills and takes from a bucket atomically. Redis truncates numbers returned by scripts to integers,
+// so the tokens left are returned in thousandths.
+const takeScript = `
+local rate, burst, now = tonumber(ARGV[1]), tonumber(ARGV[2]), tonumber(ARGV[3])
+local state = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(state[1]) or burst
+local ts = tonumber(state[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - ts) / 1000 * rate)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call("HSET", KEYS[1], "tokens", tokens, "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst / rate * 1000))
+return {allowed, math.floor(tokens * 1000)}
+`
+
+// Scripter runs Lua scripts, as the redis client of the gofr application does.
+type Scripter interface {
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) *redis.Cmd
+}
+
+type redisLimiter struct {
+	client Scripter
+	now    func() time.Time
+}
+
+// NewRedisLimiter keeps buckets in redis, so that all instances of the service share them.
+func NewRedisLimiter(c Scripter) redisLimiter {
+	return redisLimiter{client: c, now: time.Now}
+}
+
+func (r redisLimiter) Take(ctx context.Context, key string, l Limit) (Decision, error) {
+	res, err := r.client.Eval(ctx, takeScript, []string{key}, l.Rate, l.Burst, r.now().UnixNano()/int64(time.Millisecond)).
+		Int64Slice()
+	if err != nil {
+		return Decision{}, err
+	}
+
+	const resultLen, milli = 2, 1000.0
+
+	if len(res) != resultLen {
+		return Decision{}, errors.Error("unexpected rate limit script result")
+	}
+
+	return decide(l, float64(res[1])/milli, res[0] == 1), nil
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"fmt"
+
+	"developer.zopsmart.com/go/gofr/pkg/log"
+)
+
+type requestLogger struct {
+	log.Logger
+	prefix string
+}
+
+// NewRequestLogger returns a logger that starts every line written through l with request_id=<id>.
+// l is returned unchanged when id is empty.
+func NewRequestLogger(l log.Logger, id string) log.Logger {
+	if id == "" {
+		return l
+	}
+
+	return requestLogger{Logger: l, prefix: "request_id=" + id + " "}
+}
+
+func (l requestLogger) Log(args ...interface{}) {
+	l.Logger.Log(l.prefix + fmt.Sprint(args...))
+}
+
+func (l requestLogger) Info(args ...interface{}) {
+	l.Logger.Info(l.prefix + fmt.Sprint(args...))
+}
+
+func (l requestLogger) Debug(args ...interface{}) {
+	l.Logger.Debug(l.prefix + fmt.Sprint(args...))
+}
+
+func (l requestLogger) Warn(args ...interface{}) {
+	l.Logger.Warn(l.prefix + fmt.Sprint(args...))
+}
+
+func (l requestLogger) Error(args ...interface{}) {
+	l.Logger.Error(l.prefix + fmt.Sprint(args...))
+}
+
+func (l requestLogger) Fatal(args ...interface{}) {
+	l.Logger.Fatal(l.prefix + fmt.Sprint(args...))
+}
+
+func (l requestLogger) Logf(format string, a ...interface{}) {
+	l.Logger.Logf(l.prefix+format, a...)
+}
+
+func (l requestLogger) Infof(format string, a ...interface{}) {
+	l.Logger.Infof(l.prefix+format, a...)
+}
+
+func (l requestLogger) Debugf(format string, a ...interface{}) {
+	l.Logger.Debugf(l.prefix+format, a...)
+}
+
+func (l requestLogger) Warnf(format string, a ...interface{}) {
+	l.Logger.Warnf(l.prefix+format, a...)
+}
+
+func (l requestLogger) Errorf(format string, a ...interface{}) {
+	l.Logger.Errorf(l.prefix+format, a...)
+}
+
+func (l requestLogger) Fatalf(format string, a ...interface{}) {
+	l.Logger.Fatalf(l.prefix+format, a...)
+}
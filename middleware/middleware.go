@@ -1,14 +1,30 @@
 package middleware
 
-import "net/http"
+import (
+	"net/http"
+
+	"example/problem"
+	"example/requestctx"
+)
+
+// nolint:gochecknoglobals // api keys and the principals they authenticate
+var apiKeys = map[string]string{
+	"ram": "ram",
+}
 
 func Oauth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		header := r.Header.Get("api-key")
-		if header != "ram" {
-			w.WriteHeader(http.StatusUnauthorized)
+		principal, ok := Authenticate(r.Header.Get("api-key"))
+		if !ok {
+			problem.Write(w, r, problem.New(http.StatusUnauthorized, "missing or unknown api-key"))
 			return
 		}
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(w, r.WithContext(requestctx.WithPrincipal(r.Context(), principal)))
 	})
 }
+
+// Authenticate returns the principal of an api key, or false when the key is unknown.
+func Authenticate(key string) (string, bool) {
+	principal, ok := apiKeys[key]
+	return principal, ok
+}
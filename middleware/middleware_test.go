@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"example/requestctx"
+)
+
+func TestOauth(t *testing.T) {
+	var principal string
+
+	handler := Oauth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal = requestctx.Principal(r.Context())
+	}))
+
+	testcases := []struct {
+		desc      string
+		key       string
+		status    int
+		principal string
+	}{
+		{"valid key", "ram", http.StatusOK, "ram"},
+		{"invalid key", "sai", http.StatusUnauthorized, ""},
+		{"missing key", "", http.StatusUnauthorized, ""},
+	}
+
+	for i, tc := range testcases {
+		principal = ""
+		r := httptest.NewRequest(http.MethodGet, "/emp", nil)
+		r.Header.Set("api-key", tc.key)
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		if w.Code != tc.status || principal != tc.principal {
+			t.Errorf("[Test %v]Failed. Expected %v %q but got %v %q", i+1, tc.status, tc.principal, w.Code, principal)
+		}
+	}
+}
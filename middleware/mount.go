@@ -0,0 +1,33 @@
+package middleware
+
+import "net/http"
+
+// Mount serves requests for path with h ahead of the middleware and handlers that follow, such as probes that must
+// answer without authentication or rate limiting. When methods are given, only requests with one of them are served
+// by h.
+func Mount(path string, h http.Handler, methods ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == path && allowed(r.Method, methods) {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func allowed(method string, methods []string) bool {
+	if len(methods) == 0 {
+		return true
+	}
+
+	for _, m := range methods {
+		if m == method {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMount(t *testing.T) {
+	probe := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	handler := Mount("/health/live", probe)(Mount("/graphql", probe, http.MethodPost)(Oauth(http.NotFoundHandler())))
+
+	testcases := []struct {
+		method string
+		path   string
+		status int
+	}{
+		{http.MethodGet, "/health/live", http.StatusNoContent},
+		{http.MethodGet, "/health/live/x", http.StatusUnauthorized},
+		{http.MethodGet, "/emp", http.StatusUnauthorized},
+		{http.MethodPost, "/graphql", http.StatusNoContent},
+		{http.MethodGet, "/graphql", http.StatusUnauthorized},
+	}
+
+	for i, tc := range testcases {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, nil))
+
+		if w.Code != tc.status {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.status, w.Code)
+		}
+	}
+}
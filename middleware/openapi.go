@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+
+	"example/problem"
+)
+
+// OpenAPI validates the requests for operations of the OpenAPI document doc, and the responses to them, answering
+// 400 to requests and replacing responses with 500 when they do not match it. The problem lists the JSON pointers
+// of the offending values, or the names of the offending parameters. Requests for paths doc does not describe pass
+// through unchecked, and api keys are left for Oauth to check.
+//
+// Responses are buffered to be validated, so OpenAPI is meant for development and test environments.
+func OpenAPI(doc []byte) (func(http.Handler) http.Handler, error) {
+	spec, err := openapi3.NewLoader().LoadFromData(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = spec.Validate(context.Background()); err != nil {
+		return nil, err
+	}
+
+	// match requests on their path only, whichever host they were sent to
+	spec.Servers = nil
+
+	router, err := gorillamux.NewRouter(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, params, err := router.FindRoute(r)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			in := &openapi3filter.RequestValidationInput{Request: r, PathParams: params, Route: route,
+				Options: &openapi3filter.Options{MultiError: true, AuthenticationFunc: openapi3filter.NoopAuthenticationFunc}}
+
+			if err = openapi3filter.ValidateRequest(r.Context(), in); err != nil {
+				p := problem.New(http.StatusBadRequest, "request does not match the OpenAPI document: "+err.Error())
+				p.InvalidParams = pointers(err)
+				problem.Write(w, r, p)
+
+				return
+			}
+
+			bw := &bufferedWriter{header: http.Header{}, status: http.StatusOK}
+			next.ServeHTTP(bw, r)
+
+			out := &openapi3filter.ResponseValidationInput{RequestValidationInput: in, Status: bw.status,
+				Header: bw.header, Options: in.Options}
+
+			if err = openapi3filter.ValidateResponse(r.Context(), out.SetBodyBytes(bw.body.Bytes())); err != nil {
+				p := problem.New(http.StatusInternalServerError, "response does not match the OpenAPI document: "+err.Error())
+				p.InvalidParams = pointers(err)
+				problem.Write(w, r, p)
+
+				return
+			}
+
+			bw.flush(w)
+		})
+	}, nil
+}
+
+// pointers returns the JSON pointers of the values that failed validation in err, or the names of the parameters.
+func pointers(err error) []string {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var p []string
+		for _, err := range e {
+			p = append(p, pointers(err)...)
+		}
+
+		return p
+	case *openapi3filter.RequestError:
+		if e.Parameter != nil {
+			return []string{e.Parameter.Name}
+		}
+
+		if e.Err != nil {
+			return pointers(e.Err)
+		}
+	case *openapi3filter.ResponseError:
+		if e.Err != nil {
+			return pointers(e.Err)
+		}
+	case *openapi3.SchemaError:
+		var pointer strings.Builder
+		for _, token := range e.JSONPointer() {
+			pointer.WriteString("/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(token))
+		}
+
+		return []string{pointer.String()}
+	}
+
+	return []string{""}
+}
+
+// bufferedWriter holds a response back until it has been validated.
+type bufferedWriter struct {
+	header      http.Header
+	status      int
+	body        bytes.Buffer
+	wroteHeader bool
+}
+
+func (bw *bufferedWriter) Header() http.Header {
+	return bw.header
+}
+
+func (bw *bufferedWriter) WriteHeader(status int) {
+	if !bw.wroteHeader {
+		bw.status, bw.wroteHeader = status, true
+	}
+}
+
+func (bw *bufferedWriter) Write(b []byte) (int, error) {
+	bw.wroteHeader = true
+	return bw.body.Write(b)
+}
+
+func (bw *bufferedWriter) flush(w http.ResponseWriter) {
+	for k, v := range bw.header {
+		w.Header()[k] = v
+	}
+
+	w.WriteHeader(bw.status)
+	_, _ = w.Write(bw.body.Bytes())
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"example/problem"
+)
+
+func TestOpenAPI(t *testing.T) {
+	doc, err := os.ReadFile("../api/openapi.json")
+	if err != nil {
+		t.Fatalf("failed to read the OpenAPI document: %v", err)
+	}
+
+	validate, err := OpenAPI(doc)
+	if err != nil {
+		t.Fatalf("invalid OpenAPI document: %v", err)
+	}
+
+	employee := `{"id":1,"name":"Ram","email":"ram@example.com","department_id":1,"title":"Engineer","manager_id":null,` +
+		`"hire_date":"2021-06-01","status":"active","date_of_birth":"2000-03-01","age":22,` +
+		`"created_at":"2022-03-01T10:00:00Z","created_by":"ram","updated_at":"2022-03-01T10:00:00Z","updated_by":"ram"}`
+
+	testcases := []struct {
+		desc     string
+		method   string
+		target   string
+		body     string
+		response string
+		status   int
+		params   []string
+	}{
+		{"valid", http.MethodGet, "/emp/1", "", `{"data":` + employee + `}`, http.StatusOK, nil},
+		{"invalid query", http.MethodGet, "/emp?status=retired", "", `{"data":[]}`, http.StatusBadRequest,
+			[]string{"status"}},
+		{"invalid body", http.MethodPost, "/emp", `{"name":"Ram","email":1,"hire_date":"2021-06-01"}`, "",
+			http.StatusBadRequest, []string{"/email", "/date_of_birth"}},
+		{"invalid response", http.MethodGet, "/emp/1", "", `{"data":{"id":"1","name":"Ram"}}`,
+			http.StatusInternalServerError, []string{"/data/id", "/data/email", "/data/hire_date", "/data/date_of_birth"}},
+		{"undocumented path", http.MethodGet, "/metrics", "", `anything`, http.StatusOK, nil},
+	}
+
+	for i, tc := range testcases {
+		handler := validate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(tc.response))
+		}))
+
+		r := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		if w.Code != tc.status {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v %s", i+1, tc.status, w.Code, w.Body.String())
+			continue
+		}
+
+		if tc.params == nil {
+			if w.Body.String() != tc.response {
+				t.Errorf("[Test %v]Failed. Expected %s but got %s", i+1, tc.response, w.Body.String())
+			}
+
+			continue
+		}
+
+		var p problem.Problem
+
+		if err := json.NewDecoder(w.Body).Decode(&p); err != nil || !reflect.DeepEqual(tc.params, p.InvalidParams) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v %v", i+1, tc.params, p.InvalidParams, err)
+		}
+	}
+}
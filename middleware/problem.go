@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"example/problem"
+)
+
+type problemKey struct{}
+
+// SetError records err as the outcome of the request r, so that Problems answers it with problem details.
+// It is a no-op when r did not pass through Problems.
+func SetError(r *http.Request, err error) {
+	if e, ok := r.Context().Value(problemKey{}).(*error); ok {
+		*e = err
+	}
+}
+
+// Problems replaces the response of requests whose handler called SetError with the RFC 7807 problem details
+// of the recorded error.
+func Problems(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+
+		pw := &problemWriter{ResponseWriter: w, r: r, err: &err}
+
+		next.ServeHTTP(pw, r.WithContext(context.WithValue(r.Context(), problemKey{}, &err)))
+	})
+}
+
+type problemWriter struct {
+	http.ResponseWriter
+	r           *http.Request
+	err         *error
+	wroteHeader bool
+	replaced    bool
+}
+
+func (pw *problemWriter) WriteHeader(status int) {
+	if pw.wroteHeader {
+		return
+	}
+
+	pw.wroteHeader = true
+
+	if *pw.err == nil {
+		pw.ResponseWriter.WriteHeader(status)
+		return
+	}
+
+	pw.replaced = true
+
+	problem.Write(pw.ResponseWriter, pw.r, problem.From(*pw.err))
+}
+
+func (pw *problemWriter) Write(b []byte) (int, error) {
+	if !pw.wroteHeader {
+		pw.WriteHeader(http.StatusOK)
+	}
+
+	if pw.replaced {
+		return len(b), nil
+	}
+
+	return pw.ResponseWriter.Write(b)
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+)
+
+func TestProblems(t *testing.T) {
+	testcases := []struct {
+		desc   string
+		err    error
+		status int
+		body   string
+		ctype  string
+	}{
+		{"success", nil, http.StatusOK, `{"id":1}`, "application/json"},
+		{"not found", errors.EntityNotFound{Entity: "employee", ID: "1"}, http.StatusNotFound,
+			`{"type":"/problems/not-found","title":"Not Found","status":404,"detail":"` +
+				errors.EntityNotFound{Entity: "employee", ID: "1"}.Error() + `","instance":"/emp/1"}` + "\n",
+			"application/problem+json"},
+		{"internal", errors.DB{}, http.StatusInternalServerError,
+			`{"type":"/problems/internal-server-error","title":"Internal Server Error","status":500,` +
+				`"instance":"/emp/1"}` + "\n", "application/problem+json"},
+	}
+
+	for i, tc := range testcases {
+		handler := Problems(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			status := http.StatusOK
+
+			if tc.err != nil {
+				SetError(r, tc.err)
+				status = http.StatusBadGateway
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"id":1}`))
+		}))
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/emp/1", nil))
+
+		if w.Code != tc.status || w.Body.String() != tc.body || w.Header().Get("Content-Type") != tc.ctype {
+			t.Errorf("[Test %v]Failed. Expected %v %v %s but got %v %v %s", i+1, tc.status, tc.ctype, tc.body,
+				w.Code, w.Header().Get("Content-Type"), w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+
+	"example/problem"
+	"example/requestctx"
+)
+
+// Limit is a token bucket holding up to Burst requests that refills at Rate requests per second.
+// A zero Rate disables limiting.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Route applies Limit to requests with Method (empty for any) whose path is Path or lies below it.
+type Route struct {
+	Method string
+	Path   string
+	Limit  Limit
+}
+
+// Decision is the outcome of taking a token from a bucket.
+type Decision struct {
+	Allowed    bool
+	Remaining  int
+	Reset      time.Duration // until the bucket is full again
+	RetryAfter time.Duration // until the next token, when the request was not allowed
+}
+
+// Limiter takes tokens from the bucket stored under key.
+type Limiter interface {
+	Take(ctx context.Context, key string, l Limit) (Decision, error)
+}
+
+// RateLimitIP limits the client IP of every request to the limit of the first route it matches, or to def. It runs
+// before Oauth, so that requests count even when they fail authentication.
+func RateLimitIP(l Limiter, routes []Route, def Limit) func(http.Handler) http.Handler {
+	return rateLimit(l, routes, def, func(r *http.Request) string {
+		return "ip:" + clientIP(r)
+	})
+}
+
+// RateLimitKey limits the authenticated principal of a request to the limit of the first route it matches, or to
+// def, so that clients sharing an IP are limited apart. It runs after Oauth and lets requests without a principal
+// through.
+func RateLimitKey(l Limiter, routes []Route, def Limit) func(http.Handler) http.Handler {
+	return rateLimit(l, routes, def, func(r *http.Request) string {
+		if p := requestctx.Principal(r.Context()); p != "" {
+			return "key:" + p
+		}
+
+		return ""
+	})
+}
+
+// rateLimit limits the requests to the bucket named by key, skipping those it names none for. Every response
+// carries RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset of the tightest bucket taken from, and requests
+// over the limit get 429 with Retry-After. Requests are let through when the limiter fails, so an unavailable
+// counter store does not take the API down with it.
+func rateLimit(l Limiter, routes []Route, def Limit, key func(*http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			name, limit := match(r, routes, def)
+			key := key(r)
+
+			if limit.Rate <= 0 || key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			d, err := l.Take(r.Context(), "ratelimit:"+key+":"+name, limit)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// a bucket taken from earlier in the chain may have fewer tokens left
+			h := w.Header()
+			if remaining, err := strconv.Atoi(h.Get("RateLimit-Remaining")); err != nil || !d.Allowed ||
+				d.Remaining < remaining {
+				h.Set("RateLimit-Limit", strconv.Itoa(limit.Burst))
+				h.Set("RateLimit-Remaining", strconv.Itoa(d.Remaining))
+				h.Set("RateLimit-Reset", seconds(d.Reset))
+			}
+
+			if !d.Allowed {
+				h.Set("Retry-After", seconds(d.RetryAfter))
+				problem.Write(w, r, problem.New(http.StatusTooManyRequests,
+					"rate limit exceeded, retry after "+seconds(d.RetryAfter)+"s"))
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// match returns the route a request falls under, named by its method and path, with its limit.
+func match(r *http.Request, routes []Route, def Limit) (string, Limit) {
+	for _, rt := range routes {
+		if rt.Method != "" && rt.Method != r.Method {
+			continue
+		}
+
+		if r.URL.Path == rt.Path || strings.HasPrefix(r.URL.Path, strings.TrimSuffix(rt.Path, "/")+"/") {
+			return rt.Method + " " + rt.Path, rt.Limit
+		}
+	}
+
+	return "default", def
+}
+
+// clientIP is the address of the peer, since proxy headers can be forged by clients.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// seconds rounds d up to whole seconds, as the RateLimit and Retry-After headers expect.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// ParseLimit parses a limit written as rate:burst, such as 10:20.
+func ParseLimit(s string) (Limit, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) != 2 {
+		return Limit{}, errors.Error("invalid rate limit " + s + ", expected rate:burst")
+	}
+
+	rate, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil || rate < 0 {
+		return Limit{}, errors.Error("invalid rate in rate limit " + s)
+	}
+
+	burst, err := strconv.Atoi(parts[1])
+	if err != nil || burst < 1 {
+		return Limit{}, errors.Error("invalid burst in rate limit " + s)
+	}
+
+	return Limit{Rate: rate, Burst: burst}, nil
+}
+
+// ParseRoutes parses comma separated route limits written as [METHOD ]PATH=rate:burst, such as
+// "POST /emp=1:5,/departments=5:10".
+func ParseRoutes(s string) ([]Route, error) {
+	var routes []Route
+
+	for _, spec := range strings.Split(s, ",") {
+		if strings.TrimSpace(spec) == "" {
+			continue
+		}
+
+		i := strings.LastIndex(spec, "=")
+		if i < 0 {
+			return nil, errors.Error("invalid route rate limit " + spec + ", expected [METHOD ]PATH=rate:burst")
+		}
+
+		limit, err := ParseLimit(spec[i+1:])
+		if err != nil {
+			return nil, err
+		}
+
+		rt := Route{Limit: limit}
+
+		fields := strings.Fields(spec[:i])
+		switch len(fields) {
+		case 1:
+			rt.Path = fields[0]
+		case 2:
+			rt.Method, rt.Path = strings.ToUpper(fields[0]), fields[1]
+		default:
+			return nil, errors.Error("invalid route rate limit " + spec + ", expected [METHOD ]PATH=rate:burst")
+		}
+
+		routes = append(routes, rt)
+	}
+
+	return routes, nil
+}
@@ -0,0 +1,165 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+)
+
+type failingLimiter struct{}
+
+func (failingLimiter) Take(context.Context, string, Limit) (Decision, error) {
+	return Decision{}, errors.Error("connection refused")
+}
+
+// exhaustedLimiter refuses every request.
+type exhaustedLimiter struct{}
+
+func (exhaustedLimiter) Take(context.Context, string, Limit) (Decision, error) {
+	return Decision{RetryAfter: time.Second}, nil
+}
+
+func TestRateLimit(t *testing.T) {
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	limiter := NewMemoryLimiter()
+	limiter.now = func() time.Time { return now }
+
+	unlimited := Route{Path: "/health", Limit: Limit{}}
+	ip := RateLimitIP(limiter, []Route{unlimited}, Limit{Rate: 100, Burst: 100})
+	key := RateLimitKey(limiter, []Route{{Method: http.MethodPost, Path: "/emp", Limit: Limit{Rate: 1, Burst: 2}},
+		unlimited}, Limit{Rate: 10, Burst: 20})
+	handler := ip(Oauth(key(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))))
+
+	testcases := []struct {
+		desc    string
+		method  string
+		path    string
+		ip      string
+		advance time.Duration
+		status  int
+		headers map[string]string
+	}{
+		{"first", http.MethodPost, "/emp", "10.0.0.1:1000", 0, http.StatusOK,
+			map[string]string{"RateLimit-Limit": "2", "RateLimit-Remaining": "1", "RateLimit-Reset": "1"}},
+		{"second", http.MethodPost, "/emp", "10.0.0.1:1000", 0, http.StatusOK,
+			map[string]string{"RateLimit-Remaining": "0", "RateLimit-Reset": "2"}},
+		{"over limit", http.MethodPost, "/emp", "10.0.0.1:1000", 0, http.StatusTooManyRequests,
+			map[string]string{"RateLimit-Remaining": "0", "Retry-After": "1"}},
+		{"key limited from another ip", http.MethodPost, "/emp", "10.0.0.2:1000", 0, http.StatusTooManyRequests,
+			map[string]string{"Retry-After": "1"}},
+		{"other route", http.MethodGet, "/emp/1", "10.0.0.1:1000", 0, http.StatusOK,
+			map[string]string{"RateLimit-Limit": "20", "RateLimit-Remaining": "19"}},
+		{"unlimited route", http.MethodGet, "/health", "10.0.0.1:1000", 0, http.StatusOK,
+			map[string]string{"RateLimit-Limit": ""}},
+		{"refilled", http.MethodPost, "/emp", "10.0.0.1:1000", time.Second, http.StatusOK,
+			map[string]string{"RateLimit-Remaining": "0"}},
+	}
+
+	for i, tc := range testcases {
+		now = now.Add(tc.advance)
+		r := httptest.NewRequest(tc.method, tc.path, nil)
+		r.Header.Set("api-key", "ram")
+		r.RemoteAddr = tc.ip
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		if w.Code != tc.status {
+			t.Errorf("[Test %v]Failed. Expected status %v but got %v", i+1, tc.status, w.Code)
+		}
+
+		for k, v := range tc.headers {
+			if got := w.Header().Get(k); got != v {
+				t.Errorf("[Test %v]Failed. Expected %v %q but got %q", i+1, k, v, got)
+			}
+		}
+	}
+}
+
+func TestRateLimit_Unauthenticated(t *testing.T) {
+	limiter := NewMemoryLimiter()
+	limiter.now = func() time.Time { return time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC) }
+
+	ip := RateLimitIP(limiter, nil, Limit{Rate: 1, Burst: 2})
+	key := RateLimitKey(limiter, nil, Limit{Rate: 1, Burst: 1})
+	handler := ip(Oauth(key(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))))
+
+	// requests failing authentication count toward the limit of their client IP
+	for i, status := range []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests} {
+		r := httptest.NewRequest(http.MethodGet, "/emp", nil)
+		r.Header.Set("api-key", "forged")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		if w.Code != status {
+			t.Errorf("[Test %v]Failed. Expected status %v but got %v", i+1, status, w.Code)
+		}
+	}
+}
+
+func TestRateLimit_LimiterFailure(t *testing.T) {
+	called := false
+	handler := RateLimitIP(failingLimiter{}, nil, Limit{Rate: 1, Burst: 1})(http.HandlerFunc(func(http.ResponseWriter,
+		*http.Request) {
+		called = true
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/emp", nil))
+
+	if !called || w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "" {
+		t.Errorf("Failed. Expected the request to pass without limits but got %v %v", w.Code, w.Header())
+	}
+}
+
+func TestRateLimitKey_Unauthenticated(t *testing.T) {
+	called := false
+	handler := RateLimitKey(exhaustedLimiter{}, nil, Limit{Rate: 1, Burst: 1})(http.HandlerFunc(func(http.ResponseWriter,
+		*http.Request) {
+		called = true
+	}))
+
+	// requests without a principal were already counted by the ip layer
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+
+	if !called || w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "" {
+		t.Errorf("Failed. Expected the request to pass without limits but got %v %v", w.Code, w.Header())
+	}
+}
+
+func TestParseRoutes(t *testing.T) {
+	testcases := []struct {
+		desc   string
+		spec   string
+		output []Route
+		err    error
+	}{
+		{"empty", "", nil, nil},
+		{"routes", "post /emp=1:5, /departments=0.5:10", []Route{
+			{Method: http.MethodPost, Path: "/emp", Limit: Limit{Rate: 1, Burst: 5}},
+			{Path: "/departments", Limit: Limit{Rate: 0.5, Burst: 10}}}, nil},
+		{"missing limit", "/emp", nil, errors.Error("invalid route rate limit /emp, expected [METHOD ]PATH=rate:burst")},
+		{"invalid limit", "/emp=fast:5", nil, errors.Error("invalid rate in rate limit fast:5")},
+		{"invalid burst", "/emp=1:0", nil, errors.Error("invalid burst in rate limit 1:0")},
+		{"missing burst", "/emp=1", nil, errors.Error("invalid rate limit 1, expected rate:burst")},
+	}
+
+	for i, tc := range testcases {
+		resp, err := ParseRoutes(tc.spec)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
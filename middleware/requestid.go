@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"developer.zopsmart.com/go/gofr/pkg/log"
+
+	"example/requestctx"
+)
+
+// RequestIDHeader carries the id correlating a request with the log lines it produced.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID gives every request an id, the one sent by the client in X-Request-ID when it is a sensible token and a
+// random one otherwise. The id is stored in the request context and headers and echoed in the response.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := requestctx.EnsureRequestID(r.Header.Get(RequestIDHeader))
+
+		r.Header.Set(RequestIDHeader, id)
+		w.Header().Set(RequestIDHeader, id)
+
+		next.ServeHTTP(w, r.WithContext(requestctx.WithRequestID(r.Context(), id)))
+	})
+}
+
+// AccessLog logs one line per request with its id, method, path, status and duration.
+func AccessLog(l log.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(sw, r)
+
+			NewRequestLogger(l, requestctx.RequestID(r.Context())).Infof("method=%s path=%s status=%d duration=%s",
+				r.Method, r.URL.Path, sw.status, time.Since(start))
+		})
+	}
+}
+
+type statusWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (sw *statusWriter) WriteHeader(status int) {
+	if !sw.wroteHeader {
+		sw.status, sw.wroteHeader = status, true
+	}
+
+	sw.ResponseWriter.WriteHeader(status)
+}
+
+func (sw *statusWriter) Write(b []byte) (int, error) {
+	sw.wroteHeader = true
+	return sw.ResponseWriter.Write(b)
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"developer.zopsmart.com/go/gofr/pkg/log"
+
+	"example/requestctx"
+)
+
+// recorder keeps the lines logged through Infof and Errorf.
+type recorder struct {
+	log.Logger
+	lines []string
+}
+
+func (r *recorder) Infof(format string, a ...interface{}) {
+	r.lines = append(r.lines, fmt.Sprintf(format, a...))
+}
+
+func (r *recorder) Errorf(format string, a ...interface{}) {
+	r.lines = append(r.lines, fmt.Sprintf(format, a...))
+}
+
+func TestRequestID(t *testing.T) {
+	var seen, header string
+
+	handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, header = requestctx.RequestID(r.Context()), r.Header.Get(RequestIDHeader)
+	}))
+
+	testcases := []struct {
+		desc     string
+		id       string
+		accepted bool
+	}{
+		{"client id", "3f2a-1c:req_9.1", true},
+		{"missing", "", false},
+		{"unsafe characters", "abc\nrequest_id=forged", false},
+		{"too long", strings.Repeat("a", 129), false},
+	}
+
+	for i, tc := range testcases {
+		r := httptest.NewRequest(http.MethodGet, "/emp", nil)
+		r.Header.Set(RequestIDHeader, tc.id)
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		echoed := w.Header().Get(RequestIDHeader)
+
+		if seen == "" || seen != echoed || seen != header || (seen == tc.id) != tc.accepted {
+			t.Errorf("[Test %v]Failed. Expected id %q accepted %v but got %q echoed as %q", i+1, tc.id, tc.accepted,
+				seen, echoed)
+		}
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	l := &recorder{}
+
+	handler := RequestID(AccessLog(l)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewRequestLogger(l, requestctx.RequestID(r.Context())).Errorf("employee %d not found", 4)
+		w.WriteHeader(http.StatusNotFound)
+	})))
+
+	r := httptest.NewRequest(http.MethodGet, "/emp/4", nil)
+	r.Header.Set(RequestIDHeader, "abc")
+
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	if len(l.lines) != 2 || l.lines[0] != "request_id=abc employee 4 not found" ||
+		!strings.HasPrefix(l.lines[1], "request_id=abc method=GET path=/emp/4 status=404 duration=") {
+		t.Errorf("[Test 1]Failed. Expected lines tagged with request_id=abc but got %q", l.lines)
+	}
+}
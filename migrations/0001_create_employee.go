@@ -0,0 +1,20 @@
+package migrations
+
+// createEmployee creates the employee table, and brings tables created before timestamps and actors were tracked up to date.
+func createEmployee() Migration {
+	return Migration{
+		Version: 1,
+		Name:    "create_employee",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS employee(
+				id int NOT NULL PRIMARY KEY,
+				age int,
+				name varchar(20) NOT NULL
+			)`,
+			"ALTER TABLE employee ADD COLUMN IF NOT EXISTS created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP",
+			"ALTER TABLE employee ADD COLUMN IF NOT EXISTS created_by varchar(64) NOT NULL DEFAULT ''",
+			"ALTER TABLE employee ADD COLUMN IF NOT EXISTS updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP",
+			"ALTER TABLE employee ADD COLUMN IF NOT EXISTS updated_by varchar(64) NOT NULL DEFAULT ''",
+		},
+	}
+}
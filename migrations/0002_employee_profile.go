@@ -0,0 +1,23 @@
+package migrations
+
+// employeeProfile adds the HR profile columns and replaces the stored age with a date of birth it is derived from.
+func employeeProfile() Migration {
+	return Migration{
+		Version: 2,
+		Name:    "employee_profile",
+		Up: []string{
+			"ALTER TABLE employee ALTER COLUMN name TYPE varchar(64)",
+			"ALTER TABLE employee ADD COLUMN email varchar(255)",
+			"ALTER TABLE employee ADD COLUMN department varchar(64) NOT NULL DEFAULT ''",
+			"ALTER TABLE employee ADD COLUMN title varchar(64) NOT NULL DEFAULT ''",
+			"ALTER TABLE employee ADD COLUMN manager_id int REFERENCES employee(id)",
+			"ALTER TABLE employee ADD COLUMN hire_date date",
+			"ALTER TABLE employee ADD COLUMN status varchar(16) NOT NULL DEFAULT 'active'",
+			"ALTER TABLE employee ADD COLUMN date_of_birth date",
+			"UPDATE employee SET date_of_birth = CURRENT_DATE - age * INTERVAL '1 year' WHERE age IS NOT NULL",
+			"ALTER TABLE employee DROP COLUMN age",
+			"CREATE UNIQUE INDEX employee_email_key ON employee(email)",
+			"CREATE INDEX employee_manager_id_idx ON employee(manager_id)",
+		},
+	}
+}
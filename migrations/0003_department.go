@@ -0,0 +1,24 @@
+package migrations
+
+// department moves departments out of the employee table into their own table referenced by department_id.
+func department() Migration {
+	return Migration{
+		Version: 3,
+		Name:    "department",
+		Up: []string{
+			`CREATE TABLE department(
+				id int NOT NULL PRIMARY KEY,
+				name varchar(64) NOT NULL UNIQUE,
+				created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+			)`,
+			`INSERT INTO department(id,name)
+				SELECT ROW_NUMBER() OVER (ORDER BY department), department
+				FROM (SELECT DISTINCT department FROM employee WHERE department <> '') d`,
+			"ALTER TABLE employee ADD COLUMN department_id int REFERENCES department(id) ON DELETE RESTRICT",
+			"UPDATE employee SET department_id = department.id FROM department WHERE employee.department = department.name",
+			"ALTER TABLE employee DROP COLUMN department",
+			"CREATE INDEX employee_department_id_idx ON employee(department_id)",
+		},
+	}
+}
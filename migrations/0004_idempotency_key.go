@@ -0,0 +1,20 @@
+package migrations
+
+// idempotencyKey stores the Idempotency-Key of create requests with the response they produced, so retries can be
+// answered without repeating the request. A null response marks a request still in progress.
+func idempotencyKey() Migration {
+	return Migration{
+		Version: 4,
+		Name:    "idempotency_key",
+		Up: []string{
+			`CREATE TABLE idempotency_key(
+				principal varchar(64) NOT NULL,
+				id varchar(255) NOT NULL,
+				request_hash char(64) NOT NULL,
+				response text,
+				created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (principal, id)
+			)`,
+		},
+	}
+}
@@ -0,0 +1,27 @@
+package migrations
+
+// outbox stores the events of employee changes in the transaction making the change, until the outbox relay has
+// delivered them. Pending events are delivered in id order per employee, the order their changes were committed in.
+func outbox() Migration {
+	return Migration{
+		Version: 5,
+		Name:    "outbox",
+		Up: []string{
+			`CREATE TABLE outbox(
+				id bigserial PRIMARY KEY,
+				event_id varchar(64) NOT NULL UNIQUE,
+				employee_id int NOT NULL,
+				type varchar(64) NOT NULL,
+				payload text NOT NULL,
+				status varchar(16) NOT NULL DEFAULT 'pending',
+				attempts int NOT NULL DEFAULT 0,
+				next_attempt_at timestamp NOT NULL,
+				locked_until timestamp,
+				last_error text,
+				created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				delivered_at timestamp
+			)`,
+			`CREATE INDEX outbox_pending ON outbox(employee_id, id) WHERE status = 'pending'`,
+		},
+	}
+}
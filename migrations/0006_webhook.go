@@ -0,0 +1,38 @@
+package migrations
+
+// webhook stores the webhook subscriptions and logs the delivery of every event to each webhook subscribed to it.
+// A delivery is recorded once per webhook and event, however often the event is relayed.
+func webhook() Migration {
+	return Migration{
+		Version: 6,
+		Name:    "webhook",
+		Up: []string{
+			`CREATE TABLE webhook(
+				id serial PRIMARY KEY,
+				url varchar(2048) NOT NULL,
+				secret varchar(255) NOT NULL,
+				events text[] NOT NULL DEFAULT '{}',
+				created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				created_by varchar(255) NOT NULL DEFAULT '',
+				updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+			)`,
+			`CREATE TABLE webhook_delivery(
+				id bigserial PRIMARY KEY,
+				webhook_id int NOT NULL REFERENCES webhook(id) ON DELETE CASCADE,
+				event_id varchar(64) NOT NULL,
+				event_type varchar(64) NOT NULL,
+				payload text NOT NULL,
+				status varchar(16) NOT NULL DEFAULT 'pending',
+				attempts int NOT NULL DEFAULT 0,
+				response_status int,
+				last_error text,
+				next_attempt_at timestamp NOT NULL,
+				locked_until timestamp,
+				created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				delivered_at timestamp,
+				UNIQUE(webhook_id, event_id)
+			)`,
+			`CREATE INDEX webhook_delivery_pending ON webhook_delivery(next_attempt_at) WHERE status = 'pending'`,
+		},
+	}
+}
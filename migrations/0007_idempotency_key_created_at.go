@@ -0,0 +1,12 @@
+package migrations
+
+// idempotencyKeyCreatedAt indexes the idempotency keys by their creation, for the keys past their ttl to be purged.
+func idempotencyKeyCreatedAt() Migration {
+	return Migration{
+		Version: 7,
+		Name:    "idempotency_key_created_at",
+		Up: []string{
+			"CREATE INDEX idempotency_key_created_at_idx ON idempotency_key(created_at)",
+		},
+	}
+}
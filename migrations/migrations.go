@@ -0,0 +1,121 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+)
+
+// Migration is a schema change identified by an increasing version. Its statements run in a single transaction.
+type Migration struct {
+	Version int
+	Name    string
+	Up      []string
+}
+
+// All returns every migration known to the application in the order they must be applied.
+func All() []Migration {
+	return []Migration{
+		createEmployee(),
+		employeeProfile(),
+		department(),
+		idempotencyKey(),
+		outbox(),
+		webhook(),
+		idempotencyKeyCreatedAt(),
+	}
+}
+
+const createTable = `CREATE TABLE IF NOT EXISTS schema_migrations(
+	version int NOT NULL PRIMARY KEY,
+	name varchar(128) NOT NULL,
+	applied_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`
+
+// Pending returns the migrations from all that have not been applied to db yet.
+func Pending(db *sql.DB, all []Migration) ([]Migration, error) {
+	if _, err := db.Exec(createTable); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return Missing(context.Background(), db, all)
+}
+
+// Missing is Pending for callers that must not change the schema: it fails when db has no schema_migrations table
+// instead of creating it, or when ctx is done before db answers.
+func Missing(ctx context.Context, db *sql.DB, all []Migration) ([]Migration, error) {
+	rows, err := db.QueryContext(ctx, "select version from schema_migrations")
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	applied := make(map[int]bool)
+
+	for rows.Next() {
+		var v int
+
+		if err = rows.Scan(&v); err != nil {
+			return nil, errors.Error("Scan Error")
+		}
+
+		applied[v] = true
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	var pending []Migration
+
+	for _, m := range all {
+		if !applied[m.Version] {
+			pending = append(pending, m)
+		}
+	}
+
+	return pending, nil
+}
+
+// Run applies the pending migrations from all in order and returns the ones it applied.
+func Run(db *sql.DB, all []Migration) ([]Migration, error) {
+	pending, err := Pending(db, all)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, m := range pending {
+		if err = apply(db, m); err != nil {
+			return pending[:i], err
+		}
+	}
+
+	return pending, nil
+}
+
+func apply(db *sql.DB, m Migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	for _, stmt := range m.Up {
+		if _, err = tx.Exec(stmt); err != nil {
+			_ = tx.Rollback()
+			return errors.DB{Err: err}
+		}
+	}
+
+	if _, err = tx.Exec("insert into schema_migrations(version,name) VALUES ($1,$2)", m.Version, m.Name); err != nil {
+		_ = tx.Rollback()
+		return errors.DB{Err: err}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package migrations
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+)
+
+func TestAll(t *testing.T) {
+	for i, m := range All() {
+		if m.Version != i+1 || m.Name == "" || len(m.Up) == 0 {
+			t.Errorf("[Test %v]Failed. Migrations must have increasing versions, a name and statements, got %v", i+1, m)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("database error %v", err)
+	}
+
+	defer db.Close()
+
+	all := []Migration{
+		{Version: 1, Name: "first", Up: []string{"create table a(id int)"}},
+		{Version: 2, Name: "second", Up: []string{"alter table a add column b int", "create index a_b on a(b)"}},
+		{Version: 3, Name: "third", Up: []string{"drop table c"}},
+	}
+
+	insert := "insert into schema_migrations(version,name) VALUES ($1,$2)"
+
+	mock.ExpectExec(createTable).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("select version from schema_migrations").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+	mock.ExpectBegin()
+	mock.ExpectExec("alter table a add column b int").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("create index a_b on a(b)").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(insert).WithArgs(2, "second").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("drop table c").WillReturnError(errors.Error("table c does not exist"))
+	mock.ExpectRollback()
+
+	applied, err := Run(db, all)
+
+	if !reflect.DeepEqual(all[1:2], applied) {
+		t.Errorf("Failed. Expected %v but got %v", all[1:2], applied)
+	}
+
+	if !reflect.DeepEqual(errors.DB{Err: errors.Error("table c does not exist")}, err) {
+		t.Errorf("Failed. Expected DB error but got %v", err)
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Failed. %v", err)
+	}
+}
+
+func TestMissing(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("database error %v", err)
+	}
+
+	defer db.Close()
+
+	all := []Migration{{Version: 1, Name: "first"}, {Version: 2, Name: "second"}}
+	query := "select version from schema_migrations"
+
+	testcases := []struct {
+		desc    string
+		missing []Migration
+		err     error
+		mock    *sqlmock.ExpectedQuery
+	}{
+		{"up to date", nil, nil,
+			mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1).AddRow(2))},
+		{"behind", all[1:], nil, mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))},
+		{"never migrated", nil, errors.DB{Err: errors.Error(`relation "schema_migrations" does not exist`)},
+			mock.ExpectQuery(query).WillReturnError(errors.Error(`relation "schema_migrations" does not exist`))},
+	}
+
+	for i, tc := range testcases {
+		missing, err := Missing(context.Background(), db, all)
+
+		if !reflect.DeepEqual(tc.missing, missing) || !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v %v but got %v %v", i+1, tc.missing, tc.err, missing, err)
+		}
+	}
+}
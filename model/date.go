@@ -0,0 +1,141 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Date is a calendar date without a time of day, encoded as YYYY-MM-DD in JSON and stored in DATE columns.
+type Date struct {
+	time.Time
+}
+
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return Date{}, err
+	}
+
+	return Date{Time: t}, nil
+}
+
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+
+	return d.Format(dateLayout)
+}
+
+// YearsAt returns the number of whole years between d and t, which is the age at t of someone born on d.
+func (d Date) YearsAt(t time.Time) int {
+	if d.IsZero() {
+		return 0
+	}
+
+	years := t.Year() - d.Year()
+	if t.Month() < d.Month() || (t.Month() == d.Month() && t.Day() < d.Day()) {
+		years--
+	}
+
+	return years
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON fails with a *json.UnmarshalTypeError when b is not a YYYY-MM-DD string, so that the failure is
+// reported as a field of the wrong type rather than as a malformed body.
+func (d *Date) UnmarshalJSON(b []byte) error {
+	var s *string
+
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	if s == nil || *s == "" {
+		*d = Date{}
+		return nil
+	}
+
+	parsed, err := ParseDate(*s)
+	if err != nil {
+		return &json.UnmarshalTypeError{Value: "string " + strconv.Quote(*s), Type: reflect.TypeOf(d).Elem()}
+	}
+
+	*d = parsed
+
+	return nil
+}
+
+// UnmarshalYAML lets fixtures spell dates as YYYY-MM-DD.
+func (d *Date) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	if s == "" {
+		*d = Date{}
+		return nil
+	}
+
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+
+	return nil
+}
+
+func (d *Date) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*d = Date{}
+	case time.Time:
+		*d = NewDate(v.Year(), v.Month(), v.Day())
+	case []byte:
+		return d.Scan(string(v))
+	case string:
+		if len(v) > len(dateLayout) {
+			v = v[:len(dateLayout)]
+		}
+
+		parsed, err := ParseDate(v)
+		if err != nil {
+			return err
+		}
+
+		*d = parsed
+	default:
+		return fmt.Errorf("cannot scan %T into Date", value)
+	}
+
+	return nil
+}
+
+func (d Date) Value() (driver.Value, error) {
+	if d.IsZero() {
+		return nil, nil
+	}
+
+	return d.String(), nil
+}
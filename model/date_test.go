@@ -0,0 +1,85 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDate_YearsAt(t *testing.T) {
+	dob := NewDate(2000, time.March, 15)
+
+	testcases := []struct {
+		desc   string
+		at     time.Time
+		output int
+	}{
+		{"day before birthday", time.Date(2022, time.March, 14, 23, 0, 0, 0, time.UTC), 21},
+		{"on birthday", time.Date(2022, time.March, 15, 0, 0, 0, 0, time.UTC), 22},
+		{"later month", time.Date(2022, time.April, 1, 0, 0, 0, 0, time.UTC), 22},
+		{"earlier month", time.Date(2022, time.February, 28, 0, 0, 0, 0, time.UTC), 21},
+	}
+
+	for i, tc := range testcases {
+		if resp := dob.YearsAt(tc.at); resp != tc.output {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, resp)
+		}
+	}
+
+	if resp := (Date{}).YearsAt(time.Now()); resp != 0 {
+		t.Errorf("Failed. Expected 0 for an unknown date but got %v", resp)
+	}
+}
+
+func TestDate_JSON(t *testing.T) {
+	var e struct {
+		Hired Date `json:"hired"`
+		Born  Date `json:"born"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"hired":"2021-06-01","born":null}`), &e); err != nil {
+		t.Fatalf("Failed. Expected no error but got %v", err)
+	}
+
+	if !reflect.DeepEqual(NewDate(2021, time.June, 1), e.Hired) || !e.Born.IsZero() {
+		t.Errorf("Failed. Got %v %v", e.Hired, e.Born)
+	}
+
+	b, _ := json.Marshal(e)
+	if string(b) != `{"hired":"2021-06-01","born":null}` {
+		t.Errorf("Failed. Got %s", b)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if err := json.Unmarshal([]byte(`{"hired":"01/06/2021"}`), &e); !errors.As(err, &typeErr) ||
+		typeErr.Type != reflect.TypeOf(Date{}) {
+		t.Errorf("Failed. Expected a type error for an invalid date but got %v", err)
+	}
+}
+
+func TestDate_Scan(t *testing.T) {
+	testcases := []struct {
+		desc   string
+		value  interface{}
+		output Date
+		err    bool
+	}{
+		{"time", time.Date(2021, time.June, 1, 0, 0, 0, 0, time.FixedZone("IST", 19800)), NewDate(2021, time.June, 1), false},
+		{"string", "2021-06-01", NewDate(2021, time.June, 1), false},
+		{"bytes with time", []byte("2021-06-01T00:00:00Z"), NewDate(2021, time.June, 1), false},
+		{"null", nil, Date{}, false},
+		{"unsupported", 20210601, Date{}, true},
+	}
+
+	for i, tc := range testcases {
+		var d Date
+
+		err := d.Scan(tc.value)
+
+		if !reflect.DeepEqual(tc.output, d) || (err != nil) != tc.err {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v %v", i+1, tc.output, d, err)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package model
+
+import "time"
+
+// Ref refers to another resource by its id.
+type Ref struct {
+	ID int `json:"id"`
+}
+
+// Audit records who created and last updated a resource, and when.
+type Audit struct {
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UpdatedBy string    `json:"updated_by"`
+}
+
+// EmployeeV2 is the representation of an employee in version 2 of the API. The department and manager are
+// references rather than bare ids, and the audit fields, which clients cannot set, are grouped under Audit.
+type EmployeeV2 struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	Title       string `json:"title"`
+	Status      Status `json:"status"`
+	HireDate    Date   `json:"hire_date"`
+	DateOfBirth Date   `json:"date_of_birth"`
+	Age         int    `json:"age"`
+	Department  *Ref   `json:"department"`
+	Manager     *Ref   `json:"manager"`
+	Audit       Audit  `json:"audit"`
+}
+
+// NewEmployeeV2 returns the version 2 representation of e.
+func NewEmployeeV2(e Employee) EmployeeV2 {
+	return EmployeeV2{
+		ID:          e.ID,
+		Name:        e.Name,
+		Email:       e.Email,
+		Title:       e.Title,
+		Status:      e.Status,
+		HireDate:    e.HireDate,
+		DateOfBirth: e.DateOfBirth,
+		Age:         e.Age,
+		Department:  ref(e.DepartmentID),
+		Manager:     ref(e.ManagerID),
+		Audit:       Audit{CreatedAt: e.CreatedAt, CreatedBy: e.CreatedBy, UpdatedAt: e.UpdatedAt, UpdatedBy: e.UpdatedBy},
+	}
+}
+
+// Employee returns the employee e represents. The derived age and the audit fields are left out, since they are
+// never taken from clients.
+func (e EmployeeV2) Employee() Employee {
+	return Employee{
+		ID:           e.ID,
+		Name:         e.Name,
+		Email:        e.Email,
+		DepartmentID: id(e.Department),
+		Title:        e.Title,
+		ManagerID:    id(e.Manager),
+		HireDate:     e.HireDate,
+		Status:       e.Status,
+		DateOfBirth:  e.DateOfBirth,
+	}
+}
+
+func ref(id *int) *Ref {
+	if id == nil {
+		return nil
+	}
+
+	return &Ref{ID: *id}
+}
+
+func id(r *Ref) *int {
+	if r == nil {
+		return nil
+	}
+
+	return &r.ID
+}
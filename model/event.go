@@ -0,0 +1,59 @@
+package model
+
+import "time"
+
+// EventType names the change an Event records.
+type EventType string
+
+const (
+	EmployeeCreated EventType = "EmployeeCreated"
+	EmployeeUpdated EventType = "EmployeeUpdated"
+	// EmployeeDeleted is reserved for the deletion of employees, which the service does not offer yet.
+	EmployeeDeleted EventType = "EmployeeDeleted"
+)
+
+func (t EventType) Valid() bool {
+	switch t {
+	case EmployeeCreated, EmployeeUpdated, EmployeeDeleted:
+		return true
+	default:
+		return false
+	}
+}
+
+// EventSchemaVersion is the version of the Event schema published. Fields may be added to an event without changing
+// its version, while removing or changing the meaning of a field requires a new version.
+const EventSchemaVersion = 1
+
+// Event records a change to an employee for the services reacting to it. Employee is the employee as the change left
+// it, in the representation of version 1 of the HTTP API.
+type Event struct {
+	ID            string    `json:"id"`
+	Type          EventType `json:"type"`
+	SchemaVersion int       `json:"schema_version"`
+	OccurredAt    time.Time `json:"occurred_at"`
+	Principal     string    `json:"principal"`
+	RequestID     string    `json:"request_id,omitempty"`
+	Employee      Employee  `json:"employee"`
+}
+
+// OutboxStatus is the delivery status of an event in the outbox.
+type OutboxStatus string
+
+const (
+	OutboxPending   OutboxStatus = "pending"
+	OutboxDelivered OutboxStatus = "delivered"
+	// OutboxDead marks an event given up on after too many failed deliveries.
+	OutboxDead OutboxStatus = "dead"
+)
+
+// OutboxEvent is an event stored with the change it records, waiting to be delivered by the outbox relay. Attempts
+// counts the failed deliveries so far.
+type OutboxEvent struct {
+	ID            int64
+	Event         Event
+	Status        OutboxStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+}
@@ -1,7 +1,91 @@
 package model
 
+import "time"
+
+type Status string
+
+const (
+	StatusActive     Status = "active"
+	StatusOnLeave    Status = "on_leave"
+	StatusTerminated Status = "terminated"
+)
+
+func (s Status) Valid() bool {
+	switch s {
+	case StatusActive, StatusOnLeave, StatusTerminated:
+		return true
+	default:
+		return false
+	}
+}
+
+// Employee is the HR record of an employee. Age is derived from DateOfBirth when the record is read and is never stored.
 type Employee struct {
-	ID   int    `json:"id"`
-	Age  int    `json:"age"`
-	Name string `json:"name"`
+	ID           int       `json:"id" yaml:"id"`
+	Name         string    `json:"name" yaml:"name"`
+	Email        string    `json:"email" yaml:"email"`
+	DepartmentID *int      `json:"department_id" yaml:"department_id"`
+	Title        string    `json:"title" yaml:"title"`
+	ManagerID    *int      `json:"manager_id" yaml:"manager_id"`
+	HireDate     Date      `json:"hire_date" yaml:"hire_date"`
+	Status       Status    `json:"status" yaml:"status"`
+	DateOfBirth  Date      `json:"date_of_birth" yaml:"date_of_birth"`
+	Age          int       `json:"age" yaml:"-"`
+	CreatedAt    time.Time `json:"created_at" yaml:"-"`
+	CreatedBy    string    `json:"created_by" yaml:"-"`
+	UpdatedAt    time.Time `json:"updated_at" yaml:"-"`
+	UpdatedBy    string    `json:"updated_by" yaml:"-"`
+}
+
+// Filter narrows down the employees returned by a listing. Zero valued fields do not filter, while an empty but non
+// nil IDs matches no employee. AfterID and Limit page through the listing by id: a page holds at most Limit
+// employees whose id is greater than AfterID.
+type Filter struct {
+	IDs          []int
+	DepartmentID *int
+	Title        string
+	Status       Status
+	ManagerID    *int
+	AfterID      *int
+	Limit        int
+}
+
+// MaxPageSize is the largest Limit a listing accepts.
+const MaxPageSize = 1000
+
+// IdempotencyKey records a request made with an Idempotency-Key header. Keys are scoped to the principal that sent
+// them and Response stays nil until the request completes.
+type IdempotencyKey struct {
+	Principal   string
+	ID          string
+	RequestHash string
+	Response    []byte
+	CreatedAt   time.Time
+}
+
+type Department struct {
+	ID        int       `json:"id" yaml:"id"`
+	Name      string    `json:"name" yaml:"name"`
+	CreatedAt time.Time `json:"created_at" yaml:"-"`
+	UpdatedAt time.Time `json:"updated_at" yaml:"-"`
+}
+
+// Health statuses of the service and of the dependencies it checks.
+const (
+	HealthUp       = "up"
+	HealthDown     = "down"
+	HealthDraining = "draining"
+)
+
+// Health reports whether the service can serve requests and, for readiness, the status of each dependency checked.
+type Health struct {
+	Status       string                      `json:"status"`
+	Dependencies map[string]DependencyHealth `json:"dependencies,omitempty"`
+}
+
+// DependencyHealth is the outcome of checking a dependency and how long the check took.
+type DependencyHealth struct {
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
 }
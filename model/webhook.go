@@ -0,0 +1,68 @@
+package model
+
+import "time"
+
+// Webhook subscribes URL to the employee changes whose type is in Events, or to every change when Events is empty.
+// Deliveries are signed with Secret, which is never returned once stored.
+type Webhook struct {
+	ID        int         `json:"id"`
+	URL       string      `json:"url"`
+	Secret    string      `json:"secret,omitempty"`
+	Events    []EventType `json:"events"`
+	CreatedAt time.Time   `json:"created_at"`
+	CreatedBy string      `json:"created_by"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// Subscribes reports whether the webhook is sent the events of type t.
+func (w Webhook) Subscribes(t EventType) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+
+	for _, e := range w.Events {
+		if e == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DeliveryStatus is the status of the delivery of an event to a webhook.
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	// DeliveryFailed marks a delivery given up on after too many failed attempts, until it is replayed.
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+func (s DeliveryStatus) Valid() bool {
+	switch s {
+	case DeliveryPending, DeliverySucceeded, DeliveryFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// Delivery logs the delivery of an event to a webhook. ResponseStatus and LastError describe the last attempt, and
+// Attempts counts the failed attempts so far. URL, Secret and Payload are what the delivery worker sends.
+type Delivery struct {
+	ID             int64          `json:"id"`
+	WebhookID      int            `json:"webhook_id"`
+	EventID        string         `json:"event_id"`
+	EventType      EventType      `json:"event_type"`
+	Status         DeliveryStatus `json:"status"`
+	Attempts       int            `json:"attempts"`
+	ResponseStatus *int           `json:"response_status"`
+	LastError      string         `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time      `json:"next_attempt_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+	DeliveredAt    *time.Time     `json:"delivered_at"`
+	URL            string         `json:"-"`
+	Secret         string         `json:"-"`
+	Payload        []byte         `json:"-"`
+}
@@ -0,0 +1,91 @@
+// Package problem renders errors as RFC 7807 problem details.
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+
+	"example/domain"
+)
+
+// ContentType is the media type of problem details documents.
+const ContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details document, extended with the id of the request that failed and the
+// parameters that were rejected.
+type Problem struct {
+	Type          string   `json:"type"`
+	Title         string   `json:"title"`
+	Status        int      `json:"status"`
+	Detail        string   `json:"detail,omitempty"`
+	Instance      string   `json:"instance,omitempty"`
+	RequestID     string   `json:"request_id,omitempty"`
+	InvalidParams []string `json:"invalid_params,omitempty"`
+}
+
+// New returns the problem for status, typed after its status text, such as /problems/too-many-requests for 429.
+func New(status int, detail string) Problem {
+	title := http.StatusText(status)
+
+	return Problem{
+		Type:   "/problems/" + strings.ReplaceAll(strings.ToLower(title), " ", "-"),
+		Title:  title,
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// From maps the errors shared by the handler, service and datastore layers to the problem clients get:
+//   - domain.Invalid and errors.MissingParam are 400 and list the parameters,
+//   - domain.NotFound is 404,
+//   - domain.Conflict is 409 and names the conflicting field, if any,
+//   - domain.Unavailable is 503, without the failure of the dependency,
+//   - *errors.Response keeps its status and reason,
+//   - anything else is 500, and only errors.Error, whose messages are written for clients, keeps its detail.
+func From(err error) Problem {
+	switch e := err.(type) {
+	case domain.Invalid:
+		p := New(http.StatusBadRequest, e.Error())
+		p.InvalidParams = e.Param
+
+		return p
+	case errors.MissingParam:
+		p := New(http.StatusBadRequest, e.Error())
+		p.InvalidParams = e.Param
+
+		return p
+	case domain.NotFound:
+		return New(http.StatusNotFound, e.Error())
+	case domain.Conflict:
+		p := New(http.StatusConflict, e.Error())
+		if e.Field != "" {
+			p.InvalidParams = []string{e.Field}
+		}
+
+		return p
+	case domain.Unavailable:
+		return New(http.StatusServiceUnavailable, "a dependency of the service is unavailable, retry later")
+	case *errors.Response:
+		return New(e.StatusCode, e.Reason)
+	case errors.Error:
+		return New(http.StatusInternalServerError, e.Error())
+	default:
+		return New(http.StatusInternalServerError, "")
+	}
+}
+
+// Write sends p as the response to r, naming r as the instance the problem occurred on and quoting the X-Request-ID
+// set by middleware.RequestID.
+func Write(w http.ResponseWriter, r *http.Request, p Problem) {
+	p.Instance = r.URL.RequestURI()
+	p.RequestID = r.Header.Get("X-Request-ID")
+
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Del("Content-Length")
+	w.WriteHeader(p.Status)
+
+	_ = json.NewEncoder(w).Encode(p)
+}
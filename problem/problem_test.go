@@ -0,0 +1,71 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+
+	"example/domain"
+)
+
+func TestFrom(t *testing.T) {
+	testcases := []struct {
+		desc   string
+		err    error
+		output Problem
+	}{
+		{"invalid param", errors.InvalidParam{Param: []string{"email", "age"}}, Problem{Type: "/problems/bad-request",
+			Title: "Bad Request", Status: 400, Detail: errors.InvalidParam{Param: []string{"email", "age"}}.Error(),
+			InvalidParams: []string{"email", "age"}}},
+		{"missing param", errors.MissingParam{Param: []string{"name"}}, Problem{Type: "/problems/bad-request",
+			Title: "Bad Request", Status: 400, Detail: errors.MissingParam{Param: []string{"name"}}.Error(),
+			InvalidParams: []string{"name"}}},
+		{"not found", errors.EntityNotFound{Entity: "employee", ID: "3"}, Problem{Type: "/problems/not-found",
+			Title: "Not Found", Status: 404, Detail: errors.EntityNotFound{Entity: "employee", ID: "3"}.Error()}},
+		{"conflict", domain.Conflict{Entity: "employee", Field: "email"}, Problem{Type: "/problems/conflict",
+			Title: "Conflict", Status: 409, Detail: "employee with the same email already exists",
+			InvalidParams: []string{"email"}}},
+		{"state conflict", domain.Conflict{Entity: "delivery", Reason: "delivery 2 is pending"},
+			Problem{Type: "/problems/conflict", Title: "Conflict", Status: 409, Detail: "delivery 2 is pending"}},
+		{"unavailable", domain.Unavailable{Err: errors.DB{}}, Problem{Type: "/problems/service-unavailable",
+			Title: "Service Unavailable", Status: 503, Detail: "a dependency of the service is unavailable, retry later"}},
+		{"response", &errors.Response{StatusCode: 422, Code: "Unprocessable Entity", Reason: "key reused"},
+			Problem{Type: "/problems/unprocessable-entity", Title: "Unprocessable Entity", Status: 422,
+				Detail: "key reused"}},
+		{"error", errors.Error("Connect Failed"), Problem{Type: "/problems/internal-server-error",
+			Title: "Internal Server Error", Status: 500, Detail: "Connect Failed"}},
+		{"db", errors.DB{}, Problem{Type: "/problems/internal-server-error", Title: "Internal Server Error",
+			Status: 500}},
+	}
+
+	for i, tc := range testcases {
+		if output := From(tc.err); !reflect.DeepEqual(tc.output, output) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, output)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/emp/3?fields=name", nil)
+	r.Header.Set("X-Request-ID", "abc")
+	w := httptest.NewRecorder()
+
+	Write(w, r, New(http.StatusNotFound, "employee 3 not found"))
+
+	var p Problem
+
+	if err := json.NewDecoder(w.Body).Decode(&p); err != nil {
+		t.Fatalf("[Test 1]Failed.Expected a problem but Got %v", err)
+	}
+
+	expected := Problem{Type: "/problems/not-found", Title: "Not Found", Status: 404, Detail: "employee 3 not found",
+		Instance: "/emp/3?fields=name", RequestID: "abc"}
+
+	if w.Code != http.StatusNotFound || w.Header().Get("Content-Type") != ContentType || !reflect.DeepEqual(expected, p) {
+		t.Errorf("[Test 1]Failed.Expected %v but Got %v %v %v", expected, w.Code, w.Header().Get("Content-Type"), p)
+	}
+}
@@ -0,0 +1,18 @@
+// Package requestctx carries the principal and the id of a request in its context, for every transport and layer
+// serving it to read them alike.
+package requestctx
+
+import "context"
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the authenticated principal.
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// Principal returns the principal authenticated for ctx, or an empty string when there is none.
+func Principal(ctx context.Context) string {
+	p, _ := ctx.Value(principalKey{}).(string)
+	return p
+}
@@ -0,0 +1,18 @@
+package requestctx
+
+import (
+	"context"
+	"testing"
+)
+
+func TestContext(t *testing.T) {
+	ctx := WithRequestID(WithPrincipal(context.Background(), "ram"), "req-1")
+
+	if p, id := Principal(ctx), RequestID(ctx); p != "ram" || id != "req-1" {
+		t.Errorf("[Test 1]Failed. Expected ram req-1 but got %v %v", p, id)
+	}
+
+	if p, id := Principal(context.Background()), RequestID(context.Background()); p != "" || id != "" {
+		t.Errorf("[Test 2]Failed. Expected no principal or request id but got %q %q", p, id)
+	}
+}
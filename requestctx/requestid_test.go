@@ -0,0 +1,27 @@
+package requestctx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEnsureRequestID(t *testing.T) {
+	testcases := []struct {
+		desc     string
+		id       string
+		accepted bool
+	}{
+		{"client id", "3f2a-1c:req_9.1", true},
+		{"missing", "", false},
+		{"unsafe characters", "abc\nrequest_id=forged", false},
+		{"too long", strings.Repeat("a", 129), false},
+	}
+
+	for i, tc := range testcases {
+		id := EnsureRequestID(tc.id)
+
+		if id == "" || (id == tc.id) != tc.accepted {
+			t.Errorf("[Test %v]Failed. Expected id %q accepted %v but got %q", i+1, tc.id, tc.accepted, id)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: employee.proto
+
+package employeepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// EmployeesClient is the client API for Employees service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EmployeesClient interface {
+	// ListEmployees returns the employees matching the request, ordered by id.
+	ListEmployees(ctx context.Context, in *ListEmployeesRequest, opts ...grpc.CallOption) (*EmployeeList, error)
+	GetEmployee(ctx context.Context, in *GetEmployeeRequest, opts ...grpc.CallOption) (*Employee, error)
+	CreateEmployee(ctx context.Context, in *CreateEmployeeRequest, opts ...grpc.CallOption) (*Employee, error)
+	// UpdateEmployee replaces the employee with the id of the given employee.
+	UpdateEmployee(ctx context.Context, in *UpdateEmployeeRequest, opts ...grpc.CallOption) (*Employee, error)
+	// GetReports returns the employees managed directly by the employee.
+	GetReports(ctx context.Context, in *GetEmployeeRequest, opts ...grpc.CallOption) (*EmployeeList, error)
+	// GetSubtree returns every employee managed directly or indirectly by the employee.
+	GetSubtree(ctx context.Context, in *GetEmployeeRequest, opts ...grpc.CallOption) (*EmployeeList, error)
+	// GetChain returns the managers of the employee, from its manager up to the top of the hierarchy.
+	GetChain(ctx context.Context, in *GetEmployeeRequest, opts ...grpc.CallOption) (*EmployeeList, error)
+}
+
+type employeesClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEmployeesClient(cc grpc.ClientConnInterface) EmployeesClient {
+	return &employeesClient{cc}
+}
+
+func (c *employeesClient) ListEmployees(ctx context.Context, in *ListEmployeesRequest, opts ...grpc.CallOption) (*EmployeeList, error) {
+	out := new(EmployeeList)
+	err := c.cc.Invoke(ctx, "/example.employee.v1.Employees/ListEmployees", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeesClient) GetEmployee(ctx context.Context, in *GetEmployeeRequest, opts ...grpc.CallOption) (*Employee, error) {
+	out := new(Employee)
+	err := c.cc.Invoke(ctx, "/example.employee.v1.Employees/GetEmployee", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeesClient) CreateEmployee(ctx context.Context, in *CreateEmployeeRequest, opts ...grpc.CallOption) (*Employee, error) {
+	out := new(Employee)
+	err := c.cc.Invoke(ctx, "/example.employee.v1.Employees/CreateEmployee", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeesClient) UpdateEmployee(ctx context.Context, in *UpdateEmployeeRequest, opts ...grpc.CallOption) (*Employee, error) {
+	out := new(Employee)
+	err := c.cc.Invoke(ctx, "/example.employee.v1.Employees/UpdateEmployee", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeesClient) GetReports(ctx context.Context, in *GetEmployeeRequest, opts ...grpc.CallOption) (*EmployeeList, error) {
+	out := new(EmployeeList)
+	err := c.cc.Invoke(ctx, "/example.employee.v1.Employees/GetReports", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeesClient) GetSubtree(ctx context.Context, in *GetEmployeeRequest, opts ...grpc.CallOption) (*EmployeeList, error) {
+	out := new(EmployeeList)
+	err := c.cc.Invoke(ctx, "/example.employee.v1.Employees/GetSubtree", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeesClient) GetChain(ctx context.Context, in *GetEmployeeRequest, opts ...grpc.CallOption) (*EmployeeList, error) {
+	out := new(EmployeeList)
+	err := c.cc.Invoke(ctx, "/example.employee.v1.Employees/GetChain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EmployeesServer is the server API for Employees service.
+// All implementations must embed UnimplementedEmployeesServer
+// for forward compatibility
+type EmployeesServer interface {
+	// ListEmployees returns the employees matching the request, ordered by id.
+	ListEmployees(context.Context, *ListEmployeesRequest) (*EmployeeList, error)
+	GetEmployee(context.Context, *GetEmployeeRequest) (*Employee, error)
+	CreateEmployee(context.Context, *CreateEmployeeRequest) (*Employee, error)
+	// UpdateEmployee replaces the employee with the id of the given employee.
+	UpdateEmployee(context.Context, *UpdateEmployeeRequest) (*Employee, error)
+	// GetReports returns the employees managed directly by the employee.
+	GetReports(context.Context, *GetEmployeeRequest) (*EmployeeList, error)
+	// GetSubtree returns every employee managed directly or indirectly by the employee.
+	GetSubtree(context.Context, *GetEmployeeRequest) (*EmployeeList, error)
+	// GetChain returns the managers of the employee, from its manager up to the top of the hierarchy.
+	GetChain(context.Context, *GetEmployeeRequest) (*EmployeeList, error)
+	mustEmbedUnimplementedEmployeesServer()
+}
+
+// UnimplementedEmployeesServer must be embedded to have forward compatible implementations.
+type UnimplementedEmployeesServer struct {
+}
+
+func (UnimplementedEmployeesServer) ListEmployees(context.Context, *ListEmployeesRequest) (*EmployeeList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEmployees not implemented")
+}
+func (UnimplementedEmployeesServer) GetEmployee(context.Context, *GetEmployeeRequest) (*Employee, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEmployee not implemented")
+}
+func (UnimplementedEmployeesServer) CreateEmployee(context.Context, *CreateEmployeeRequest) (*Employee, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEmployee not implemented")
+}
+func (UnimplementedEmployeesServer) UpdateEmployee(context.Context, *UpdateEmployeeRequest) (*Employee, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEmployee not implemented")
+}
+func (UnimplementedEmployeesServer) GetReports(context.Context, *GetEmployeeRequest) (*EmployeeList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReports not implemented")
+}
+func (UnimplementedEmployeesServer) GetSubtree(context.Context, *GetEmployeeRequest) (*EmployeeList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSubtree not implemented")
+}
+func (UnimplementedEmployeesServer) GetChain(context.Context, *GetEmployeeRequest) (*EmployeeList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChain not implemented")
+}
+func (UnimplementedEmployeesServer) mustEmbedUnimplementedEmployeesServer() {}
+
+// UnsafeEmployeesServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EmployeesServer will
+// result in compilation errors.
+type UnsafeEmployeesServer interface {
+	mustEmbedUnimplementedEmployeesServer()
+}
+
+func RegisterEmployeesServer(s grpc.ServiceRegistrar, srv EmployeesServer) {
+	s.RegisterService(&Employees_ServiceDesc, srv)
+}
+
+func _Employees_ListEmployees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEmployeesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeesServer).ListEmployees(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/example.employee.v1.Employees/ListEmployees",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeesServer).ListEmployees(ctx, req.(*ListEmployeesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Employees_GetEmployee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEmployeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeesServer).GetEmployee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/example.employee.v1.Employees/GetEmployee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeesServer).GetEmployee(ctx, req.(*GetEmployeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Employees_CreateEmployee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEmployeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeesServer).CreateEmployee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/example.employee.v1.Employees/CreateEmployee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeesServer).CreateEmployee(ctx, req.(*CreateEmployeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Employees_UpdateEmployee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEmployeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeesServer).UpdateEmployee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/example.employee.v1.Employees/UpdateEmployee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeesServer).UpdateEmployee(ctx, req.(*UpdateEmployeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Employees_GetReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEmployeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeesServer).GetReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/example.employee.v1.Employees/GetReports",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeesServer).GetReports(ctx, req.(*GetEmployeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Employees_GetSubtree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEmployeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeesServer).GetSubtree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/example.employee.v1.Employees/GetSubtree",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeesServer).GetSubtree(ctx, req.(*GetEmployeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Employees_GetChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEmployeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeesServer).GetChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/example.employee.v1.Employees/GetChain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeesServer).GetChain(ctx, req.(*GetEmployeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Employees_ServiceDesc is the grpc.ServiceDesc for Employees service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Employees_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "example.employee.v1.Employees",
+	HandlerType: (*EmployeesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListEmployees",
+			Handler:    _Employees_ListEmployees_Handler,
+		},
+		{
+			MethodName: "GetEmployee",
+			Handler:    _Employees_GetEmployee_Handler,
+		},
+		{
+			MethodName: "CreateEmployee",
+			Handler:    _Employees_CreateEmployee_Handler,
+		},
+		{
+			MethodName: "UpdateEmployee",
+			Handler:    _Employees_UpdateEmployee_Handler,
+		},
+		{
+			MethodName: "GetReports",
+			Handler:    _Employees_GetReports_Handler,
+		},
+		{
+			MethodName: "GetSubtree",
+			Handler:    _Employees_GetSubtree_Handler,
+		},
+		{
+			MethodName: "GetChain",
+			Handler:    _Employees_GetChain_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "employee.proto",
+}
@@ -0,0 +1,155 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"developer.zopsmart.com/go/gofr/pkg/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"example/middleware"
+	"example/problem"
+	"example/requestctx"
+)
+
+// RequestID gives every call the id sent in its x-request-id metadata when it is a sensible token, and a random one
+// otherwise, echoing it in the response header.
+func RequestID(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	id := requestctx.EnsureRequestID(first(ctx, strings.ToLower(middleware.RequestIDHeader)))
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(middleware.RequestIDHeader, id))
+
+	return handler(requestctx.WithRequestID(ctx, id), req)
+}
+
+// Auth authenticates calls with the api key of their api-key metadata, as middleware.Oauth does for HTTP requests.
+func Auth(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{},
+	error) {
+	principal, ok := middleware.Authenticate(first(ctx, "api-key"))
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing or unknown api-key")
+	}
+
+	return handler(requestctx.WithPrincipal(ctx, principal), req)
+}
+
+// Logging logs one line per call with its request id, method, code and duration, and the errors of the calls that
+// failed on the server.
+func Logging(l log.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		logger := middleware.NewRequestLogger(l, requestctx.RequestID(ctx))
+		code := Status(err).Code()
+
+		if serverError(code) {
+			logger.Errorf("%s failed: %v", info.FullMethod, err)
+		}
+
+		logger.Infof("method=%s code=%s duration=%s", info.FullMethod, code, time.Since(start))
+
+		return resp, err
+	}
+}
+
+// Errors answers the errors of the service with the status matching them, see Status.
+func Errors(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{},
+	error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, Status(err).Err()
+	}
+
+	return resp, nil
+}
+
+// nolint:gochecknoglobals // codes of the HTTP statuses problem.From maps errors to
+var codeOf = map[int]codes.Code{
+	http.StatusBadRequest:            codes.InvalidArgument,
+	http.StatusUnauthorized:          codes.Unauthenticated,
+	http.StatusForbidden:             codes.PermissionDenied,
+	http.StatusNotFound:              codes.NotFound,
+	http.StatusConflict:              codes.AlreadyExists,
+	http.StatusPreconditionFailed:    codes.FailedPrecondition,
+	http.StatusRequestEntityTooLarge: codes.ResourceExhausted,
+	http.StatusUnprocessableEntity:   codes.FailedPrecondition,
+	http.StatusTooManyRequests:       codes.ResourceExhausted,
+	http.StatusNotImplemented:        codes.Unimplemented,
+	http.StatusServiceUnavailable:    codes.Unavailable,
+	http.StatusGatewayTimeout:        codes.DeadlineExceeded,
+}
+
+// Status returns the status err is answered with. Errors of the service get the code matching the HTTP status of
+// problem.From, so that a call fails the same way over gRPC and HTTP, and the parameters they reject are listed as
+// the field violations of a BadRequest detail. Statuses and context errors keep their code.
+func Status(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+
+	if s, ok := status.FromError(err); ok {
+		return s
+	}
+
+	if s := status.FromContextError(err); s.Code() != codes.Unknown {
+		return s
+	}
+
+	p := problem.From(err)
+
+	code, ok := codeOf[p.Status]
+	if !ok {
+		code = codes.Internal
+	}
+
+	msg := p.Detail
+	if msg == "" {
+		msg = p.Title
+	}
+
+	s := status.New(code, msg)
+	if len(p.InvalidParams) == 0 {
+		return s
+	}
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, len(p.InvalidParams))
+	for i, param := range p.InvalidParams {
+		violations[i] = &errdetails.BadRequest_FieldViolation{Field: param, Description: msg}
+	}
+
+	if detailed, err := s.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		return detailed
+	}
+
+	return s
+}
+
+// serverError reports whether code means the call failed on the server rather than because of its request.
+func serverError(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss, codes.Unimplemented:
+		return true
+	default:
+		return false
+	}
+}
+
+// first returns the first value of the metadata key of the call, or an empty string when it has none.
+func first(ctx context.Context, key string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+
+	return ""
+}
@@ -0,0 +1,60 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"example/domain"
+)
+
+func TestStatus(t *testing.T) {
+	testcases := []struct {
+		desc  string
+		err   error
+		code  codes.Code
+		msg   string
+		field string
+	}{
+		{"none", nil, codes.OK, "", ""},
+		{"invalid", errors.InvalidParam{Param: []string{"email"}}, codes.InvalidArgument,
+			errors.InvalidParam{Param: []string{"email"}}.Error(), "email"},
+		{"missing", errors.MissingParam{Param: []string{"name"}}, codes.InvalidArgument,
+			errors.MissingParam{Param: []string{"name"}}.Error(), "name"},
+		{"not found", errors.EntityNotFound{Entity: "employee", ID: "1"}, codes.NotFound,
+			errors.EntityNotFound{Entity: "employee", ID: "1"}.Error(), ""},
+		{"conflict", domain.Conflict{Field: "email"}, codes.AlreadyExists, domain.Conflict{Field: "email"}.Error(),
+			"email"},
+		{"unprocessable", &errors.Response{StatusCode: 422, Reason: "cycle"}, codes.FailedPrecondition, "cycle", ""},
+		{"unavailable", domain.Unavailable{Err: errors.DB{}}, codes.Unavailable,
+			"a dependency of the service is unavailable, retry later", ""},
+		{"client error", errors.Error("Connect Failed"), codes.Internal, "Connect Failed", ""},
+		{"canceled", context.Canceled, codes.Canceled, context.Canceled.Error(), ""},
+		{"status", status.Error(codes.Unauthenticated, "no key"), codes.Unauthenticated, "no key", ""},
+		{"unknown", errors.DB{}, codes.Internal, "Internal Server Error", ""},
+	}
+
+	for i, tc := range testcases {
+		s := Status(tc.err)
+
+		if s.Code() != tc.code || s.Message() != tc.msg || field(s) != tc.field {
+			t.Errorf("[Test %v]Failed. Expected %v %q %q but got %v %q %q", i+1, tc.code, tc.msg, tc.field, s.Code(),
+				s.Message(), field(s))
+		}
+	}
+}
+
+// field returns the field of the first field violation of s, or an empty string when it has none.
+func field(s *status.Status) string {
+	for _, d := range s.Details() {
+		if b, ok := d.(*errdetails.BadRequest); ok && len(b.FieldViolations) > 0 {
+			return b.FieldViolations[0].Field
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,275 @@
+// Package rpc serves the employees over gRPC, as described by api/employee.proto, with the service behind the HTTP
+// handlers.
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"example/middleware"
+	"example/model"
+	"example/requestctx"
+	"example/rpc/employeepb"
+	"example/service"
+)
+
+// NewServer returns a gRPC server serving the Employees service with emp, authenticating, logging and mapping the
+// errors of every call. Server reflection is enabled, so that tools such as grpcurl can list and call the service.
+func NewServer(app *gofr.Gofr, emp service.EmpService) *grpc.Server {
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(RequestID, Errors, Logging(app.Logger), Auth))
+
+	employeepb.RegisterEmployeesServer(s, New(emp, app))
+	reflection.Register(s)
+
+	return s
+}
+
+type server struct {
+	employeepb.UnimplementedEmployeesServer
+
+	service service.EmpService
+	app     *gofr.Gofr
+}
+
+// New returns the Employees service, calling emp with a gofr context built from the context of every call.
+// nolint:revive // server should not be used without proper initialization with required dependency
+func New(emp service.EmpService, app *gofr.Gofr) server {
+	return server{service: emp, app: app}
+}
+
+func (s server) ListEmployees(ctx context.Context, req *employeepb.ListEmployeesRequest) (*employeepb.EmployeeList,
+	error) {
+	filter, err := toFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.service.GetEmp(s.context(ctx), filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return toList(resp), nil
+}
+
+func (s server) GetEmployee(ctx context.Context, req *employeepb.GetEmployeeRequest) (*employeepb.Employee, error) {
+	resp, err := s.service.GetEmpByID(s.context(ctx), int(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+
+	return toEmployee(resp), nil
+}
+
+func (s server) CreateEmployee(ctx context.Context, req *employeepb.CreateEmployeeRequest) (*employeepb.Employee,
+	error) {
+	e, err := fromEmployee(req.GetEmployee())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.service.CreateEmp(s.context(ctx), e)
+	if err != nil {
+		return nil, err
+	}
+
+	return toEmployee(resp), nil
+}
+
+func (s server) UpdateEmployee(ctx context.Context, req *employeepb.UpdateEmployeeRequest) (*employeepb.Employee,
+	error) {
+	e, err := fromEmployee(req.GetEmployee())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.service.UpdateEmp(s.context(ctx), e)
+	if err != nil {
+		return nil, err
+	}
+
+	return toEmployee(resp), nil
+}
+
+func (s server) GetReports(ctx context.Context, req *employeepb.GetEmployeeRequest) (*employeepb.EmployeeList, error) {
+	return s.hierarchy(ctx, req, s.service.GetReports)
+}
+
+func (s server) GetSubtree(ctx context.Context, req *employeepb.GetEmployeeRequest) (*employeepb.EmployeeList, error) {
+	return s.hierarchy(ctx, req, s.service.GetSubtree)
+}
+
+func (s server) GetChain(ctx context.Context, req *employeepb.GetEmployeeRequest) (*employeepb.EmployeeList, error) {
+	return s.hierarchy(ctx, req, s.service.GetChain)
+}
+
+func (s server) hierarchy(ctx context.Context, req *employeepb.GetEmployeeRequest,
+	fn func(*gofr.Context, int) ([]model.Employee, error)) (*employeepb.EmployeeList, error) {
+	resp, err := fn(s.context(ctx), int(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+
+	return toList(resp), nil
+}
+
+// context returns the gofr context the service is called with, logging with the request id of the call.
+func (s server) context(ctx context.Context) *gofr.Context {
+	c := gofr.NewContext(nil, nil, s.app)
+	c.Context = ctx
+	c.Logger = middleware.NewRequestLogger(s.app.Logger, requestctx.RequestID(ctx))
+
+	return c
+}
+
+// nolint:gochecknoglobals // statuses of the protobuf enum and the model
+var statuses = map[employeepb.Status]model.Status{
+	employeepb.Status_STATUS_UNSPECIFIED: "",
+	employeepb.Status_STATUS_ACTIVE:      model.StatusActive,
+	employeepb.Status_STATUS_ON_LEAVE:    model.StatusOnLeave,
+	employeepb.Status_STATUS_TERMINATED:  model.StatusTerminated,
+}
+
+func toStatus(s employeepb.Status) (model.Status, error) {
+	status, ok := statuses[s]
+	if !ok {
+		return "", errors.InvalidParam{Param: []string{"status"}}
+	}
+
+	return status, nil
+}
+
+func fromStatus(s model.Status) employeepb.Status {
+	for k, v := range statuses {
+		if v == s {
+			return k
+		}
+	}
+
+	return employeepb.Status_STATUS_UNSPECIFIED
+}
+
+func toFilter(req *employeepb.ListEmployeesRequest) (model.Filter, error) {
+	status, err := toStatus(req.GetStatus())
+	if err != nil {
+		return model.Filter{}, err
+	}
+
+	return model.Filter{
+		DepartmentID: toID(req.DepartmentId),
+		Title:        req.GetTitle(),
+		Status:       status,
+		ManagerID:    toID(req.ManagerId),
+		AfterID:      toID(req.AfterId),
+		Limit:        int(req.GetLimit()),
+	}, nil
+}
+
+func fromEmployee(e *employeepb.Employee) (model.Employee, error) {
+	status, err := toStatus(e.GetStatus())
+	if err != nil {
+		return model.Employee{}, err
+	}
+
+	hireDate, err := toDate(e.GetHireDate(), "hire_date")
+	if err != nil {
+		return model.Employee{}, err
+	}
+
+	dob, err := toDate(e.GetDateOfBirth(), "date_of_birth")
+	if err != nil {
+		return model.Employee{}, err
+	}
+
+	var departmentID, managerID *int64
+	if e != nil {
+		departmentID, managerID = e.DepartmentId, e.ManagerId
+	}
+
+	return model.Employee{
+		ID:           int(e.GetId()),
+		Name:         e.GetName(),
+		Email:        e.GetEmail(),
+		DepartmentID: toID(departmentID),
+		Title:        e.GetTitle(),
+		ManagerID:    toID(managerID),
+		HireDate:     hireDate,
+		Status:       status,
+		DateOfBirth:  dob,
+	}, nil
+}
+
+func toEmployee(e model.Employee) *employeepb.Employee {
+	return &employeepb.Employee{
+		Id:           int64(e.ID),
+		Name:         e.Name,
+		Email:        e.Email,
+		DepartmentId: fromID(e.DepartmentID),
+		Title:        e.Title,
+		ManagerId:    fromID(e.ManagerID),
+		HireDate:     e.HireDate.String(),
+		Status:       fromStatus(e.Status),
+		DateOfBirth:  e.DateOfBirth.String(),
+		Age:          int32(e.Age),
+		CreatedAt:    timestamp(e.CreatedAt),
+		CreatedBy:    e.CreatedBy,
+		UpdatedAt:    timestamp(e.UpdatedAt),
+		UpdatedBy:    e.UpdatedBy,
+	}
+}
+
+func toList(employees []model.Employee) *employeepb.EmployeeList {
+	list := &employeepb.EmployeeList{Employees: make([]*employeepb.Employee, len(employees))}
+	for i := range employees {
+		list.Employees[i] = toEmployee(employees[i])
+	}
+
+	return list
+}
+
+func toDate(s, name string) (model.Date, error) {
+	if s == "" {
+		return model.Date{}, nil
+	}
+
+	d, err := model.ParseDate(s)
+	if err != nil {
+		return model.Date{}, errors.InvalidParam{Param: []string{name}}
+	}
+
+	return d, nil
+}
+
+func toID(id *int64) *int {
+	if id == nil {
+		return nil
+	}
+
+	i := int(*id)
+
+	return &i
+}
+
+func fromID(id *int) *int64 {
+	if id == nil {
+		return nil
+	}
+
+	i := int64(*id)
+
+	return &i
+}
+
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
@@ -0,0 +1,201 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"example/domain"
+	"example/model"
+	"example/requestctx"
+	"example/rpc/employeepb"
+	"example/service/mocks"
+)
+
+// dial serves emp on an in-memory listener and returns a client of it.
+func dial(t *testing.T, emp *mocks.MockEmpService) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	s := NewServer(gofr.New(), emp)
+
+	go func() { _ = s.Serve(lis) }()
+
+	conn, err := grpc.Dial("bufnet", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(
+		func(context.Context, string) (net.Conn, error) { return lis.Dial() }))
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+		s.Stop()
+	})
+
+	return conn
+}
+
+func authorized() context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "api-key", "ram")
+}
+
+func TestServer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	emp := mocks.NewMockEmpService(ctrl)
+	client := employeepb.NewEmployeesClient(dial(t, emp))
+
+	one, four := 1, 4
+	one64, four64 := int64(1), int64(4)
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	stored := model.Employee{ID: 2, Name: "Sai", Email: "sai@example.com", DepartmentID: &four, ManagerID: &one,
+		HireDate: model.NewDate(2020, 1, 6), Status: model.StatusActive, DateOfBirth: model.NewDate(1990, 5, 1),
+		Age: 31, CreatedAt: now, CreatedBy: "ram", UpdatedAt: now, UpdatedBy: "ram"}
+	sent := &employeepb.Employee{Id: 2, Name: "Sai", Email: "sai@example.com", DepartmentId: &four64,
+		ManagerId: &one64, HireDate: "2020-01-06", Status: employeepb.Status_STATUS_ACTIVE, DateOfBirth: "1990-05-01",
+		Age: 31, CreatedAt: timestamppb.New(now), CreatedBy: "ram", UpdatedAt: timestamppb.New(now), UpdatedBy: "ram"}
+	list := &employeepb.EmployeeList{Employees: []*employeepb.Employee{sent}}
+
+	testcases := []struct {
+		desc   string
+		call   func() (proto.Message, error)
+		mock   *gomock.Call
+		output proto.Message
+	}{
+		{"list", func() (proto.Message, error) {
+			return client.ListEmployees(authorized(), &employeepb.ListEmployeesRequest{DepartmentId: &four64,
+				Status: employeepb.Status_STATUS_ACTIVE, AfterId: &one64, Limit: 10})
+		}, emp.EXPECT().GetEmp(gomock.Any(), model.Filter{DepartmentID: &four, Status: model.StatusActive,
+			AfterID: &one, Limit: 10}).Return([]model.Employee{stored}, nil), list},
+		{"get", func() (proto.Message, error) {
+			return client.GetEmployee(authorized(), &employeepb.GetEmployeeRequest{Id: 2})
+		}, emp.EXPECT().GetEmpByID(gomock.Any(), 2).Return(stored, nil), sent},
+		{"create", func() (proto.Message, error) {
+			return client.CreateEmployee(authorized(), &employeepb.CreateEmployeeRequest{Employee: &employeepb.Employee{
+				Name: "Sai", Email: "sai@example.com", ManagerId: &one64, DateOfBirth: "1990-05-01", Age: 99}})
+		}, emp.EXPECT().CreateEmp(gomock.Any(), model.Employee{Name: "Sai", Email: "sai@example.com", ManagerID: &one,
+			DateOfBirth: model.NewDate(1990, 5, 1)}).Return(stored, nil), sent},
+		{"update", func() (proto.Message, error) {
+			return client.UpdateEmployee(authorized(), &employeepb.UpdateEmployeeRequest{Employee: &employeepb.Employee{
+				Id: 2, Name: "Sai", Status: employeepb.Status_STATUS_ON_LEAVE}})
+		}, emp.EXPECT().UpdateEmp(gomock.Any(), model.Employee{ID: 2, Name: "Sai", Status: model.StatusOnLeave}).
+			Return(stored, nil), sent},
+		{"reports", func() (proto.Message, error) {
+			return client.GetReports(authorized(), &employeepb.GetEmployeeRequest{Id: 1})
+		}, emp.EXPECT().GetReports(gomock.Any(), 1).Return([]model.Employee{stored}, nil), list},
+		{"subtree", func() (proto.Message, error) {
+			return client.GetSubtree(authorized(), &employeepb.GetEmployeeRequest{Id: 1})
+		}, emp.EXPECT().GetSubtree(gomock.Any(), 1).Return([]model.Employee{stored}, nil), list},
+		{"chain", func() (proto.Message, error) {
+			return client.GetChain(authorized(), &employeepb.GetEmployeeRequest{Id: 3})
+		}, emp.EXPECT().GetChain(gomock.Any(), 3).Return([]model.Employee{stored}, nil), list},
+	}
+
+	for i, tc := range testcases {
+		resp, err := tc.call()
+		if err != nil || !proto.Equal(tc.output, resp) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v %v", i+1, tc.output, resp, err)
+		}
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	emp := mocks.NewMockEmpService(ctrl)
+	client := employeepb.NewEmployeesClient(dial(t, emp))
+
+	testcases := []struct {
+		desc  string
+		ctx   context.Context
+		req   *employeepb.GetEmployeeRequest
+		mock  []*gomock.Call
+		code  codes.Code
+		field string
+	}{
+		{"unauthenticated", context.Background(), &employeepb.GetEmployeeRequest{Id: 1}, nil, codes.Unauthenticated, ""},
+		{"unknown key", metadata.AppendToOutgoingContext(context.Background(), "api-key", "x"),
+			&employeepb.GetEmployeeRequest{Id: 1}, nil, codes.Unauthenticated, ""},
+		{"not found", authorized(), &employeepb.GetEmployeeRequest{Id: 9}, []*gomock.Call{
+			emp.EXPECT().GetEmpByID(gomock.Any(), 9).Return(model.Employee{}, errors.EntityNotFound{Entity: "employee"}),
+		}, codes.NotFound, ""},
+		{"invalid", authorized(), &employeepb.GetEmployeeRequest{Id: -1}, []*gomock.Call{
+			emp.EXPECT().GetEmpByID(gomock.Any(), -1).Return(model.Employee{}, errors.InvalidParam{Param: []string{"id"}}),
+		}, codes.InvalidArgument, "id"},
+		{"failure", authorized(), &employeepb.GetEmployeeRequest{Id: 2}, []*gomock.Call{
+			emp.EXPECT().GetEmpByID(gomock.Any(), 2).Return(model.Employee{}, domain.Unavailable{Err: errors.DB{}}),
+		}, codes.Unavailable, ""},
+	}
+
+	for i, tc := range testcases {
+		_, err := client.GetEmployee(tc.ctx, tc.req)
+
+		s := status.Convert(err)
+		if s.Code() != tc.code || field(s) != tc.field {
+			t.Errorf("[Test %v]Failed. Expected %v %q but got %v %q", i+1, tc.code, tc.field, s.Code(), field(s))
+		}
+	}
+}
+
+func TestServer_Context(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	emp := mocks.NewMockEmpService(ctrl)
+	client := employeepb.NewEmployeesClient(dial(t, emp))
+
+	var principal, requestID string
+
+	emp.EXPECT().GetEmpByID(gomock.Any(), 1).DoAndReturn(func(c *gofr.Context, _ int) (model.Employee, error) {
+		principal, requestID = requestctx.Principal(c), requestctx.RequestID(c)
+		return model.Employee{ID: 1}, nil
+	})
+
+	var header metadata.MD
+
+	ctx := metadata.AppendToOutgoingContext(authorized(), "x-request-id", "req-1")
+	if _, err := client.GetEmployee(ctx, &employeepb.GetEmployeeRequest{Id: 1}, grpc.Header(&header)); err != nil {
+		t.Fatalf("[Test %v]Failed. Expected no error but got %v", 1, err)
+	}
+
+	if principal != "ram" || requestID != "req-1" || header.Get("x-request-id")[0] != "req-1" {
+		t.Errorf("[Test %v]Failed. Expected ram req-1 but got %v %v %v", 2, principal, requestID, header)
+	}
+}
+
+func TestServer_Reflection(t *testing.T) {
+	stream, err := reflectionpb.NewServerReflectionClient(dial(t, nil)).ServerReflectionInfo(context.Background())
+	if err != nil {
+		t.Fatalf("[Test %v]Failed. Expected no error but got %v", 1, err)
+	}
+
+	err = stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{}})
+	if err != nil {
+		t.Fatalf("[Test %v]Failed. Expected no error but got %v", 2, err)
+	}
+
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("[Test %v]Failed. Expected no error but got %v", 3, err)
+	}
+
+	found := false
+	for _, s := range resp.GetListServicesResponse().GetService() {
+		found = found || s.GetName() == "example.employee.v1.Employees"
+	}
+
+	if !found {
+		t.Errorf("[Test %v]Failed. Expected the Employees service to be listed but got %v", 4, resp)
+	}
+}
@@ -0,0 +1,171 @@
+package seed
+
+import (
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/datastore"
+	"example/model"
+)
+
+const (
+	minBirthYear = 1962
+	maxBirthYear = 2000
+	lastHireYear = 2022
+	minHireAge   = 21
+	actor        = "seed"
+)
+
+// nolint:gochecknoglobals // fixed pools keep generated employees deterministic for a given seed
+var (
+	firstNames = []string{"Ram", "Sai", "Kiran", "Gopal", "Harish", "Anita", "Priya", "Rahul", "Sneha", "Arjun",
+		"Meera", "Vikram", "Divya", "Suresh", "Lakshmi", "Manoj", "Kavya", "Nikhil", "Pooja", "Rohan"}
+	lastNames = []string{"Sharma", "Reddy", "Iyer", "Nair", "Rao", "Patel", "Gupta", "Menon", "Das", "Kumar"}
+	titles    = []string{"Associate", "Analyst", "Engineer", "Senior Engineer", "Manager", "Director"}
+	statuses  = []model.Status{model.StatusActive, model.StatusActive, model.StatusActive, model.StatusOnLeave,
+		model.StatusTerminated}
+)
+
+type seeder struct {
+	store     datastore.EmpStore
+	deptStore datastore.DeptStore
+}
+
+// nolint:revive // seeder should not be used without proper initialization with required dependency
+func New(s datastore.EmpStore, d datastore.DeptStore) seeder {
+	return seeder{store: s, deptStore: d}
+}
+
+// Load reads fixtures from a .json, .yaml or .yml file into v.
+func Load(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Error("Failed to read fixture " + path)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, v)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, v)
+	default:
+		return errors.Error("Unsupported fixture format " + filepath.Ext(path))
+	}
+
+	if err != nil {
+		return errors.Error("Failed to parse fixture " + path)
+	}
+
+	return nil
+}
+
+// Generate returns n fake employees with IDs starting at firstID, spread over departmentIDs. The same seed always
+// yields the same employees. The first employee has no manager and every other one reports to an employee generated
+// before it.
+func Generate(n int, seed int64, firstID int, departmentIDs []int) []model.Employee {
+	// nolint:gosec // fixture data does not need a cryptographically secure source
+	r := rand.New(rand.NewSource(seed))
+	emp := make([]model.Employee, 0, n)
+
+	for i := 0; i < n; i++ {
+		first, last := firstNames[r.Intn(len(firstNames))], lastNames[r.Intn(len(lastNames))]
+		id := firstID + i
+		dob := model.NewDate(minBirthYear+r.Intn(maxBirthYear-minBirthYear+1), time.Month(1+r.Intn(12)), 1+r.Intn(28))
+		hireYear := dob.Year() + minHireAge + 1 + r.Intn(lastHireYear-dob.Year()-minHireAge)
+
+		e := model.Employee{
+			ID:          id,
+			Name:        first + " " + last,
+			Email:       strings.ToLower(first+"."+last) + "." + strconv.Itoa(id) + "@example.com",
+			Title:       titles[r.Intn(len(titles))],
+			HireDate:    model.NewDate(hireYear, time.Month(1+r.Intn(12)), 1+r.Intn(28)),
+			Status:      statuses[r.Intn(len(statuses))],
+			DateOfBirth: dob,
+		}
+
+		if len(departmentIDs) > 0 {
+			dept := departmentIDs[r.Intn(len(departmentIDs))]
+			e.DepartmentID = &dept
+		}
+
+		if i > 0 {
+			manager := firstID + r.Intn(i)
+			e.ManagerID = &manager
+		}
+
+		emp = append(emp, e)
+	}
+
+	return emp
+}
+
+// SeedDepartments inserts missing departments and renames changed ones, so running it repeatedly leaves the table
+// unchanged.
+func (s seeder) SeedDepartments(ctx *gofr.Context, dept []model.Department) error {
+	for i := range dept {
+		existing, err := s.deptStore.DeptGetByID(ctx, dept[i].ID)
+
+		switch err.(type) {
+		case nil:
+			if existing.Name == dept[i].Name {
+				continue
+			}
+
+			_, err = s.deptStore.DeptUpdate(ctx, dept[i])
+		case errors.EntityNotFound:
+			_, err = s.deptStore.DeptCreate(ctx, dept[i])
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Seed inserts missing employees and updates changed ones, so running it repeatedly leaves the table unchanged. Fixtures
+// are not changes worth reporting, so no events are recorded for them.
+func (s seeder) Seed(ctx *gofr.Context, emp []model.Employee) error {
+	for i := range emp {
+		emp[i].CreatedBy, emp[i].UpdatedBy = actor, actor
+
+		existing, err := s.store.EmpGetByID(ctx, emp[i].ID)
+
+		switch err.(type) {
+		case nil:
+			if sameFixture(existing, emp[i]) {
+				continue
+			}
+
+			_, err = s.store.EmpUpdate(ctx, emp[i], nil)
+		case errors.EntityNotFound:
+			_, err = s.store.EmpCreate(ctx, emp[i], nil)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sameFixture compares the fixture fields of two employees, ignoring the bookkeeping fields the store maintains.
+func sameFixture(a, b model.Employee) bool {
+	a.Age, a.CreatedAt, a.CreatedBy, a.UpdatedAt, a.UpdatedBy = 0, time.Time{}, "", time.Time{}, ""
+	b.Age, b.CreatedAt, b.CreatedBy, b.UpdatedAt, b.UpdatedBy = 0, time.Time{}, "", time.Time{}, ""
+
+	return reflect.DeepEqual(a, b)
+}
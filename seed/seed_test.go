@@ -0,0 +1,184 @@
+package seed
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/datastore/mocks"
+	"example/model"
+)
+
+func TestLoad(t *testing.T) {
+	manager, dept := 2, 3
+	dob := model.NewDate(2000, time.March, 1)
+	dir := t.TempDir()
+	files := map[string]string{
+		"emp.json": `[{"id":1,"name":"Ram","department_id":3,"manager_id":2,"date_of_birth":"2000-03-01"}]`,
+		"emp.yaml": "- id: 1\n  name: Ram\n  department_id: 3\n  manager_id: 2\n  date_of_birth: \"2000-03-01\"\n",
+		"bad.json": `[{"id":"one"}]`,
+		"emp.txt":  "1,21,Ram",
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatalf("failed to write fixture %v", err)
+		}
+	}
+
+	testcases := []struct {
+		desc   string
+		file   string
+		output []model.Employee
+		err    error
+	}{
+		{"json", "emp.json", []model.Employee{{ID: 1, Name: "Ram", DepartmentID: &dept, ManagerID: &manager,
+			DateOfBirth: dob}}, nil},
+		{"yaml", "emp.yaml", []model.Employee{{ID: 1, Name: "Ram", DepartmentID: &dept, ManagerID: &manager,
+			DateOfBirth: dob}}, nil},
+		{"parse error", "bad.json", nil, errors.Error("Failed to parse fixture " + filepath.Join(dir, "bad.json"))},
+		{"unsupported", "emp.txt", nil, errors.Error("Unsupported fixture format .txt")},
+		{"missing", "none.json", nil, errors.Error("Failed to read fixture " + filepath.Join(dir, "none.json"))},
+	}
+
+	for i, tc := range testcases {
+		var resp []model.Employee
+
+		err := Load(filepath.Join(dir, tc.file), &resp)
+
+		if tc.err == nil && !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	departments := []int{7, 8}
+	first := Generate(5, 42, 100, departments)
+	second := Generate(5, 42, 100, departments)
+
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("Failed. Expected same employees for the same seed but got %v and %v", first, second)
+	}
+
+	emails := make(map[string]bool)
+
+	for i, e := range first {
+		if e.ID != 100+i || e.Name == "" || emails[e.Email] || e.DateOfBirth.YearsAt(e.HireDate.Time) < minHireAge ||
+			e.DateOfBirth.Year() < minBirthYear || e.DateOfBirth.Year() > maxBirthYear || !e.Status.Valid() {
+			t.Errorf("[Test %v]Failed. Got invalid employee %v", i+1, e)
+		}
+
+		if e.DepartmentID == nil || (*e.DepartmentID != 7 && *e.DepartmentID != 8) {
+			t.Errorf("[Test %v]Failed. Got invalid department for employee %v", i+1, e)
+		}
+
+		if (i == 0) != (e.ManagerID == nil) || (e.ManagerID != nil && *e.ManagerID >= e.ID) {
+			t.Errorf("[Test %v]Failed. Got invalid manager for employee %v", i+1, e)
+		}
+
+		emails[e.Email] = true
+	}
+
+	if reflect.DeepEqual(first, Generate(5, 43, 100, departments)) {
+		t.Errorf("Failed. Expected different employees for a different seed")
+	}
+}
+
+func TestSeeder_Seed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockEmpStore(ctrl)
+	s := New(m, mocks.NewMockDeptStore(ctrl))
+	ctx := gofr.NewContext(nil, nil, gofr.New())
+	ctx.Context = context.Background()
+
+	emp := model.Employee{ID: 1, Name: "Ram", Email: "ram@example.com", CreatedBy: "seed", UpdatedBy: "seed"}
+	notFound := errors.EntityNotFound{Entity: "employee", ID: "1"}
+
+	testcases := []struct {
+		desc string
+		err  error
+		mock []*gomock.Call
+	}{
+		{"unchanged", nil, []*gomock.Call{
+			m.EXPECT().EmpGetByID(gomock.Any(), 1).Return(model.Employee{ID: 1, Age: 21, Name: "Ram", Email: "ram@example.com",
+				UpdatedAt: time.Now(), UpdatedBy: "ram"}, nil),
+		}},
+		{"changed", nil, []*gomock.Call{
+			m.EXPECT().EmpGetByID(gomock.Any(), 1).Return(model.Employee{ID: 1, Name: "Ram", Email: "ram@example.org"}, nil),
+			m.EXPECT().EmpUpdate(gomock.Any(), emp, nil).Return(emp, nil),
+		}},
+		{"missing", nil, []*gomock.Call{
+			m.EXPECT().EmpGetByID(gomock.Any(), 1).Return(model.Employee{}, notFound),
+			m.EXPECT().EmpCreate(gomock.Any(), emp, nil).Return(emp, nil),
+		}},
+		{"create failure", errors.Error("Internal DB Error"), []*gomock.Call{
+			m.EXPECT().EmpGetByID(gomock.Any(), 1).Return(model.Employee{}, notFound),
+			m.EXPECT().EmpCreate(gomock.Any(), emp, nil).Return(model.Employee{}, errors.Error("Internal DB Error")),
+		}},
+		{"lookup failure", errors.Error("Scan Error"), []*gomock.Call{
+			m.EXPECT().EmpGetByID(gomock.Any(), 1).Return(model.Employee{}, errors.Error("Scan Error")),
+		}},
+	}
+
+	for i, tc := range testcases {
+		err := s.Seed(ctx, []model.Employee{{ID: 1, Name: "Ram", Email: "ram@example.com"}})
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestSeeder_SeedDepartments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockDeptStore(ctrl)
+	s := New(mocks.NewMockEmpStore(ctrl), m)
+	ctx := gofr.NewContext(nil, nil, gofr.New())
+	ctx.Context = context.Background()
+
+	dept := model.Department{ID: 1, Name: "Engineering"}
+	notFound := errors.EntityNotFound{Entity: "department", ID: "1"}
+
+	testcases := []struct {
+		desc string
+		err  error
+		mock []*gomock.Call
+	}{
+		{"unchanged", nil, []*gomock.Call{
+			m.EXPECT().DeptGetByID(gomock.Any(), 1).Return(model.Department{ID: 1, Name: "Engineering",
+				UpdatedAt: time.Now()}, nil),
+		}},
+		{"renamed", nil, []*gomock.Call{
+			m.EXPECT().DeptGetByID(gomock.Any(), 1).Return(model.Department{ID: 1, Name: "Eng"}, nil),
+			m.EXPECT().DeptUpdate(gomock.Any(), dept).Return(dept, nil),
+		}},
+		{"missing", nil, []*gomock.Call{
+			m.EXPECT().DeptGetByID(gomock.Any(), 1).Return(model.Department{}, notFound),
+			m.EXPECT().DeptCreate(gomock.Any(), dept).Return(dept, nil),
+		}},
+		{"lookup failure", errors.Error("Scan Error"), []*gomock.Call{
+			m.EXPECT().DeptGetByID(gomock.Any(), 1).Return(model.Department{}, errors.Error("Scan Error")),
+		}},
+	}
+
+	for i, tc := range testcases {
+		err := s.SeedDepartments(ctx, []model.Department{dept})
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
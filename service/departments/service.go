@@ -0,0 +1,100 @@
+package departments
+
+import (
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/datastore"
+	"example/domain"
+	"example/model"
+)
+
+type service struct {
+	store    datastore.DeptStore
+	empStore datastore.EmpStore
+}
+
+func New(s datastore.DeptStore, e datastore.EmpStore) service {
+	return service{store: s, empStore: e}
+}
+
+// storeError passes missing departments and unique conflicts through to the caller, and reports every other store
+// error as the store being unavailable.
+func storeError(err error) error {
+	switch e := err.(type) {
+	case domain.NotFound, domain.Conflict:
+		return e
+	default:
+		return domain.Unavailable{Err: err}
+	}
+}
+
+func (s service) GetDept(ctx *gofr.Context) ([]model.Department, error) {
+	resp, err := s.store.DeptGet(ctx)
+
+	if err != nil {
+		return nil, domain.Unavailable{Err: err}
+	}
+
+	return resp, nil
+}
+
+func (s service) GetDeptByID(ctx *gofr.Context, id int) (model.Department, error) {
+	resp, err := s.store.DeptGetByID(ctx, id)
+
+	if err != nil {
+		return model.Department{}, storeError(err)
+	}
+
+	return resp, nil
+}
+
+func (s service) CreateDept(ctx *gofr.Context, department model.Department) (model.Department, error) {
+	if department.Name == "" {
+		return model.Department{}, domain.Invalid{Param: []string{"name"}}
+	}
+
+	resp, err := s.store.DeptCreate(ctx, department)
+
+	if err != nil {
+		return model.Department{}, storeError(err)
+	}
+
+	return resp, nil
+}
+
+func (s service) UpdateDept(ctx *gofr.Context, department model.Department) (model.Department, error) {
+	if department.Name == "" {
+		return model.Department{}, domain.Invalid{Param: []string{"name"}}
+	}
+
+	resp, err := s.store.DeptUpdate(ctx, department)
+
+	if err != nil {
+		return model.Department{}, storeError(err)
+	}
+
+	return resp, nil
+}
+
+// DeleteDept refuses to delete a department that employees still belong to: the store reports it as a conflict.
+func (s service) DeleteDept(ctx *gofr.Context, id int) error {
+	if err := s.store.DeptDelete(ctx, id); err != nil {
+		return storeError(err)
+	}
+
+	return nil
+}
+
+func (s service) GetDeptEmployees(ctx *gofr.Context, id int) ([]model.Employee, error) {
+	if _, err := s.store.DeptGetByID(ctx, id); err != nil {
+		return nil, storeError(err)
+	}
+
+	resp, err := s.empStore.EmpGet(ctx, model.Filter{DepartmentID: &id})
+
+	if err != nil {
+		return nil, domain.Unavailable{Err: err}
+	}
+
+	return resp, nil
+}
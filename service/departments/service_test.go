@@ -0,0 +1,232 @@
+package departments
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/datastore/mocks"
+	"example/domain"
+	"example/model"
+)
+
+func newContext() *gofr.Context {
+	ctx := gofr.NewContext(nil, nil, gofr.New())
+	ctx.Context = context.Background()
+
+	return ctx
+}
+
+func TestService_GetDept(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	d := mocks.NewMockDeptStore(ctrl)
+	s := New(d, mocks.NewMockEmpStore(ctrl))
+
+	testcases := []struct {
+		desc   string
+		output []model.Department
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"success", []model.Department{{ID: 1, Name: "Engineering"}}, nil, []*gomock.Call{
+			d.EXPECT().DeptGet(gomock.Any()).Return([]model.Department{{ID: 1, Name: "Engineering"}}, nil),
+		}},
+		{"failure", nil, domain.Unavailable{Err: errors.DB{}}, []*gomock.Call{
+			d.EXPECT().DeptGet(gomock.Any()).Return(nil, errors.DB{}),
+		}},
+	}
+
+	for i, tc := range testcases {
+		resp, err := s.GetDept(newContext())
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestService_GetDeptByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	d := mocks.NewMockDeptStore(ctrl)
+	s := New(d, mocks.NewMockEmpStore(ctrl))
+	notFound := errors.EntityNotFound{Entity: "department", ID: "2"}
+
+	testcases := []struct {
+		desc   string
+		id     int
+		output model.Department
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"success", 1, model.Department{ID: 1, Name: "Engineering"}, nil, []*gomock.Call{
+			d.EXPECT().DeptGetByID(gomock.Any(), 1).Return(model.Department{ID: 1, Name: "Engineering"}, nil),
+		}},
+		{"not found", 2, model.Department{}, notFound, []*gomock.Call{
+			d.EXPECT().DeptGetByID(gomock.Any(), 2).Return(model.Department{}, notFound),
+		}},
+		{"failure", 3, model.Department{}, domain.Unavailable{Err: errors.Error("Scan Error")}, []*gomock.Call{
+			d.EXPECT().DeptGetByID(gomock.Any(), 3).Return(model.Department{}, errors.Error("Scan Error")),
+		}},
+	}
+
+	for i, tc := range testcases {
+		resp, err := s.GetDeptByID(newContext(), tc.id)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestService_CreateDept(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	d := mocks.NewMockDeptStore(ctrl)
+	s := New(d, mocks.NewMockEmpStore(ctrl))
+	dept := model.Department{ID: 1, Name: "Engineering"}
+
+	testcases := []struct {
+		desc   string
+		input  model.Department
+		output model.Department
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"success", dept, dept, nil, []*gomock.Call{d.EXPECT().DeptCreate(gomock.Any(), dept).Return(dept, nil)}},
+		{"failure", dept, model.Department{}, domain.Unavailable{Err: errors.Error("Internal DB Error")}, []*gomock.Call{
+			d.EXPECT().DeptCreate(gomock.Any(), dept).Return(model.Department{}, errors.Error("Internal DB Error")),
+		}},
+		{"missing name", model.Department{ID: 2}, model.Department{}, errors.InvalidParam{Param: []string{"name"}}, nil},
+	}
+
+	for i, tc := range testcases {
+		resp, err := s.CreateDept(newContext(), tc.input)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestService_UpdateDept(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	d := mocks.NewMockDeptStore(ctrl)
+	s := New(d, mocks.NewMockEmpStore(ctrl))
+	dept := model.Department{ID: 1, Name: "Platform"}
+	notFound := errors.EntityNotFound{Entity: "department", ID: "1"}
+
+	testcases := []struct {
+		desc   string
+		input  model.Department
+		output model.Department
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"success", dept, dept, nil, []*gomock.Call{d.EXPECT().DeptUpdate(gomock.Any(), dept).Return(dept, nil)}},
+		{"not found", dept, model.Department{}, notFound, []*gomock.Call{
+			d.EXPECT().DeptUpdate(gomock.Any(), dept).Return(model.Department{}, notFound),
+		}},
+		{"missing name", model.Department{ID: 1}, model.Department{}, errors.InvalidParam{Param: []string{"name"}}, nil},
+	}
+
+	for i, tc := range testcases {
+		resp, err := s.UpdateDept(newContext(), tc.input)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestService_DeleteDept(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	d := mocks.NewMockDeptStore(ctrl)
+	e := mocks.NewMockEmpStore(ctrl)
+	s := New(d, e)
+	conflict := domain.Conflict{Entity: "department", Reason: "department 2 still has employees"}
+
+	testcases := []struct {
+		desc string
+		id   int
+		err  error
+		mock []*gomock.Call
+	}{
+		{"success", 1, nil, []*gomock.Call{
+			d.EXPECT().DeptDelete(gomock.Any(), 1).Return(nil),
+		}},
+		{"has employees", 2, conflict, []*gomock.Call{
+			d.EXPECT().DeptDelete(gomock.Any(), 2).Return(conflict),
+		}},
+		{"not found", 3, errors.EntityNotFound{Entity: "department", ID: "3"}, []*gomock.Call{
+			d.EXPECT().DeptDelete(gomock.Any(), 3).Return(errors.EntityNotFound{Entity: "department", ID: "3"}),
+		}},
+		{"failure", 4, domain.Unavailable{Err: errors.Error("Internal DB Error")}, []*gomock.Call{
+			d.EXPECT().DeptDelete(gomock.Any(), 4).Return(errors.Error("Internal DB Error")),
+		}},
+	}
+
+	for i, tc := range testcases {
+		err := s.DeleteDept(newContext(), tc.id)
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestService_GetDeptEmployees(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	d := mocks.NewMockDeptStore(ctrl)
+	e := mocks.NewMockEmpStore(ctrl)
+	s := New(d, e)
+	one := 1
+	notFound := errors.EntityNotFound{Entity: "department", ID: "2"}
+
+	testcases := []struct {
+		desc   string
+		id     int
+		output []model.Employee
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"success", 1, []model.Employee{{ID: 4, DepartmentID: &one}}, nil, []*gomock.Call{
+			d.EXPECT().DeptGetByID(gomock.Any(), 1).Return(model.Department{ID: 1}, nil),
+			e.EXPECT().EmpGet(gomock.Any(), model.Filter{DepartmentID: &one}).Return([]model.Employee{{ID: 4, DepartmentID: &one}}, nil),
+		}},
+		{"not found", 2, nil, notFound, []*gomock.Call{
+			d.EXPECT().DeptGetByID(gomock.Any(), 2).Return(model.Department{}, notFound),
+		}},
+	}
+
+	for i, tc := range testcases {
+		resp, err := s.GetDeptEmployees(newContext(), tc.id)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package employees
+
+import (
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/domain"
+	"example/model"
+)
+
+// GetReports returns the employees whose manager is id.
+func (s service) GetReports(ctx *gofr.Context, id int) ([]model.Employee, error) {
+	if err := s.exists(ctx, id); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.store.EmpGet(ctx, model.Filter{ManagerID: &id})
+
+	if err != nil {
+		return nil, domain.Unavailable{Err: err}
+	}
+
+	return resp, nil
+}
+
+// GetSubtree returns every employee reporting to id directly or indirectly.
+func (s service) GetSubtree(ctx *gofr.Context, id int) ([]model.Employee, error) {
+	if err := s.exists(ctx, id); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.store.EmpSubtree(ctx, id)
+
+	if err != nil {
+		return nil, domain.Unavailable{Err: err}
+	}
+
+	return resp, nil
+}
+
+// GetChain returns the managers of id, starting with its direct manager and ending at the root.
+func (s service) GetChain(ctx *gofr.Context, id int) ([]model.Employee, error) {
+	if err := s.exists(ctx, id); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.store.EmpChain(ctx, id)
+
+	if err != nil {
+		return nil, domain.Unavailable{Err: err}
+	}
+
+	return resp, nil
+}
+
+func (s service) exists(ctx *gofr.Context, id int) error {
+	if _, err := s.store.EmpGetByID(ctx, id); err != nil {
+		return storeError(err)
+	}
+
+	return nil
+}
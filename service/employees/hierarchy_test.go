@@ -0,0 +1,72 @@
+package employees
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/datastore/mocks"
+	"example/domain"
+	"example/model"
+)
+
+func TestService_Hierarchy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockEmpStore(ctrl)
+	s := service{store: m, now: clock}
+	app := gofr.New()
+	one := 1
+	notFound := errors.EntityNotFound{Entity: "employee", ID: "9"}
+
+	testcases := []struct {
+		desc   string
+		get    func(*gofr.Context, int) ([]model.Employee, error)
+		id     int
+		output []model.Employee
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"reports", s.GetReports, 1, []model.Employee{{ID: 2, ManagerID: &one}}, nil, []*gomock.Call{
+			m.EXPECT().EmpGetByID(gomock.Any(), 1).Return(model.Employee{ID: 1}, nil),
+			m.EXPECT().EmpGet(gomock.Any(), model.Filter{ManagerID: &one}).Return([]model.Employee{{ID: 2, ManagerID: &one}}, nil),
+		}},
+		{"subtree", s.GetSubtree, 1, []model.Employee{{ID: 2}, {ID: 3}}, nil, []*gomock.Call{
+			m.EXPECT().EmpGetByID(gomock.Any(), 1).Return(model.Employee{ID: 1}, nil),
+			m.EXPECT().EmpSubtree(gomock.Any(), 1).Return([]model.Employee{{ID: 2}, {ID: 3}}, nil),
+		}},
+		{"chain", s.GetChain, 3, []model.Employee{{ID: 2}, {ID: 1}}, nil, []*gomock.Call{
+			m.EXPECT().EmpGetByID(gomock.Any(), 3).Return(model.Employee{ID: 3}, nil),
+			m.EXPECT().EmpChain(gomock.Any(), 3).Return([]model.Employee{{ID: 2}, {ID: 1}}, nil),
+		}},
+		{"chain failure", s.GetChain, 4, nil, domain.Unavailable{Err: errors.DB{}}, []*gomock.Call{
+			m.EXPECT().EmpGetByID(gomock.Any(), 4).Return(model.Employee{ID: 4}, nil),
+			m.EXPECT().EmpChain(gomock.Any(), 4).Return(nil, errors.DB{}),
+		}},
+		{"not found", s.GetSubtree, 9, nil, notFound, []*gomock.Call{
+			m.EXPECT().EmpGetByID(gomock.Any(), 9).Return(model.Employee{}, notFound),
+		}},
+		{"lookup failure", s.GetReports, 5, nil, domain.Unavailable{Err: errors.Error("Scan Error")}, []*gomock.Call{
+			m.EXPECT().EmpGetByID(gomock.Any(), 5).Return(model.Employee{}, errors.Error("Scan Error")),
+		}},
+	}
+
+	for i, tc := range testcases {
+		ctx := gofr.NewContext(nil, nil, app)
+		ctx.Context = context.Background()
+
+		resp, err := tc.get(ctx, tc.id)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
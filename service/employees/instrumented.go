@@ -0,0 +1,83 @@
+package employees
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/metrics"
+	"example/model"
+	"example/tracing"
+)
+
+// instrumented traces the calls to the service and counts them by operation and outcome.
+type instrumented struct {
+	service
+}
+
+func (s instrumented) GetEmp(ctx *gofr.Context, filter model.Filter) ([]model.Employee, error) {
+	span := tracing.Start(ctx, "EmpService.GetEmp")
+	resp, err := s.service.GetEmp(ctx, filter)
+	span.SetAttributes(attribute.Int("employee.count", len(resp)))
+	span.End(err)
+	metrics.Operation(ctx, "list", err)
+
+	return resp, err
+}
+
+func (s instrumented) GetEmpByID(ctx *gofr.Context, id int) (model.Employee, error) {
+	span := tracing.Start(ctx, "EmpService.GetEmpByID", attribute.Int("employee.id", id))
+	resp, err := s.service.GetEmpByID(ctx, id)
+	span.End(err)
+	metrics.Operation(ctx, "get", err)
+
+	return resp, err
+}
+
+func (s instrumented) CreateEmp(ctx *gofr.Context, employee model.Employee) (model.Employee, error) {
+	span := tracing.Start(ctx, "EmpService.CreateEmp", attribute.Int("employee.id", employee.ID))
+	resp, err := s.service.CreateEmp(ctx, employee)
+	span.End(err)
+	metrics.Operation(ctx, "create", err)
+
+	return resp, err
+}
+
+func (s instrumented) UpdateEmp(ctx *gofr.Context, employee model.Employee) (model.Employee, error) {
+	span := tracing.Start(ctx, "EmpService.UpdateEmp", attribute.Int("employee.id", employee.ID))
+	resp, err := s.service.UpdateEmp(ctx, employee)
+	span.End(err)
+	metrics.Operation(ctx, "update", err)
+
+	return resp, err
+}
+
+func (s instrumented) GetReports(ctx *gofr.Context, id int) ([]model.Employee, error) {
+	span := tracing.Start(ctx, "EmpService.GetReports", attribute.Int("employee.id", id))
+	resp, err := s.service.GetReports(ctx, id)
+	span.SetAttributes(attribute.Int("employee.count", len(resp)))
+	span.End(err)
+	metrics.Operation(ctx, "reports", err)
+
+	return resp, err
+}
+
+func (s instrumented) GetSubtree(ctx *gofr.Context, id int) ([]model.Employee, error) {
+	span := tracing.Start(ctx, "EmpService.GetSubtree", attribute.Int("employee.id", id))
+	resp, err := s.service.GetSubtree(ctx, id)
+	span.SetAttributes(attribute.Int("employee.count", len(resp)))
+	span.End(err)
+	metrics.Operation(ctx, "subtree", err)
+
+	return resp, err
+}
+
+func (s instrumented) GetChain(ctx *gofr.Context, id int) ([]model.Employee, error) {
+	span := tracing.Start(ctx, "EmpService.GetChain", attribute.Int("employee.id", id))
+	resp, err := s.service.GetChain(ctx, id)
+	span.SetAttributes(attribute.Int("employee.count", len(resp)))
+	span.End(err)
+	metrics.Operation(ctx, "chain", err)
+
+	return resp, err
+}
@@ -0,0 +1,68 @@
+package employees
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"developer.zopsmart.com/go/gofr/pkg/log"
+
+	"example/datastore/mocks"
+	"example/model"
+)
+
+type metric struct {
+	recorded []string
+}
+
+func (m *metric) IncCounter(name string, labels ...string) error {
+	m.recorded = append(m.recorded, fmt.Sprint(name, labels))
+	return nil
+}
+
+func (m *metric) ObserveHistogram(name string, _ float64, labels ...string) error {
+	m.recorded = append(m.recorded, fmt.Sprint(name, labels))
+	return nil
+}
+
+func (m *metric) SetGauge(name string, value float64, labels ...string) error {
+	m.recorded = append(m.recorded, fmt.Sprint(name, labels, value))
+	return nil
+}
+
+func TestInstrumented_GetEmpByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockEmpStore(ctrl)
+	s := instrumented{service{store: m, now: clock}}
+
+	testcases := []struct {
+		desc     string
+		recorded string
+		mock     *gomock.Call
+	}{
+		{"success", "employee_operations_total[get success]",
+			m.EXPECT().EmpGetByID(gomock.Any(), 1).Return(model.Employee{ID: 1}, nil)},
+		{"not found", "employee_operations_total[get not_found]",
+			m.EXPECT().EmpGetByID(gomock.Any(), 2).Return(model.Employee{}, errors.EntityNotFound{Entity: "employee", ID: "2"})},
+		{"failure", "employee_operations_total[get error]",
+			m.EXPECT().EmpGetByID(gomock.Any(), 3).Return(model.Employee{}, errors.DB{})},
+	}
+
+	for i, tc := range testcases {
+		rec := &metric{}
+		ctx := gofr.NewContext(nil, nil, &gofr.Gofr{Metric: rec, Logger: log.NewMockLogger(io.Discard)})
+		ctx.Context = context.Background()
+
+		_, _ = s.GetEmpByID(ctx, i+1)
+
+		if !reflect.DeepEqual([]string{tc.recorded}, rec.recorded) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.recorded, rec.recorded)
+		}
+	}
+}
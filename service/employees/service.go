@@ -1,26 +1,46 @@
 package employees
 
 import (
-	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"crypto/rand"
+	"encoding/hex"
+	"net/mail"
+	"time"
+
 	"developer.zopsmart.com/go/gofr/pkg/gofr"
 
 	"example/datastore"
+	"example/domain"
 	"example/model"
+	"example/requestctx"
 )
 
+const minWorkingAge = 14
+
 type service struct {
-	store datastore.EmpStore
+	store     datastore.EmpStore
+	deptStore datastore.DeptStore
+	now       func() time.Time
 }
 
-func New(s datastore.EmpStore) service {
-	return service{store: s}
+// New returns the employee service, tracing its calls and counting them in metrics.Operations. The changes it makes
+// to employees are stored with their events, for the outbox relay to publish.
+func New(s datastore.EmpStore, d datastore.DeptStore) instrumented {
+	return instrumented{service{store: s, deptStore: d, now: time.Now}}
 }
 
-func (s service) GetEmp(ctx *gofr.Context) ([]model.Employee, error) {
-	resp, err := s.store.EmpGet(ctx)
+func (s service) GetEmp(ctx *gofr.Context, filter model.Filter) ([]model.Employee, error) {
+	if filter.Status != "" && !filter.Status.Valid() {
+		return nil, domain.Invalid{Param: []string{"status"}}
+	}
+
+	if filter.Limit < 0 || filter.Limit > model.MaxPageSize {
+		return nil, domain.Invalid{Param: []string{"limit"}}
+	}
+
+	resp, err := s.store.EmpGet(ctx, filter)
 
 	if err != nil {
-		return nil, errors.Error("Connect Failed")
+		return nil, storeError(err)
 	}
 
 	return resp, nil
@@ -30,28 +50,152 @@ func (s service) GetEmpByID(ctx *gofr.Context, id int) (model.Employee, error) {
 	resp, err := s.store.EmpGetByID(ctx, id)
 
 	if err != nil {
-		return model.Employee{}, errors.Error("Connect Failed")
+		return model.Employee{}, storeError(err)
 	}
 
 	return resp, nil
 }
 
+// CreateEmp records the authenticated principal as both creator and last updater of the employee.
 func (s service) CreateEmp(ctx *gofr.Context, employee model.Employee) (model.Employee, error) {
-	resp, err := s.store.EmpCreate(ctx, employee)
+	if employee.Status == "" {
+		employee.Status = model.StatusActive
+	}
+
+	if err := s.validate(ctx, employee); err != nil {
+		return model.Employee{}, err
+	}
+
+	employee.CreatedBy = requestctx.Principal(ctx)
+	employee.UpdatedBy = employee.CreatedBy
+
+	resp, err := s.store.EmpCreate(ctx, employee, s.event(ctx, model.EmployeeCreated))
 
 	if err != nil {
-		return model.Employee{}, errors.Error("Connect Failed")
+		return model.Employee{}, storeError(err)
 	}
 
 	return resp, err
 }
 
+// UpdateEmp records the authenticated principal as the last updater of the employee. The store refuses a manager
+// that reports to the employee, directly or indirectly.
 func (s service) UpdateEmp(ctx *gofr.Context, employee model.Employee) (model.Employee, error) {
-	resp, err := s.store.EmpUpdate(ctx, employee)
+	if employee.Status == "" {
+		employee.Status = model.StatusActive
+	}
+
+	if err := s.validate(ctx, employee); err != nil {
+		return model.Employee{}, err
+	}
+
+	employee.UpdatedBy = requestctx.Principal(ctx)
+
+	resp, err := s.store.EmpUpdate(ctx, employee, s.event(ctx, model.EmployeeUpdated))
 
 	if err != nil {
-		return model.Employee{}, errors.Error("Connect Failed")
+		return model.Employee{}, storeError(err)
 	}
 
 	return resp, err
 }
+
+// event returns the event of a change of type t made for ctx, which the store completes with the changed employee.
+func (s service) event(ctx *gofr.Context, t model.EventType) *model.Event {
+	return &model.Event{ID: newEventID(), Type: t, SchemaVersion: model.EventSchemaVersion, OccurredAt: s.now().UTC(),
+		Principal: requestctx.Principal(ctx), RequestID: requestctx.RequestID(ctx)}
+}
+
+func newEventID() string {
+	b := make([]byte, 16)
+
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+// storeError passes missing employees, unique conflicts and references to missing rows through to the caller, and
+// reports every other store error as the store being unavailable.
+func storeError(err error) error {
+	switch e := err.(type) {
+	case domain.NotFound, domain.Conflict, domain.Invalid:
+		return e
+	default:
+		return domain.Unavailable{Err: err}
+	}
+}
+
+// validate returns an InvalidParam listing every field of the employee that does not hold an acceptable value.
+func (s service) validate(ctx *gofr.Context, e model.Employee) error {
+	var invalid []string
+
+	if e.Name == "" {
+		invalid = append(invalid, "name")
+	}
+
+	if addr, err := mail.ParseAddress(e.Email); err != nil || addr.Address != e.Email {
+		invalid = append(invalid, "email")
+	}
+
+	if e.ManagerID != nil && *e.ManagerID == e.ID {
+		invalid = append(invalid, "manager_id")
+	}
+
+	if !e.Status.Valid() {
+		invalid = append(invalid, "status")
+	}
+
+	if e.DateOfBirth.IsZero() || e.DateOfBirth.YearsAt(s.now()) < minWorkingAge {
+		invalid = append(invalid, "date_of_birth")
+	}
+
+	if e.HireDate.IsZero() || e.HireDate.Before(e.DateOfBirth.Time) {
+		invalid = append(invalid, "hire_date")
+	}
+
+	if len(invalid) > 0 {
+		return domain.Invalid{Param: invalid}
+	}
+
+	if err := s.validateDepartment(ctx, e.DepartmentID); err != nil {
+		return err
+	}
+
+	return s.managerExists(ctx, e.ManagerID)
+}
+
+// validateDepartment checks that the department an employee is assigned to exists.
+func (s service) validateDepartment(ctx *gofr.Context, id *int) error {
+	if id == nil {
+		return nil
+	}
+
+	_, err := s.deptStore.DeptGetByID(ctx, *id)
+
+	switch err.(type) {
+	case nil:
+		return nil
+	case domain.NotFound:
+		return domain.Invalid{Param: []string{"department_id"}}
+	default:
+		return domain.Unavailable{Err: err}
+	}
+}
+
+// managerExists checks that the manager an employee reports to exists.
+func (s service) managerExists(ctx *gofr.Context, id *int) error {
+	if id == nil {
+		return nil
+	}
+
+	_, err := s.store.EmpGetByID(ctx, *id)
+
+	switch err.(type) {
+	case nil:
+		return nil
+	case domain.NotFound:
+		return domain.Invalid{Param: []string{"manager_id"}}
+	default:
+		return domain.Unavailable{Err: err}
+	}
+}
@@ -2,8 +2,10 @@ package employees
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 
@@ -11,27 +13,46 @@ import (
 	"developer.zopsmart.com/go/gofr/pkg/gofr"
 
 	"example/datastore/mocks"
+	"example/domain"
 	"example/model"
+	"example/requestctx"
 )
 
+func clock() time.Time {
+	return time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+}
+
+func validEmployee(id int) model.Employee {
+	dept := 1
+
+	return model.Employee{ID: id, Name: "Ram", Email: "ram@example.com", DepartmentID: &dept, Title: "Engineer",
+		HireDate: model.NewDate(2021, time.June, 1), Status: model.StatusActive, DateOfBirth: model.NewDate(2000, time.March, 1)}
+}
+
 func TestService_GetEmp(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	m := mocks.NewMockEmpStore(ctrl)
-	s := service{store: m}
+	s := service{store: m, now: clock}
 	app := gofr.New()
-	_ = New(m)
+	dept := 1
+	_ = New(m, mocks.NewMockDeptStore(ctrl))
 
 	testcases := []struct {
 		desc   string
+		filter model.Filter
 		output []model.Employee
 		err    error
 		mock   []*gomock.Call
 	}{
-		{"success", []model.Employee{{ID: 2, Age: 21, Name: "Ram"}}, nil, []*gomock.Call{
-			m.EXPECT().EmpGet(gomock.Any()).Return([]model.Employee{{ID: 2, Age: 21, Name: "Ram"}}, nil),
+		{"success", model.Filter{DepartmentID: &dept}, []model.Employee{{ID: 2, Age: 21, Name: "Ram"}}, nil, []*gomock.Call{
+			m.EXPECT().EmpGet(gomock.Any(), model.Filter{DepartmentID: &dept}).
+				Return([]model.Employee{{ID: 2, Age: 21, Name: "Ram"}}, nil),
 		}},
-		{desc: "failure", output: nil, err: errors.Error("Connect Failed"), mock: []*gomock.Call{
-			m.EXPECT().EmpGet(gomock.Any()).Return(nil, errors.Error("Connect Failed"))}},
+		{desc: "failure", output: nil, err: domain.Unavailable{Err: errors.Error("Connect Failed")}, mock: []*gomock.Call{
+			m.EXPECT().EmpGet(gomock.Any(), model.Filter{}).Return(nil, errors.Error("Connect Failed"))}},
+		{desc: "invalid status", filter: model.Filter{Status: "retired"}, err: errors.InvalidParam{Param: []string{"status"}}},
+		{desc: "limit too large", filter: model.Filter{Limit: model.MaxPageSize + 1}, err: errors.InvalidParam{Param: []string{"limit"}}},
+		{desc: "negative limit", filter: model.Filter{Limit: -1}, err: errors.InvalidParam{Param: []string{"limit"}}},
 	}
 
 	for i, tc := range testcases {
@@ -40,7 +61,7 @@ func TestService_GetEmp(t *testing.T) {
 		ctx.Context = context.Background()
 
 		t.Run(tc.desc, func(t *testing.T) {
-			resp, err := s.GetEmp(ctx)
+			resp, err := s.GetEmp(ctx, tc.filter)
 
 			if !reflect.DeepEqual(tc.output, resp) {
 				t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
@@ -56,7 +77,7 @@ func TestService_GetEmp(t *testing.T) {
 func TestService_GetEmpByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	m := mocks.NewMockEmpStore(ctrl)
-	s := service{store: m}
+	s := service{store: m, now: clock}
 	app := gofr.New()
 
 	testcases := []struct {
@@ -69,8 +90,11 @@ func TestService_GetEmpByID(t *testing.T) {
 		{desc: "Success", id: 1, output: model.Employee{ID: 1, Age: 21, Name: "Ram"}, mock: []*gomock.Call{m.EXPECT().
 			EmpGetByID(gomock.Any(), gomock.Any()).Return(model.Employee{ID: 1, Age: 21, Name: "Ram"}, nil),
 		}},
-		{"Failure", 2, model.Employee{}, errors.Error("Connect Failed"), []*gomock.Call{m.EXPECT().
-			EmpGetByID(gomock.Any(), gomock.Any()).Return(model.Employee{}, errors.Error("Connect Failed")),
+		{"Failure", 2, model.Employee{}, domain.Unavailable{Err: errors.DB{}}, []*gomock.Call{m.EXPECT().
+			EmpGetByID(gomock.Any(), gomock.Any()).Return(model.Employee{}, errors.DB{}),
+		}},
+		{"Not found", 3, model.Employee{}, errors.EntityNotFound{Entity: "employee", ID: "3"}, []*gomock.Call{m.EXPECT().
+			EmpGetByID(gomock.Any(), gomock.Any()).Return(model.Employee{}, errors.EntityNotFound{Entity: "employee", ID: "3"}),
 		}},
 	}
 
@@ -95,8 +119,26 @@ func TestService_GetEmpByID(t *testing.T) {
 func TestService_CreateEmp(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	m := mocks.NewMockEmpStore(ctrl)
-	s := service{store: m}
+	d := mocks.NewMockDeptStore(ctrl)
+	s := service{store: m, deptStore: d, now: clock}
 	app := gofr.New()
+	unavailable := domain.Unavailable{Err: errors.Error("Connect Failed")}
+
+	input := validEmployee(1)
+	input.CreatedBy, input.Status = "someone", ""
+
+	stored := validEmployee(1)
+	stored.CreatedBy, stored.UpdatedBy = "ram", "ram"
+
+	invalid := validEmployee(2)
+	invalid.Email, invalid.Status, invalid.DateOfBirth = "ram", "retired", model.NewDate(2010, time.January, 1)
+
+	unknownDept, noDept := validEmployee(3), validEmployee(4)
+	*unknownDept.DepartmentID, *noDept.DepartmentID = 9, 10
+
+	unknownManager, deletedManager := validEmployee(6), validEmployee(7)
+	managerID := 99
+	unknownManager.ManagerID, deletedManager.ManagerID = &managerID, &managerID
 
 	testcases := []struct {
 		desc   string
@@ -105,16 +147,40 @@ func TestService_CreateEmp(t *testing.T) {
 		err    error
 		mock   []*gomock.Call
 	}{
-		{desc: "Success", input: model.Employee{ID: 1, Age: 20, Name: "Ram"}, output: model.Employee{ID: 1, Age: 20, Name: "Ram"},
-			mock: []*gomock.Call{m.EXPECT().EmpCreate(gomock.Any(), gomock.Any()).Return(model.Employee{ID: 1, Age: 20, Name: "Ram"}, nil)}},
-		{"Failure", model.Employee{2, 20, "Sai"}, model.Employee{},
-			errors.Error("Connect Failed"), []*gomock.Call{m.EXPECT().EmpCreate(gomock.Any(), gomock.Any()).
-				Return(model.Employee{}, errors.Error("Connect Failed"))}},
+		{desc: "Success", input: input, output: stored, mock: []*gomock.Call{
+			d.EXPECT().DeptGetByID(gomock.Any(), 1).Return(model.Department{ID: 1, Name: "Engineering"}, nil),
+			m.EXPECT().EmpCreate(gomock.Any(), stored, event(model.EmployeeCreated)).Return(stored, nil)}},
+		{"Failure", validEmployee(2), model.Employee{}, unavailable, []*gomock.Call{
+			d.EXPECT().DeptGetByID(gomock.Any(), 1).Return(model.Department{ID: 1, Name: "Engineering"}, nil),
+			m.EXPECT().EmpCreate(gomock.Any(), gomock.Any(), gomock.Any()).Return(model.Employee{}, errors.Error("Connect Failed"))}},
+		{"Invalid", invalid, model.Employee{}, errors.InvalidParam{Param: []string{"email", "status", "date_of_birth"}}, nil},
+		{"Duplicate email", validEmployee(5), model.Employee{}, domain.Conflict{Entity: "employee", Field: "email"},
+			[]*gomock.Call{
+				d.EXPECT().DeptGetByID(gomock.Any(), 1).Return(model.Department{ID: 1, Name: "Engineering"}, nil),
+				m.EXPECT().EmpCreate(gomock.Any(), gomock.Any(), gomock.Any()).Return(model.Employee{},
+					domain.Conflict{Entity: "employee", Field: "email"})}},
+		{"Unknown department", unknownDept, model.Employee{}, errors.InvalidParam{Param: []string{"department_id"}},
+			[]*gomock.Call{d.EXPECT().DeptGetByID(gomock.Any(), 9).
+				Return(model.Department{}, errors.EntityNotFound{Entity: "department", ID: "9"})}},
+		{"Department lookup failure", noDept, model.Employee{}, domain.Unavailable{Err: errors.DB{}},
+			[]*gomock.Call{d.EXPECT().DeptGetByID(gomock.Any(), 10).Return(model.Department{}, errors.DB{})}},
+		{"Unknown manager", unknownManager, model.Employee{}, errors.InvalidParam{Param: []string{"manager_id"}},
+			[]*gomock.Call{
+				d.EXPECT().DeptGetByID(gomock.Any(), 1).Return(model.Department{ID: 1, Name: "Engineering"}, nil),
+				m.EXPECT().EmpGetByID(gomock.Any(), 99).Return(model.Employee{},
+					errors.EntityNotFound{Entity: "employee", ID: "99"})}},
+		{"Manager deleted concurrently", deletedManager, model.Employee{},
+			errors.InvalidParam{Param: []string{"manager_id"}}, []*gomock.Call{
+				d.EXPECT().DeptGetByID(gomock.Any(), 1).Return(model.Department{ID: 1, Name: "Engineering"}, nil),
+				m.EXPECT().EmpGetByID(gomock.Any(), 99).Return(model.Employee{ID: 99}, nil),
+				m.EXPECT().EmpCreate(gomock.Any(), gomock.Any(), gomock.Any()).Return(model.Employee{},
+					errors.InvalidParam{Param: []string{"manager_id"}})}},
 	}
 
 	for i, tc := range testcases {
 		tc := tc
 		cxt := gofr.NewContext(nil, nil, app)
+		cxt.Context = requestctx.WithRequestID(requestctx.WithPrincipal(context.Background(), "ram"), "req-1")
 
 		t.Run(tc.desc, func(t *testing.T) {
 			resp, err := s.CreateEmp(cxt, tc.input)
@@ -133,8 +199,25 @@ func TestService_CreateEmp(t *testing.T) {
 func TestService_UpdateEmp(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	m := mocks.NewMockEmpStore(ctrl)
-	s := service{store: m}
+	d := mocks.NewMockDeptStore(ctrl)
+	s := service{store: m, deptStore: d, now: clock}
 	app := gofr.New()
+	unavailable := domain.Unavailable{Err: errors.Error("Connect Failed")}
+
+	stored := validEmployee(1)
+	stored.UpdatedBy = "ram"
+
+	manager := 5
+
+	cyclic := validEmployee(1)
+	cyclic.ManagerID = &manager
+
+	unknownManager := validEmployee(2)
+	unknownManager.ManagerID = &manager
+
+	selfManaged := validEmployee(3)
+	selfManaged.ManagerID = &selfManaged.ID
+	selfManaged.Name, selfManaged.HireDate = "", model.NewDate(1999, time.January, 1)
 
 	testcases := []struct {
 		desc   string
@@ -144,18 +227,31 @@ func TestService_UpdateEmp(t *testing.T) {
 		err    error
 		mock   []*gomock.Call
 	}{
-		{"success", 1, model.Employee{1, 21, "Ram"},
-			model.Employee{1, 21, "Ram"}, nil, []*gomock.Call{m.EXPECT().
-				EmpUpdate(gomock.Any(), gomock.Any()).Return(model.Employee{1, 21, "Ram"}, nil),
-			}},
-		{"Failure", 2, model.Employee{2, 22, "sai"}, model.Employee{},
-			errors.Error("Connect Failed"), []*gomock.Call{m.EXPECT().EmpUpdate(gomock.Any(), gomock.Any()).
-				Return(model.Employee{}, errors.Error("Connect Failed"))}},
+		{"success", 1, validEmployee(1), stored, nil, []*gomock.Call{
+			d.EXPECT().DeptGetByID(gomock.Any(), 1).Return(model.Department{ID: 1, Name: "Engineering"}, nil),
+			m.EXPECT().EmpUpdate(gomock.Any(), stored, event(model.EmployeeUpdated)).Return(stored, nil),
+		}},
+		{"Failure", 2, validEmployee(2), model.Employee{}, unavailable, []*gomock.Call{
+			d.EXPECT().DeptGetByID(gomock.Any(), 1).Return(model.Department{ID: 1, Name: "Engineering"}, nil),
+			m.EXPECT().EmpUpdate(gomock.Any(), gomock.Any(), gomock.Any()).Return(model.Employee{}, errors.Error("Connect Failed"))}},
+		{"Invalid", 3, selfManaged, model.Employee{}, errors.InvalidParam{Param: []string{"name", "manager_id", "hire_date"}}, nil},
+		{"Cycle", 1, cyclic, model.Employee{}, errors.InvalidParam{Param: []string{"manager_id"}}, []*gomock.Call{
+			d.EXPECT().DeptGetByID(gomock.Any(), 1).Return(model.Department{ID: 1, Name: "Engineering"}, nil),
+			m.EXPECT().EmpGetByID(gomock.Any(), 5).Return(model.Employee{ID: 5}, nil),
+			m.EXPECT().EmpUpdate(gomock.Any(), gomock.Any(), gomock.Any()).Return(model.Employee{},
+				errors.InvalidParam{Param: []string{"manager_id"}}),
+		}},
+		{"Unknown manager", 2, unknownManager, model.Employee{}, errors.InvalidParam{Param: []string{"manager_id"}},
+			[]*gomock.Call{
+				d.EXPECT().DeptGetByID(gomock.Any(), 1).Return(model.Department{ID: 1, Name: "Engineering"}, nil),
+				m.EXPECT().EmpGetByID(gomock.Any(), 5).Return(model.Employee{},
+					errors.EntityNotFound{Entity: "employee", ID: "5"})}},
 	}
 
 	for i, tc := range testcases {
 		tc := tc
 		cxt := gofr.NewContext(nil, nil, app)
+		cxt.Context = requestctx.WithRequestID(requestctx.WithPrincipal(context.Background(), "ram"), "req-1")
 
 		t.Run(tc.desc, func(t *testing.T) {
 			resp, err := s.UpdateEmp(cxt, tc.input)
@@ -170,3 +266,30 @@ func TestService_UpdateEmp(t *testing.T) {
 		})
 	}
 }
+
+// eventMatcher matches the event of a change made by ram at clock, whatever its id. The store completes the event
+// with the employee it stored.
+type eventMatcher struct {
+	event model.Event
+}
+
+func event(t model.EventType) gomock.Matcher {
+	return eventMatcher{model.Event{Type: t, SchemaVersion: model.EventSchemaVersion, OccurredAt: clock(),
+		Principal: "ram", RequestID: "req-1"}}
+}
+
+func (m eventMatcher) Matches(x interface{}) bool {
+	p, ok := x.(*model.Event)
+	if !ok || p == nil || len(p.ID) != 32 {
+		return false
+	}
+
+	e := *p
+	e.ID = ""
+
+	return reflect.DeepEqual(m.event, e)
+}
+
+func (m eventMatcher) String() string {
+	return fmt.Sprintf("is the event %v", m.event)
+}
@@ -0,0 +1,132 @@
+package health
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/datastore"
+	"example/migrations"
+	"example/model"
+)
+
+// Dependency is something the service needs to serve requests, checked by calling Check before the deadline of its
+// context.
+type Dependency struct {
+	Name  string
+	Check func(ctx *gofr.Context) error
+}
+
+type service struct {
+	dependencies []Dependency
+	timeout      time.Duration
+	draining     *int32
+	now          func() time.Time
+}
+
+// New returns the health service checking dependencies for readiness, failing each check that takes longer
+// than timeout.
+func New(timeout time.Duration, dependencies ...Dependency) service {
+	return service{dependencies: dependencies, timeout: timeout, draining: new(int32), now: time.Now}
+}
+
+// Live reports the service up for as long as it runs, so that the orchestrator only restarts processes that hang.
+func (s service) Live(ctx *gofr.Context) model.Health {
+	return model.Health{Status: model.HealthUp}
+}
+
+// Ready checks every dependency and reports the service up only when all of them are, and it is not draining.
+func (s service) Ready(ctx *gofr.Context) model.Health {
+	h := model.Health{Status: model.HealthUp, Dependencies: make(map[string]model.DependencyHealth, len(s.dependencies))}
+
+	for _, d := range s.dependencies {
+		dh := s.check(ctx, d)
+		if dh.Status != model.HealthUp {
+			h.Status = model.HealthDown
+		}
+
+		h.Dependencies[d.Name] = dh
+	}
+
+	if atomic.LoadInt32(s.draining) == 1 {
+		h.Status = model.HealthDraining
+	}
+
+	return h
+}
+
+// Drain makes Ready report the service draining from now on, so that it is taken out of rotation before it stops.
+func (s service) Drain() {
+	atomic.StoreInt32(s.draining, 1)
+}
+
+// check runs the check of d with a deadline of the timeout of the service, on its own copy of ctx: a check that
+// times out keeps running until it notices its deadline, and must not race with the caller or the other checks on
+// the spans it starts.
+func (s service) check(ctx *gofr.Context, d Dependency) model.DependencyHealth {
+	parent := ctx.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	deadline, cancel := context.WithTimeout(parent, s.timeout)
+	defer cancel()
+
+	c := *ctx
+	c.Context = deadline
+
+	start := s.now()
+	done := make(chan error, 1)
+
+	go func() { done <- d.Check(&c) }()
+
+	var err error
+
+	select {
+	case err = <-done:
+	case <-deadline.Done():
+	}
+
+	if deadline.Err() == context.DeadlineExceeded {
+		err = errors.Error("timed out after " + s.timeout.String())
+	}
+
+	dh := model.DependencyHealth{Status: model.HealthUp, LatencyMS: float64(s.now().Sub(start).Microseconds()) / 1000}
+
+	if err != nil {
+		dh.Status, dh.Error = model.HealthDown, err.Error()
+	}
+
+	return dh
+}
+
+// Database checks that the employee database answers.
+func Database(s datastore.EmpStore) Dependency {
+	return Dependency{Name: "database", Check: s.EmpPing}
+}
+
+// Migrations checks that every migration in all has been applied to the database, within the deadline of ctx.
+func Migrations(all []migrations.Migration) Dependency {
+	return Dependency{Name: "migrations", Check: func(ctx *gofr.Context) error {
+		missing, err := migrations.Missing(ctx, ctx.DB().DB, all)
+		if err != nil {
+			return err
+		}
+
+		if len(missing) == 0 {
+			return nil
+		}
+
+		versions := make([]string, len(missing))
+		for i, m := range missing {
+			versions[i] = strconv.Itoa(m.Version) + "_" + m.Name
+		}
+
+		return errors.Error("pending migrations " + strings.Join(versions, ", "))
+	}}
+}
@@ -0,0 +1,162 @@
+package health
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+
+	"developer.zopsmart.com/go/gofr/pkg/datastore"
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/datastore/mocks"
+	"example/migrations"
+	"example/model"
+)
+
+// clock advances by a millisecond on every reading, so that every check takes exactly one millisecond.
+func clock() func() time.Time {
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+	return func() time.Time {
+		now = now.Add(time.Millisecond)
+		return now
+	}
+}
+
+func dependency(name string, err error) Dependency {
+	return Dependency{Name: name, Check: func(*gofr.Context) error { return err }}
+}
+
+func TestService_Ready(t *testing.T) {
+	hang := Dependency{Name: "cache", Check: func(c *gofr.Context) error {
+		<-c.Done()
+		return c.Err()
+	}}
+
+	up := model.DependencyHealth{Status: model.HealthUp, LatencyMS: 1}
+
+	testcases := []struct {
+		desc         string
+		dependencies []Dependency
+		drain        bool
+		output       model.Health
+	}{
+		{"up", []Dependency{dependency("database", nil), dependency("migrations", nil)}, false,
+			model.Health{Status: model.HealthUp, Dependencies: map[string]model.DependencyHealth{"database": up,
+				"migrations": up}}},
+		{"down", []Dependency{dependency("database", nil), dependency("migrations", errors.Error("pending migrations 4"))},
+			false, model.Health{Status: model.HealthDown, Dependencies: map[string]model.DependencyHealth{"database": up,
+				"migrations": {Status: model.HealthDown, LatencyMS: 1, Error: "pending migrations 4"}}}},
+		{"timed out", []Dependency{hang}, false, model.Health{Status: model.HealthDown,
+			Dependencies: map[string]model.DependencyHealth{"cache": {Status: model.HealthDown, LatencyMS: 1,
+				Error: "timed out after 10ms"}}}},
+		{"draining", []Dependency{dependency("database", nil)}, true, model.Health{Status: model.HealthDraining,
+			Dependencies: map[string]model.DependencyHealth{"database": up}}},
+	}
+
+	for i, tc := range testcases {
+		s := New(10*time.Millisecond, tc.dependencies...)
+		s.now = clock()
+
+		if tc.drain {
+			s.Drain()
+		}
+
+		ctx := gofr.NewContext(nil, nil, gofr.New())
+
+		if output := s.Ready(ctx); !reflect.DeepEqual(tc.output, output) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, output)
+		}
+
+		if live := s.Live(ctx); live.Status != model.HealthUp {
+			t.Errorf("[Test %v]Failed.Expected live but Got %v", i+1, live)
+		}
+	}
+}
+
+type spanKey struct{}
+
+func TestService_ReadyContext(t *testing.T) {
+	var deadlines []bool
+
+	// like tracing.Start, the checks replace the context they are given with one carrying their span
+	span := func(name string) Dependency {
+		return Dependency{Name: name, Check: func(c *gofr.Context) error {
+			_, ok := c.Deadline()
+			deadlines = append(deadlines, ok)
+			c.Context = context.WithValue(c.Context, spanKey{}, name)
+
+			return nil
+		}}
+	}
+
+	s := New(time.Second, span("database"), span("migrations"))
+	ctx := gofr.NewContext(nil, nil, gofr.New())
+	ctx.Context = context.Background()
+
+	if h := s.Ready(ctx); h.Status != model.HealthUp {
+		t.Errorf("[Test 1]Failed.Expected up but Got %v", h)
+	}
+
+	if !reflect.DeepEqual([]bool{true, true}, deadlines) {
+		t.Errorf("[Test 2]Failed.Expected every check to have a deadline but Got %v", deadlines)
+	}
+
+	if ctx.Context != context.Background() {
+		t.Errorf("[Test 3]Failed.Expected the checks to leave the context of the caller alone but Got %v", ctx.Context)
+	}
+}
+
+func TestDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockEmpStore(ctrl)
+	ctx := gofr.NewContext(nil, nil, gofr.New())
+
+	m.EXPECT().EmpPing(ctx).Return(errors.DB{})
+
+	d := Database(m)
+
+	if err := d.Check(ctx); d.Name != "database" || !reflect.DeepEqual(errors.DB{}, err) {
+		t.Errorf("[Test 1]Failed.Expected database to fail but Got %v %v", d.Name, err)
+	}
+}
+
+func TestMigrations(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("database error %v", err)
+	}
+
+	defer db.Close()
+
+	ctx := gofr.NewContext(nil, nil, &gofr.Gofr{DataStore: datastore.DataStore{ORM: db}})
+	ctx.Context = context.Background()
+
+	all := []migrations.Migration{{Version: 1, Name: "create_employee"}, {Version: 2, Name: "employee_profile"},
+		{Version: 3, Name: "department"}}
+	query := "select version from schema_migrations"
+
+	testcases := []struct {
+		desc string
+		err  error
+		mock *sqlmock.ExpectedQuery
+	}{
+		{"applied", nil, mock.ExpectQuery(query).
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1).AddRow(2).AddRow(3))},
+		{"pending", errors.Error("pending migrations 2_employee_profile, 3_department"),
+			mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))},
+		{"unreachable", errors.DB{Err: errors.Error("connection refused")},
+			mock.ExpectQuery(query).WillReturnError(errors.Error("connection refused"))},
+	}
+
+	for i, tc := range testcases {
+		if err := Migrations(all).Check(ctx); !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package idempotency
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/datastore"
+	"example/domain"
+	"example/model"
+	"example/requestctx"
+)
+
+type service struct {
+	store datastore.IdempotencyStore
+	ttl   time.Duration
+	lock  time.Duration
+	now   func() time.Time
+}
+
+// New returns a service that remembers the response of a request for ttl after it was first made. A request holds
+// its key for at most lock before recording its response: a key held longer belongs to a request that will never
+// finish, such as one whose process was killed, and is taken over by the next request with the key.
+func New(s datastore.IdempotencyStore, ttl, lock time.Duration) service {
+	return service{store: s, ttl: ttl, lock: lock, now: time.Now}
+}
+
+// Do runs fn once for the key of the authenticated principal. Retries with the same request hash get the original
+// response back, as raw JSON, without running fn again. Reusing the key for a different request fails with 422,
+// and retrying while the first request is still running fails with 409. Failed requests do not keep their key,
+// so they can be retried, and neither does a request whose response could not be recorded, once its lock expired.
+func (s service) Do(ctx *gofr.Context, key, hash string, fn func() (interface{}, error)) (interface{}, error) {
+	k := model.IdempotencyKey{Principal: requestctx.Principal(ctx), ID: key, RequestHash: hash}
+
+	replay, err := s.reserve(ctx, k)
+	if err != nil || replay != nil {
+		return replay, err
+	}
+
+	resp, err := fn()
+	if err != nil {
+		if err := s.store.KeyDelete(ctx, k.Principal, k.ID); err != nil {
+			ctx.Logger.Errorf("failed to release idempotency key %s: %v", k.ID, err)
+		}
+
+		return nil, err
+	}
+
+	if k.Response, err = json.Marshal(resp); err == nil {
+		err = s.store.KeyUpdate(ctx, k)
+	}
+
+	if err != nil {
+		ctx.Logger.Errorf("failed to record response for idempotency key %s: %v", k.ID, err)
+	}
+
+	return resp, nil
+}
+
+// reserve claims the key for a new request. When the key is already taken by an earlier request it returns the
+// response of that request instead, taking the key over once it expired.
+func (s service) reserve(ctx *gofr.Context, k model.IdempotencyKey) (interface{}, error) {
+	err := s.store.KeyCreate(ctx, k)
+	if _, ok := err.(domain.Conflict); !ok {
+		return nil, storeError(err)
+	}
+
+	existing, err := s.store.KeyGet(ctx, k.Principal, k.ID)
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	if s.expired(existing) {
+		replaced, err := s.store.KeyReplace(ctx, existing, k)
+		if err != nil {
+			return nil, storeError(err)
+		}
+
+		// another request took the key over first
+		if !replaced {
+			return nil, inProgress()
+		}
+
+		return nil, nil
+	}
+
+	switch {
+	case existing.RequestHash != k.RequestHash:
+		return nil, &errors.Response{
+			StatusCode: http.StatusUnprocessableEntity,
+			Code:       "Unprocessable Entity",
+			Reason:     "Idempotency-Key " + k.ID + " was already used for a different request",
+		}
+	case existing.Response == nil:
+		return nil, inProgress()
+	default:
+		return json.RawMessage(existing.Response), nil
+	}
+}
+
+// expired reports whether k is older than the ttl or, while its request is still running, than the lock.
+func (s service) expired(k model.IdempotencyKey) bool {
+	age := s.now().Sub(k.CreatedAt)
+
+	return (s.ttl > 0 && age > s.ttl) || (k.Response == nil && s.lock > 0 && age > s.lock)
+}
+
+// Purge deletes the keys older than the ttl now and every interval after until ctx is done, so that keys which are
+// never reused do not pile up. Keys are kept forever when the ttl is 0.
+func (s service) Purge(ctx *gofr.Context, interval time.Duration) {
+	if s.ttl <= 0 {
+		return
+	}
+
+	for {
+		if n, err := s.store.KeyPurge(ctx, s.now().Add(-s.ttl)); err != nil {
+			ctx.Logger.Errorf("failed to purge expired idempotency keys: %v", err)
+		} else if n > 0 {
+			ctx.Logger.Infof("purged %d expired idempotency keys", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+func inProgress() error {
+	return domain.Conflict{Entity: "idempotency key",
+		Reason: "a request with the same Idempotency-Key is still in progress"}
+}
+
+func storeError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return domain.Unavailable{Err: err}
+}
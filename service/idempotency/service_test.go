@@ -0,0 +1,135 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/datastore/mocks"
+	"example/domain"
+	"example/model"
+	"example/requestctx"
+)
+
+func TestService_Do(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockIdempotencyStore(ctrl)
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	s := service{store: m, ttl: time.Hour, lock: time.Minute, now: func() time.Time { return now }}
+	_ = New(m, time.Hour, time.Minute)
+
+	app := gofr.New()
+	taken := domain.Conflict{Entity: "idempotency key", Field: "id"}
+	emp := map[string]interface{}{"id": 1, "name": "Ram"}
+	created := func() (interface{}, error) { return emp, nil }
+	failed := func() (interface{}, error) { return nil, errors.InvalidParam{Param: []string{"email"}} }
+	key := model.IdempotencyKey{Principal: "ram", ID: "a", RequestHash: "h"}
+	done := key
+	done.Response = []byte(`{"id":1,"name":"Ram"}`)
+	expired := model.IdempotencyKey{Principal: "ram", ID: "a", RequestHash: "other", Response: done.Response,
+		CreatedAt: now.Add(-2 * time.Hour)}
+	abandoned := model.IdempotencyKey{Principal: "ram", ID: "a", RequestHash: "h", CreatedAt: now.Add(-2 * time.Minute)}
+
+	testcases := []struct {
+		desc   string
+		fn     func() (interface{}, error)
+		output interface{}
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"first request", created, emp, nil, []*gomock.Call{
+			m.EXPECT().KeyCreate(gomock.Any(), key).Return(nil),
+			m.EXPECT().KeyUpdate(gomock.Any(), done).Return(nil),
+		}},
+		{"failed request", failed, nil, errors.InvalidParam{Param: []string{"email"}}, []*gomock.Call{
+			m.EXPECT().KeyCreate(gomock.Any(), key).Return(nil),
+			m.EXPECT().KeyDelete(gomock.Any(), "ram", "a").Return(nil),
+		}},
+		{"retry", created, json.RawMessage(done.Response), nil, []*gomock.Call{
+			m.EXPECT().KeyCreate(gomock.Any(), key).Return(taken),
+			m.EXPECT().KeyGet(gomock.Any(), "ram", "a").Return(model.IdempotencyKey{Principal: "ram", ID: "a",
+				RequestHash: "h", Response: done.Response, CreatedAt: now.Add(-time.Minute)}, nil),
+		}},
+		{"different request", created, nil, &errors.Response{StatusCode: http.StatusUnprocessableEntity,
+			Code: "Unprocessable Entity", Reason: "Idempotency-Key a was already used for a different request"}, []*gomock.Call{
+			m.EXPECT().KeyCreate(gomock.Any(), key).Return(taken),
+			m.EXPECT().KeyGet(gomock.Any(), "ram", "a").Return(model.IdempotencyKey{Principal: "ram", ID: "a",
+				RequestHash: "other", Response: done.Response, CreatedAt: now}, nil),
+		}},
+		{"in progress", created, nil, inProgress(), []*gomock.Call{
+			m.EXPECT().KeyCreate(gomock.Any(), key).Return(taken),
+			m.EXPECT().KeyGet(gomock.Any(), "ram", "a").Return(model.IdempotencyKey{Principal: "ram", ID: "a",
+				RequestHash: "h", CreatedAt: now}, nil),
+		}},
+		{"expired", created, emp, nil, []*gomock.Call{
+			m.EXPECT().KeyCreate(gomock.Any(), key).Return(taken),
+			m.EXPECT().KeyGet(gomock.Any(), "ram", "a").Return(expired, nil),
+			m.EXPECT().KeyReplace(gomock.Any(), expired, key).Return(true, nil),
+			m.EXPECT().KeyUpdate(gomock.Any(), done).Return(nil),
+		}},
+		{"abandoned", created, emp, nil, []*gomock.Call{
+			m.EXPECT().KeyCreate(gomock.Any(), key).Return(taken),
+			m.EXPECT().KeyGet(gomock.Any(), "ram", "a").Return(abandoned, nil),
+			m.EXPECT().KeyReplace(gomock.Any(), abandoned, key).Return(true, nil),
+			m.EXPECT().KeyUpdate(gomock.Any(), done).Return(nil),
+		}},
+		{"taken over concurrently", created, nil, inProgress(), []*gomock.Call{
+			m.EXPECT().KeyCreate(gomock.Any(), key).Return(taken),
+			m.EXPECT().KeyGet(gomock.Any(), "ram", "a").Return(abandoned, nil),
+			m.EXPECT().KeyReplace(gomock.Any(), abandoned, key).Return(false, nil),
+		}},
+		{"store failure", created, nil, domain.Unavailable{Err: errors.Error("Internal DB Error")}, []*gomock.Call{
+			m.EXPECT().KeyCreate(gomock.Any(), key).Return(errors.Error("Internal DB Error")),
+		}},
+	}
+
+	for i, tc := range testcases {
+		ctx := gofr.NewContext(nil, nil, app)
+		ctx.Context = requestctx.WithPrincipal(context.Background(), "ram")
+
+		resp, err := s.Do(ctx, "a", "h", tc.fn)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestService_Purge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockIdempotencyStore(ctrl)
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+	c, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ctx := gofr.NewContext(nil, nil, gofr.New())
+	ctx.Context = c
+
+	// purges at once and on every interval, until the second purge cancels the context
+	gomock.InOrder(
+		m.EXPECT().KeyPurge(gomock.Any(), now.Add(-time.Hour)).Return(int64(0), errors.Error("Internal DB Error")),
+		m.EXPECT().KeyPurge(gomock.Any(), now.Add(-time.Hour)).DoAndReturn(func(*gofr.Context, time.Time) (int64,
+			error) {
+			cancel()
+			return 3, nil
+		}),
+	)
+
+	service{store: m, ttl: time.Hour, now: func() time.Time { return now }}.Purge(ctx, time.Millisecond)
+
+	// keys are kept forever without a ttl
+	service{store: m, now: func() time.Time { return now }}.Purge(ctx, time.Millisecond)
+}
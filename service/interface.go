@@ -7,8 +7,41 @@ import (
 )
 
 type EmpService interface {
-	GetEmp(ctx *gofr.Context) ([]model.Employee, error)
+	GetEmp(ctx *gofr.Context, filter model.Filter) ([]model.Employee, error)
 	GetEmpByID(ctx *gofr.Context, id int) (model.Employee, error)
 	CreateEmp(ctx *gofr.Context, employee model.Employee) (model.Employee, error)
 	UpdateEmp(ctx *gofr.Context, employee model.Employee) (model.Employee, error)
+	GetReports(ctx *gofr.Context, id int) ([]model.Employee, error)
+	GetSubtree(ctx *gofr.Context, id int) ([]model.Employee, error)
+	GetChain(ctx *gofr.Context, id int) ([]model.Employee, error)
+}
+
+type IdempotencyService interface {
+	Do(ctx *gofr.Context, key, hash string, fn func() (interface{}, error)) (interface{}, error)
+}
+
+type DeptService interface {
+	GetDept(ctx *gofr.Context) ([]model.Department, error)
+	GetDeptByID(ctx *gofr.Context, id int) (model.Department, error)
+	CreateDept(ctx *gofr.Context, department model.Department) (model.Department, error)
+	UpdateDept(ctx *gofr.Context, department model.Department) (model.Department, error)
+	DeleteDept(ctx *gofr.Context, id int) error
+	GetDeptEmployees(ctx *gofr.Context, id int) ([]model.Employee, error)
+}
+
+type HealthService interface {
+	Live(ctx *gofr.Context) model.Health
+	Ready(ctx *gofr.Context) model.Health
+	Drain()
+}
+
+// WebhookService manages the webhook subscriptions and their delivery logs. Secrets are accepted but never returned.
+type WebhookService interface {
+	GetWebhooks(ctx *gofr.Context) ([]model.Webhook, error)
+	GetWebhookByID(ctx *gofr.Context, id int) (model.Webhook, error)
+	CreateWebhook(ctx *gofr.Context, webhook model.Webhook) (model.Webhook, error)
+	UpdateWebhook(ctx *gofr.Context, webhook model.Webhook) (model.Webhook, error)
+	DeleteWebhook(ctx *gofr.Context, id int) error
+	GetDeliveries(ctx *gofr.Context, webhookID int, status model.DeliveryStatus, limit int) ([]model.Delivery, error)
+	ReplayDelivery(ctx *gofr.Context, webhookID int, id int64) (model.Delivery, error)
 }
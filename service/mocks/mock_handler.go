@@ -50,19 +50,34 @@ func (mr *MockEmpServiceMockRecorder) CreateEmp(ctx, employee interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmp", reflect.TypeOf((*MockEmpService)(nil).CreateEmp), ctx, employee)
 }
 
+// GetChain mocks base method.
+func (m *MockEmpService) GetChain(ctx *gofr.Context, id int) ([]model.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChain", ctx, id)
+	ret0, _ := ret[0].([]model.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChain indicates an expected call of GetChain.
+func (mr *MockEmpServiceMockRecorder) GetChain(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChain", reflect.TypeOf((*MockEmpService)(nil).GetChain), ctx, id)
+}
+
 // GetEmp mocks base method.
-func (m *MockEmpService) GetEmp(ctx *gofr.Context) ([]model.Employee, error) {
+func (m *MockEmpService) GetEmp(ctx *gofr.Context, filter model.Filter) ([]model.Employee, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEmp", ctx)
+	ret := m.ctrl.Call(m, "GetEmp", ctx, filter)
 	ret0, _ := ret[0].([]model.Employee)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetEmp indicates an expected call of GetEmp.
-func (mr *MockEmpServiceMockRecorder) GetEmp(ctx interface{}) *gomock.Call {
+func (mr *MockEmpServiceMockRecorder) GetEmp(ctx, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmp", reflect.TypeOf((*MockEmpService)(nil).GetEmp), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmp", reflect.TypeOf((*MockEmpService)(nil).GetEmp), ctx, filter)
 }
 
 // GetEmpByID mocks base method.
@@ -80,6 +95,36 @@ func (mr *MockEmpServiceMockRecorder) GetEmpByID(ctx, id interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmpByID", reflect.TypeOf((*MockEmpService)(nil).GetEmpByID), ctx, id)
 }
 
+// GetReports mocks base method.
+func (m *MockEmpService) GetReports(ctx *gofr.Context, id int) ([]model.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReports", ctx, id)
+	ret0, _ := ret[0].([]model.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReports indicates an expected call of GetReports.
+func (mr *MockEmpServiceMockRecorder) GetReports(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReports", reflect.TypeOf((*MockEmpService)(nil).GetReports), ctx, id)
+}
+
+// GetSubtree mocks base method.
+func (m *MockEmpService) GetSubtree(ctx *gofr.Context, id int) ([]model.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubtree", ctx, id)
+	ret0, _ := ret[0].([]model.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubtree indicates an expected call of GetSubtree.
+func (mr *MockEmpServiceMockRecorder) GetSubtree(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubtree", reflect.TypeOf((*MockEmpService)(nil).GetSubtree), ctx, id)
+}
+
 // UpdateEmp mocks base method.
 func (m *MockEmpService) UpdateEmp(ctx *gofr.Context, employee model.Employee) (model.Employee, error) {
 	m.ctrl.T.Helper()
@@ -94,3 +139,343 @@ func (mr *MockEmpServiceMockRecorder) UpdateEmp(ctx, employee interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmp", reflect.TypeOf((*MockEmpService)(nil).UpdateEmp), ctx, employee)
 }
+
+// MockIdempotencyService is a mock of IdempotencyService interface.
+type MockIdempotencyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyServiceMockRecorder
+}
+
+// MockIdempotencyServiceMockRecorder is the mock recorder for MockIdempotencyService.
+type MockIdempotencyServiceMockRecorder struct {
+	mock *MockIdempotencyService
+}
+
+// NewMockIdempotencyService creates a new mock instance.
+func NewMockIdempotencyService(ctrl *gomock.Controller) *MockIdempotencyService {
+	mock := &MockIdempotencyService{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyService) EXPECT() *MockIdempotencyServiceMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *MockIdempotencyService) Do(ctx *gofr.Context, key, hash string, fn func() (interface{}, error)) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", ctx, key, hash, fn)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockIdempotencyServiceMockRecorder) Do(ctx, key, hash, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockIdempotencyService)(nil).Do), ctx, key, hash, fn)
+}
+
+// MockDeptService is a mock of DeptService interface.
+type MockDeptService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeptServiceMockRecorder
+}
+
+// MockDeptServiceMockRecorder is the mock recorder for MockDeptService.
+type MockDeptServiceMockRecorder struct {
+	mock *MockDeptService
+}
+
+// NewMockDeptService creates a new mock instance.
+func NewMockDeptService(ctrl *gomock.Controller) *MockDeptService {
+	mock := &MockDeptService{ctrl: ctrl}
+	mock.recorder = &MockDeptServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeptService) EXPECT() *MockDeptServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateDept mocks base method.
+func (m *MockDeptService) CreateDept(ctx *gofr.Context, department model.Department) (model.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDept", ctx, department)
+	ret0, _ := ret[0].(model.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDept indicates an expected call of CreateDept.
+func (mr *MockDeptServiceMockRecorder) CreateDept(ctx, department interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDept", reflect.TypeOf((*MockDeptService)(nil).CreateDept), ctx, department)
+}
+
+// DeleteDept mocks base method.
+func (m *MockDeptService) DeleteDept(ctx *gofr.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDept", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDept indicates an expected call of DeleteDept.
+func (mr *MockDeptServiceMockRecorder) DeleteDept(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDept", reflect.TypeOf((*MockDeptService)(nil).DeleteDept), ctx, id)
+}
+
+// GetDept mocks base method.
+func (m *MockDeptService) GetDept(ctx *gofr.Context) ([]model.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDept", ctx)
+	ret0, _ := ret[0].([]model.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDept indicates an expected call of GetDept.
+func (mr *MockDeptServiceMockRecorder) GetDept(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDept", reflect.TypeOf((*MockDeptService)(nil).GetDept), ctx)
+}
+
+// GetDeptByID mocks base method.
+func (m *MockDeptService) GetDeptByID(ctx *gofr.Context, id int) (model.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeptByID", ctx, id)
+	ret0, _ := ret[0].(model.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeptByID indicates an expected call of GetDeptByID.
+func (mr *MockDeptServiceMockRecorder) GetDeptByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeptByID", reflect.TypeOf((*MockDeptService)(nil).GetDeptByID), ctx, id)
+}
+
+// GetDeptEmployees mocks base method.
+func (m *MockDeptService) GetDeptEmployees(ctx *gofr.Context, id int) ([]model.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeptEmployees", ctx, id)
+	ret0, _ := ret[0].([]model.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeptEmployees indicates an expected call of GetDeptEmployees.
+func (mr *MockDeptServiceMockRecorder) GetDeptEmployees(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeptEmployees", reflect.TypeOf((*MockDeptService)(nil).GetDeptEmployees), ctx, id)
+}
+
+// UpdateDept mocks base method.
+func (m *MockDeptService) UpdateDept(ctx *gofr.Context, department model.Department) (model.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDept", ctx, department)
+	ret0, _ := ret[0].(model.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDept indicates an expected call of UpdateDept.
+func (mr *MockDeptServiceMockRecorder) UpdateDept(ctx, department interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDept", reflect.TypeOf((*MockDeptService)(nil).UpdateDept), ctx, department)
+}
+
+// MockHealthService is a mock of HealthService interface.
+type MockHealthService struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthServiceMockRecorder
+}
+
+// MockHealthServiceMockRecorder is the mock recorder for MockHealthService.
+type MockHealthServiceMockRecorder struct {
+	mock *MockHealthService
+}
+
+// NewMockHealthService creates a new mock instance.
+func NewMockHealthService(ctrl *gomock.Controller) *MockHealthService {
+	mock := &MockHealthService{ctrl: ctrl}
+	mock.recorder = &MockHealthServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthService) EXPECT() *MockHealthServiceMockRecorder {
+	return m.recorder
+}
+
+// Drain mocks base method.
+func (m *MockHealthService) Drain() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Drain")
+}
+
+// Drain indicates an expected call of Drain.
+func (mr *MockHealthServiceMockRecorder) Drain() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drain", reflect.TypeOf((*MockHealthService)(nil).Drain))
+}
+
+// Live mocks base method.
+func (m *MockHealthService) Live(ctx *gofr.Context) model.Health {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Live", ctx)
+	ret0, _ := ret[0].(model.Health)
+	return ret0
+}
+
+// Live indicates an expected call of Live.
+func (mr *MockHealthServiceMockRecorder) Live(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Live", reflect.TypeOf((*MockHealthService)(nil).Live), ctx)
+}
+
+// Ready mocks base method.
+func (m *MockHealthService) Ready(ctx *gofr.Context) model.Health {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ready", ctx)
+	ret0, _ := ret[0].(model.Health)
+	return ret0
+}
+
+// Ready indicates an expected call of Ready.
+func (mr *MockHealthServiceMockRecorder) Ready(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ready", reflect.TypeOf((*MockHealthService)(nil).Ready), ctx)
+}
+
+// MockWebhookService is a mock of WebhookService interface.
+type MockWebhookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookServiceMockRecorder
+}
+
+// MockWebhookServiceMockRecorder is the mock recorder for MockWebhookService.
+type MockWebhookServiceMockRecorder struct {
+	mock *MockWebhookService
+}
+
+// NewMockWebhookService creates a new mock instance.
+func NewMockWebhookService(ctrl *gomock.Controller) *MockWebhookService {
+	mock := &MockWebhookService{ctrl: ctrl}
+	mock.recorder = &MockWebhookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookService) EXPECT() *MockWebhookServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhook mocks base method.
+func (m *MockWebhookService) CreateWebhook(ctx *gofr.Context, webhook model.Webhook) (model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", ctx, webhook)
+	ret0, _ := ret[0].(model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockWebhookServiceMockRecorder) CreateWebhook(ctx, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockWebhookService)(nil).CreateWebhook), ctx, webhook)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhookService) DeleteWebhook(ctx *gofr.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookServiceMockRecorder) DeleteWebhook(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhookService)(nil).DeleteWebhook), ctx, id)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockWebhookService) GetDeliveries(ctx *gofr.Context, webhookID int, status model.DeliveryStatus, limit int) ([]model.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", ctx, webhookID, status, limit)
+	ret0, _ := ret[0].([]model.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockWebhookServiceMockRecorder) GetDeliveries(ctx, webhookID, status, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhookService)(nil).GetDeliveries), ctx, webhookID, status, limit)
+}
+
+// GetWebhookByID mocks base method.
+func (m *MockWebhookService) GetWebhookByID(ctx *gofr.Context, id int) (model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookByID", ctx, id)
+	ret0, _ := ret[0].(model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookByID indicates an expected call of GetWebhookByID.
+func (mr *MockWebhookServiceMockRecorder) GetWebhookByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookByID", reflect.TypeOf((*MockWebhookService)(nil).GetWebhookByID), ctx, id)
+}
+
+// GetWebhooks mocks base method.
+func (m *MockWebhookService) GetWebhooks(ctx *gofr.Context) ([]model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", ctx)
+	ret0, _ := ret[0].([]model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *MockWebhookServiceMockRecorder) GetWebhooks(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockWebhookService)(nil).GetWebhooks), ctx)
+}
+
+// ReplayDelivery mocks base method.
+func (m *MockWebhookService) ReplayDelivery(ctx *gofr.Context, webhookID int, id int64) (model.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayDelivery", ctx, webhookID, id)
+	ret0, _ := ret[0].(model.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplayDelivery indicates an expected call of ReplayDelivery.
+func (mr *MockWebhookServiceMockRecorder) ReplayDelivery(ctx, webhookID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayDelivery", reflect.TypeOf((*MockWebhookService)(nil).ReplayDelivery), ctx, webhookID, id)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockWebhookService) UpdateWebhook(ctx *gofr.Context, webhook model.Webhook) (model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", ctx, webhook)
+	ret0, _ := ret[0].(model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockWebhookServiceMockRecorder) UpdateWebhook(ctx, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockWebhookService)(nil).UpdateWebhook), ctx, webhook)
+}
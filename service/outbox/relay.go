@@ -0,0 +1,117 @@
+// Package outbox relays the events stored in the outbox with the employee changes they record to a publisher.
+package outbox
+
+import (
+	"time"
+
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/datastore"
+	"example/model"
+)
+
+// Policy tunes the relay. Batch events are claimed at a time and reserved for Lease, after which another relay may
+// claim the ones not settled yet. A failed delivery is retried after Backoff, doubled for every further failure up to
+// MaxBackoff, and the event is dead once MaxAttempts deliveries failed.
+type Policy struct {
+	Batch       int
+	Lease       time.Duration
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+type relay struct {
+	store     datastore.OutboxStore
+	publisher datastore.Publisher
+	policy    Policy
+	now       func() time.Time
+}
+
+// New returns the relay publishing the events of s to p. Events are delivered at least once, and those of an employee
+// in the order they were stored in: an event is not delivered while an earlier one of its employee is pending.
+// nolint:revive // relay should not be used without proper initialization with required dependency
+func New(s datastore.OutboxStore, p datastore.Publisher, policy Policy) relay {
+	return relay{store: s, publisher: p, policy: policy, now: time.Now}
+}
+
+// Run relays batches of due events until ctx is done, waiting interval after every batch that was not full.
+func (r relay) Run(ctx *gofr.Context, interval time.Duration) {
+	for {
+		n, err := r.Relay(ctx)
+		if err != nil {
+			ctx.Logger.Errorf("failed to relay outbox events: %v", err)
+		}
+
+		wait := interval
+		if err == nil && n == r.policy.Batch {
+			wait = 0
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// Relay delivers a batch of due events and returns how many it claimed.
+func (r relay) Relay(ctx *gofr.Context) (int, error) {
+	events, err := r.store.OutboxClaim(ctx, r.policy.Batch, r.policy.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range events {
+		r.deliver(ctx, events[i])
+	}
+
+	return len(events), nil
+}
+
+// deliver publishes the event and settles it: delivered, due for another attempt, or dead after too many.
+func (r relay) deliver(ctx *gofr.Context, e model.OutboxEvent) {
+	err := r.publisher.Publish(ctx, e.Event)
+	if err == nil {
+		if err = r.store.OutboxDelivered(ctx, e.ID); err != nil {
+			ctx.Logger.Errorf("failed to mark event %s delivered: %v", e.Event.ID, err)
+		}
+
+		return
+	}
+
+	attempts := e.Attempts + 1
+	if attempts >= r.policy.MaxAttempts {
+		ctx.Logger.Errorf("giving up on event %s of employee %d after %d attempts: %v", e.Event.ID,
+			e.Event.Employee.ID, attempts, err)
+
+		err = r.store.OutboxDead(ctx, e.ID, err.Error())
+	} else {
+		retry := r.backoff(attempts)
+
+		ctx.Logger.Warnf("failed to deliver event %s of employee %d, retrying in %v: %v", e.Event.ID,
+			e.Event.Employee.ID, retry, err)
+
+		err = r.store.OutboxRetry(ctx, e.ID, r.now().Add(retry), err.Error())
+	}
+
+	if err != nil {
+		ctx.Logger.Errorf("failed to record the delivery failure of event %s: %v", e.Event.ID, err)
+	}
+}
+
+// backoff returns the delay before retrying an event whose delivery failed attempts times.
+func (r relay) backoff(attempts int) time.Duration {
+	d := r.policy.Backoff
+
+	for i := 1; i < attempts && d < r.policy.MaxBackoff; i++ {
+		d *= 2
+	}
+
+	if d > r.policy.MaxBackoff {
+		return r.policy.MaxBackoff
+	}
+
+	return d
+}
@@ -0,0 +1,98 @@
+package outbox
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+	"github.com/golang/mock/gomock"
+
+	"example/datastore/mocks"
+	"example/model"
+)
+
+func TestRelay_Relay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockOutboxStore(ctrl)
+	publisher := mocks.NewMockPublisher(ctrl)
+
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	policy := Policy{Batch: 10, Lease: time.Minute, MaxAttempts: 3, Backoff: time.Second, MaxBackoff: time.Minute}
+	r := relay{store: store, publisher: publisher, policy: policy, now: func() time.Time { return now }}
+
+	ctx := gofr.NewContext(nil, nil, gofr.New())
+	ctx.Context = context.Background()
+
+	first := model.OutboxEvent{ID: 1, Event: model.Event{ID: "e1", Employee: model.Employee{ID: 2}}}
+	second := model.OutboxEvent{ID: 2, Attempts: 1, Event: model.Event{ID: "e2", Employee: model.Employee{ID: 3}}}
+	last := model.OutboxEvent{ID: 3, Attempts: 2, Event: model.Event{ID: "e3", Employee: model.Employee{ID: 4}}}
+
+	testcases := []struct {
+		desc   string
+		output int
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"delivered", 1, nil, []*gomock.Call{
+			store.EXPECT().OutboxClaim(ctx, 10, time.Minute).Return([]model.OutboxEvent{first}, nil),
+			publisher.EXPECT().Publish(ctx, first.Event).Return(nil),
+			store.EXPECT().OutboxDelivered(ctx, int64(1)).Return(nil),
+		}},
+		{"retried", 1, nil, []*gomock.Call{
+			store.EXPECT().OutboxClaim(ctx, 10, time.Minute).Return([]model.OutboxEvent{second}, nil),
+			publisher.EXPECT().Publish(ctx, second.Event).Return(errors.Error("unreachable")),
+			store.EXPECT().OutboxRetry(ctx, int64(2), now.Add(2*time.Second), "unreachable").Return(nil),
+		}},
+		{"dead", 1, nil, []*gomock.Call{
+			store.EXPECT().OutboxClaim(ctx, 10, time.Minute).Return([]model.OutboxEvent{last}, nil),
+			publisher.EXPECT().Publish(ctx, last.Event).Return(errors.Error("unreachable")),
+			store.EXPECT().OutboxDead(ctx, int64(3), "unreachable").Return(nil),
+		}},
+		{"settle failure", 2, nil, []*gomock.Call{
+			store.EXPECT().OutboxClaim(ctx, 10, time.Minute).Return([]model.OutboxEvent{first, second}, nil),
+			publisher.EXPECT().Publish(ctx, first.Event).Return(nil),
+			store.EXPECT().OutboxDelivered(ctx, int64(1)).Return(errors.Error("Internal DB Error")),
+			publisher.EXPECT().Publish(ctx, second.Event).Return(nil),
+			store.EXPECT().OutboxDelivered(ctx, int64(2)).Return(nil),
+		}},
+		{"claim failure", 0, errors.DB{}, []*gomock.Call{
+			store.EXPECT().OutboxClaim(ctx, 10, time.Minute).Return(nil, errors.DB{}),
+		}},
+	}
+
+	for i, tc := range testcases {
+		n, err := r.Relay(ctx)
+
+		if n != tc.output {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, n)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestRelay_Backoff(t *testing.T) {
+	r := New(nil, nil, Policy{Backoff: time.Second, MaxBackoff: 10 * time.Second})
+
+	testcases := []struct {
+		attempts int
+		output   time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{4, 8 * time.Second},
+		{5, 10 * time.Second},
+		{40, 10 * time.Second},
+	}
+
+	for i, tc := range testcases {
+		if d := r.backoff(tc.attempts); d != tc.output {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, d)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package webhooks
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+)
+
+// sharedAddressSpace is the carrier-grade NAT range, private to the network of the service like RFC 1918 ranges.
+// nolint:gochecknoglobals // parsed once, never modified
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// public reports whether ip may be sent deliveries: webhooks must not reach the loopback, link-local (such as cloud
+// metadata endpoints), private or otherwise internal addresses of the network the service runs in.
+func public(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() || ip.IsPrivate() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip))
+}
+
+// publicOnly refuses connections to addresses that are not public. It runs once the host name is resolved, so names
+// resolving to internal addresses are refused as well.
+func publicOnly(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	if ip := net.ParseIP(host); ip == nil || !public(ip) {
+		return errors.Error("webhook address " + host + " is not allowed")
+	}
+
+	return nil
+}
+
+// newClient returns the client deliveries are sent with. It only connects to public addresses and does not follow
+// redirects, which would otherwise lead it to any address: a redirect is answered like any other non 2xx status.
+func newClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: publicOnly}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:       timeout,
+		Transport:     transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+}
+
+// internal reports whether host is known to be internal without resolving it: localhost or an address that is not
+// public. Names resolving to internal addresses are only refused when deliveries are sent.
+func internal(host string) bool {
+	if strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && !public(ip)
+}
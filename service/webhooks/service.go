@@ -0,0 +1,200 @@
+// Package webhooks manages the webhook subscriptions and sends them the events of employee changes.
+package webhooks
+
+import (
+	"net/url"
+	"strconv"
+
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/datastore"
+	"example/domain"
+	"example/model"
+	"example/requestctx"
+)
+
+// minSecretLength is the shortest secret a webhook can be signed with.
+const minSecretLength = 16
+
+// defaultDeliveries is the number of deliveries listed when no limit is given.
+const defaultDeliveries = 100
+
+type service struct {
+	store datastore.WebhookStore
+}
+
+// nolint:revive // service should not be used without proper initialization with required dependency
+func New(s datastore.WebhookStore) service {
+	return service{store: s}
+}
+
+// storeError passes missing webhooks and deliveries, and deliveries replayed concurrently, through to the caller, and
+// reports every other store error as the store being unavailable.
+func storeError(err error) error {
+	switch e := err.(type) {
+	case domain.NotFound, domain.Conflict:
+		return e
+	default:
+		return domain.Unavailable{Err: err}
+	}
+}
+
+// validate returns the fields of the webhook that are invalid. The secret may be left empty when updating.
+func validate(w model.Webhook, update bool) error {
+	var params []string
+
+	if u, err := url.Parse(w.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+		internal(u.Hostname()) {
+		params = append(params, "url")
+	}
+
+	if len(w.Secret) < minSecretLength && (!update || w.Secret != "") {
+		params = append(params, "secret")
+	}
+
+	for _, e := range w.Events {
+		if !e.Valid() {
+			params = append(params, "events")
+			break
+		}
+	}
+
+	if len(params) > 0 {
+		return domain.Invalid{Param: params}
+	}
+
+	return nil
+}
+
+// redact removes the secret from a webhook returned to the caller.
+func redact(w model.Webhook) model.Webhook {
+	w.Secret = ""
+	return w
+}
+
+func (s service) GetWebhooks(ctx *gofr.Context) ([]model.Webhook, error) {
+	resp, err := s.store.WebhookGet(ctx)
+
+	if err != nil {
+		return nil, domain.Unavailable{Err: err}
+	}
+
+	for i := range resp {
+		resp[i] = redact(resp[i])
+	}
+
+	return resp, nil
+}
+
+func (s service) GetWebhookByID(ctx *gofr.Context, id int) (model.Webhook, error) {
+	resp, err := s.store.WebhookGetByID(ctx, id)
+
+	if err != nil {
+		return model.Webhook{}, storeError(err)
+	}
+
+	return redact(resp), nil
+}
+
+func (s service) CreateWebhook(ctx *gofr.Context, webhook model.Webhook) (model.Webhook, error) {
+	if err := validate(webhook, false); err != nil {
+		return model.Webhook{}, err
+	}
+
+	webhook.CreatedBy = requestctx.Principal(ctx)
+
+	if webhook.Events == nil {
+		webhook.Events = []model.EventType{}
+	}
+
+	resp, err := s.store.WebhookCreate(ctx, webhook)
+
+	if err != nil {
+		return model.Webhook{}, storeError(err)
+	}
+
+	return redact(resp), nil
+}
+
+// UpdateWebhook replaces the url and events of the webhook, and its secret unless none is given.
+func (s service) UpdateWebhook(ctx *gofr.Context, webhook model.Webhook) (model.Webhook, error) {
+	if err := validate(webhook, true); err != nil {
+		return model.Webhook{}, err
+	}
+
+	if webhook.Secret == "" {
+		current, err := s.store.WebhookGetByID(ctx, webhook.ID)
+		if err != nil {
+			return model.Webhook{}, storeError(err)
+		}
+
+		webhook.Secret = current.Secret
+	}
+
+	resp, err := s.store.WebhookUpdate(ctx, webhook)
+
+	if err != nil {
+		return model.Webhook{}, storeError(err)
+	}
+
+	return redact(resp), nil
+}
+
+func (s service) DeleteWebhook(ctx *gofr.Context, id int) error {
+	if err := s.store.WebhookDelete(ctx, id); err != nil {
+		return storeError(err)
+	}
+
+	return nil
+}
+
+// GetDeliveries returns the delivery log of the webhook, latest first, only the deliveries with status unless it is
+// empty. At most limit deliveries are returned, 100 when limit is 0.
+func (s service) GetDeliveries(ctx *gofr.Context, webhookID int, status model.DeliveryStatus,
+	limit int) ([]model.Delivery, error) {
+	if status != "" && !status.Valid() {
+		return nil, domain.Invalid{Param: []string{"status"}}
+	}
+
+	if limit < 0 || limit > model.MaxPageSize {
+		return nil, domain.Invalid{Param: []string{"limit"}}
+	}
+
+	if limit == 0 {
+		limit = defaultDeliveries
+	}
+
+	if _, err := s.store.WebhookGetByID(ctx, webhookID); err != nil {
+		return nil, storeError(err)
+	}
+
+	resp, err := s.store.DeliveryGet(ctx, webhookID, status, limit)
+
+	if err != nil {
+		return nil, domain.Unavailable{Err: err}
+	}
+
+	return resp, nil
+}
+
+// ReplayDelivery sends a failed delivery again, with as many attempts as a new one. Only failed deliveries can be
+// replayed: the others are still being attempted or already succeeded.
+func (s service) ReplayDelivery(ctx *gofr.Context, webhookID int, id int64) (model.Delivery, error) {
+	d, err := s.store.DeliveryGetByID(ctx, webhookID, id)
+	if err != nil {
+		return model.Delivery{}, storeError(err)
+	}
+
+	if d.Status != model.DeliveryFailed {
+		return model.Delivery{}, domain.Conflict{Entity: "delivery", Reason: "delivery " + strconv.FormatInt(id, 10) +
+			" is " + string(d.Status) + ", only failed deliveries can be replayed"}
+	}
+
+	resp, err := s.store.DeliveryReplay(ctx, webhookID, id)
+
+	if err != nil {
+		return model.Delivery{}, storeError(err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,328 @@
+package webhooks
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/datastore/mocks"
+	"example/domain"
+	"example/model"
+	"example/requestctx"
+)
+
+const secret = "0123456789abcdef"
+
+func newContext() *gofr.Context {
+	ctx := gofr.NewContext(nil, nil, gofr.New())
+	ctx.Context = requestctx.WithPrincipal(context.Background(), "ram")
+
+	return ctx
+}
+
+func TestService_GetWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockWebhookStore(ctrl)
+	s := New(m)
+
+	testcases := []struct {
+		desc   string
+		output []model.Webhook
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"success", []model.Webhook{{ID: 1, URL: "https://example.com"}}, nil, []*gomock.Call{
+			m.EXPECT().WebhookGet(gomock.Any()).Return([]model.Webhook{{ID: 1, URL: "https://example.com",
+				Secret: secret}}, nil),
+		}},
+		{"failure", nil, domain.Unavailable{Err: errors.DB{}}, []*gomock.Call{
+			m.EXPECT().WebhookGet(gomock.Any()).Return(nil, errors.DB{}),
+		}},
+	}
+
+	for i, tc := range testcases {
+		resp, err := s.GetWebhooks(newContext())
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestService_GetWebhookByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockWebhookStore(ctrl)
+	s := New(m)
+	notFound := errors.EntityNotFound{Entity: "webhook", ID: "2"}
+
+	testcases := []struct {
+		desc   string
+		id     int
+		output model.Webhook
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"success", 1, model.Webhook{ID: 1, URL: "https://example.com"}, nil, []*gomock.Call{
+			m.EXPECT().WebhookGetByID(gomock.Any(), 1).Return(model.Webhook{ID: 1, URL: "https://example.com",
+				Secret: secret}, nil),
+		}},
+		{"not found", 2, model.Webhook{}, notFound, []*gomock.Call{
+			m.EXPECT().WebhookGetByID(gomock.Any(), 2).Return(model.Webhook{}, notFound),
+		}},
+		{"failure", 3, model.Webhook{}, domain.Unavailable{Err: errors.Error("Scan Error")}, []*gomock.Call{
+			m.EXPECT().WebhookGetByID(gomock.Any(), 3).Return(model.Webhook{}, errors.Error("Scan Error")),
+		}},
+	}
+
+	for i, tc := range testcases {
+		resp, err := s.GetWebhookByID(newContext(), tc.id)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestService_CreateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockWebhookStore(ctrl)
+	s := New(m)
+
+	input := model.Webhook{URL: "https://example.com/hook", Secret: secret, CreatedBy: "someone"}
+	stored := input
+	stored.CreatedBy, stored.Events = "ram", []model.EventType{}
+	created := stored
+	created.ID = 1
+	output := created
+	output.Secret = ""
+
+	testcases := []struct {
+		desc   string
+		input  model.Webhook
+		output model.Webhook
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"success", input, output, nil, []*gomock.Call{
+			m.EXPECT().WebhookCreate(gomock.Any(), stored).Return(created, nil),
+		}},
+		{"failure", input, model.Webhook{}, domain.Unavailable{Err: errors.Error("Internal DB Error")}, []*gomock.Call{
+			m.EXPECT().WebhookCreate(gomock.Any(), stored).Return(model.Webhook{}, errors.Error("Internal DB Error")),
+		}},
+		{"invalid", model.Webhook{URL: "ftp://example.com", Secret: "short", Events: []model.EventType{"Hired"}},
+			model.Webhook{}, errors.InvalidParam{Param: []string{"url", "secret", "events"}}, nil},
+		{"no host", model.Webhook{URL: "https://", Secret: secret}, model.Webhook{},
+			errors.InvalidParam{Param: []string{"url"}}, nil},
+		{"no secret", model.Webhook{URL: "https://example.com"}, model.Webhook{},
+			errors.InvalidParam{Param: []string{"secret"}}, nil},
+		{"loopback", model.Webhook{URL: "http://127.0.0.1:8000/hook", Secret: secret}, model.Webhook{},
+			errors.InvalidParam{Param: []string{"url"}}, nil},
+		{"localhost", model.Webhook{URL: "http://localhost/hook", Secret: secret}, model.Webhook{},
+			errors.InvalidParam{Param: []string{"url"}}, nil},
+		{"metadata", model.Webhook{URL: "http://169.254.169.254/latest", Secret: secret}, model.Webhook{},
+			errors.InvalidParam{Param: []string{"url"}}, nil},
+		{"private", model.Webhook{URL: "https://[fd00::1]/hook", Secret: secret}, model.Webhook{},
+			errors.InvalidParam{Param: []string{"url"}}, nil},
+	}
+
+	for i, tc := range testcases {
+		resp, err := s.CreateWebhook(newContext(), tc.input)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestService_UpdateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockWebhookStore(ctrl)
+	s := New(m)
+
+	events := []model.EventType{model.EmployeeUpdated}
+	stored := model.Webhook{ID: 1, URL: "https://example.com", Secret: secret, Events: events}
+	output := model.Webhook{ID: 1, URL: "https://example.com", Events: events}
+	notFound := errors.EntityNotFound{Entity: "webhook", ID: "2"}
+
+	testcases := []struct {
+		desc   string
+		input  model.Webhook
+		output model.Webhook
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"new secret", stored, output, nil, []*gomock.Call{
+			m.EXPECT().WebhookUpdate(gomock.Any(), stored).Return(stored, nil),
+		}},
+		{"kept secret", model.Webhook{ID: 1, URL: "https://example.com", Events: events}, output, nil, []*gomock.Call{
+			m.EXPECT().WebhookGetByID(gomock.Any(), 1).Return(model.Webhook{ID: 1, Secret: secret}, nil),
+			m.EXPECT().WebhookUpdate(gomock.Any(), stored).Return(stored, nil),
+		}},
+		{"not found", model.Webhook{ID: 2, URL: "https://example.com"}, model.Webhook{}, notFound, []*gomock.Call{
+			m.EXPECT().WebhookGetByID(gomock.Any(), 2).Return(model.Webhook{}, notFound),
+		}},
+		{"failure", stored, model.Webhook{}, domain.Unavailable{Err: errors.Error("Internal DB Error")}, []*gomock.Call{
+			m.EXPECT().WebhookUpdate(gomock.Any(), stored).Return(model.Webhook{}, errors.Error("Internal DB Error")),
+		}},
+		{"short secret", model.Webhook{ID: 1, URL: "https://example.com", Secret: "short"}, model.Webhook{},
+			errors.InvalidParam{Param: []string{"secret"}}, nil},
+	}
+
+	for i, tc := range testcases {
+		resp, err := s.UpdateWebhook(newContext(), tc.input)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestService_DeleteWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockWebhookStore(ctrl)
+	s := New(m)
+	notFound := errors.EntityNotFound{Entity: "webhook", ID: "2"}
+
+	testcases := []struct {
+		desc string
+		id   int
+		err  error
+		mock []*gomock.Call
+	}{
+		{"success", 1, nil, []*gomock.Call{m.EXPECT().WebhookDelete(gomock.Any(), 1).Return(nil)}},
+		{"not found", 2, notFound, []*gomock.Call{m.EXPECT().WebhookDelete(gomock.Any(), 2).Return(notFound)}},
+		{"failure", 3, domain.Unavailable{Err: errors.Error("Internal DB Error")}, []*gomock.Call{
+			m.EXPECT().WebhookDelete(gomock.Any(), 3).Return(errors.Error("Internal DB Error")),
+		}},
+	}
+
+	for i, tc := range testcases {
+		if err := s.DeleteWebhook(newContext(), tc.id); !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestService_GetDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockWebhookStore(ctrl)
+	s := New(m)
+
+	deliveries := []model.Delivery{{ID: 2, WebhookID: 1, Status: model.DeliveryFailed}}
+	notFound := errors.EntityNotFound{Entity: "webhook", ID: "2"}
+
+	testcases := []struct {
+		desc   string
+		id     int
+		status model.DeliveryStatus
+		limit  int
+		output []model.Delivery
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"default limit", 1, "", 0, deliveries, nil, []*gomock.Call{
+			m.EXPECT().WebhookGetByID(gomock.Any(), 1).Return(model.Webhook{ID: 1}, nil),
+			m.EXPECT().DeliveryGet(gomock.Any(), 1, model.DeliveryStatus(""), 100).Return(deliveries, nil),
+		}},
+		{"filtered", 1, model.DeliveryFailed, 5, deliveries, nil, []*gomock.Call{
+			m.EXPECT().WebhookGetByID(gomock.Any(), 1).Return(model.Webhook{ID: 1}, nil),
+			m.EXPECT().DeliveryGet(gomock.Any(), 1, model.DeliveryFailed, 5).Return(deliveries, nil),
+		}},
+		{"unknown webhook", 2, "", 0, nil, notFound, []*gomock.Call{
+			m.EXPECT().WebhookGetByID(gomock.Any(), 2).Return(model.Webhook{}, notFound),
+		}},
+		{"failure", 1, "", 0, nil, domain.Unavailable{Err: errors.DB{}}, []*gomock.Call{
+			m.EXPECT().WebhookGetByID(gomock.Any(), 1).Return(model.Webhook{ID: 1}, nil),
+			m.EXPECT().DeliveryGet(gomock.Any(), 1, model.DeliveryStatus(""), 100).Return(nil, errors.DB{}),
+		}},
+		{"invalid status", 1, "lost", 0, nil, errors.InvalidParam{Param: []string{"status"}}, nil},
+		{"invalid limit", 1, "", model.MaxPageSize + 1, nil, errors.InvalidParam{Param: []string{"limit"}}, nil},
+	}
+
+	for i, tc := range testcases {
+		resp, err := s.GetDeliveries(newContext(), tc.id, tc.status, tc.limit)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestService_ReplayDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mocks.NewMockWebhookStore(ctrl)
+	s := New(m)
+
+	failed := model.Delivery{ID: 2, WebhookID: 1, Status: model.DeliveryFailed, Attempts: 8}
+	pending := model.Delivery{ID: 2, WebhookID: 1, Status: model.DeliveryPending}
+	notFound := errors.EntityNotFound{Entity: "delivery", ID: "3"}
+	conflict := domain.Conflict{Entity: "delivery",
+		Reason: "delivery 2 is pending, only failed deliveries can be replayed"}
+
+	testcases := []struct {
+		desc   string
+		id     int64
+		output model.Delivery
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"success", 2, pending, nil, []*gomock.Call{
+			m.EXPECT().DeliveryGetByID(gomock.Any(), 1, int64(2)).Return(failed, nil),
+			m.EXPECT().DeliveryReplay(gomock.Any(), 1, int64(2)).Return(pending, nil),
+		}},
+		{"not failed", 4, model.Delivery{}, domain.Conflict{Entity: "delivery",
+			Reason: "delivery 4 is succeeded, only failed deliveries can be replayed"}, []*gomock.Call{
+			m.EXPECT().DeliveryGetByID(gomock.Any(), 1, int64(4)).Return(model.Delivery{ID: 4,
+				Status: model.DeliverySucceeded}, nil),
+		}},
+		{"not found", 3, model.Delivery{}, notFound, []*gomock.Call{
+			m.EXPECT().DeliveryGetByID(gomock.Any(), 1, int64(3)).Return(model.Delivery{}, notFound),
+		}},
+		{"replayed concurrently", 2, model.Delivery{}, conflict, []*gomock.Call{
+			m.EXPECT().DeliveryGetByID(gomock.Any(), 1, int64(2)).Return(failed, nil),
+			m.EXPECT().DeliveryReplay(gomock.Any(), 1, int64(2)).Return(model.Delivery{}, conflict),
+		}},
+		{"failure", 2, model.Delivery{}, domain.Unavailable{Err: errors.Error("Internal DB Error")}, []*gomock.Call{
+			m.EXPECT().DeliveryGetByID(gomock.Any(), 1, int64(2)).Return(failed, nil),
+			m.EXPECT().DeliveryReplay(gomock.Any(), 1, int64(2)).Return(model.Delivery{},
+				errors.Error("Internal DB Error")),
+		}},
+	}
+
+	for i, tc := range testcases {
+		resp, err := s.ReplayDelivery(newContext(), 1, tc.id)
+
+		if !reflect.DeepEqual(tc.output, resp) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.output, resp)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+	}
+}
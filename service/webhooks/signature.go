@@ -0,0 +1,70 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+)
+
+// SignatureHeader carries the signature of a delivery, as "t=<unix seconds>,v1=<hex HMAC-SHA256>". The HMAC is
+// computed with the webhook secret over the timestamp, a dot and the request body.
+const SignatureHeader = "Webhook-Signature"
+
+// Sign returns the SignatureHeader value of body sent at.
+func Sign(secret string, at time.Time, body []byte) string {
+	t := strconv.FormatInt(at.Unix(), 10)
+
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac(secret, t, body))
+}
+
+// Verify checks that signature is a SignatureHeader value of body signed with secret at most tolerance before now,
+// which receivers use to reject forged and replayed requests.
+func Verify(secret, signature string, body []byte, now time.Time, tolerance time.Duration) error {
+	var t, v1 string
+
+	for _, part := range strings.Split(signature, ",") {
+		switch k, v, _ := cut(part, "="); k {
+		case "t":
+			t = v
+		case "v1":
+			v1 = v
+		}
+	}
+
+	sec, err := strconv.ParseInt(t, 10, 64)
+	if err != nil {
+		return errors.Error("malformed webhook signature")
+	}
+
+	if age := now.Sub(time.Unix(sec, 0)); age > tolerance || age < -tolerance {
+		return errors.Error("webhook signature expired")
+	}
+
+	sum, err := hex.DecodeString(v1)
+	if err != nil || !hmac.Equal(sum, mac(secret, t, body)) {
+		return errors.Error("webhook signature mismatch")
+	}
+
+	return nil
+}
+
+func mac(secret, t string, body []byte) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(t + "."))
+	h.Write(body)
+
+	return h.Sum(nil)
+}
+
+func cut(s, sep string) (before, after string, found bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+
+	return s, "", false
+}
@@ -0,0 +1,50 @@
+package webhooks
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+)
+
+func TestSign(t *testing.T) {
+	at := time.Unix(1646128800, 0)
+
+	// HMAC-SHA256 of "1646128800.{}" keyed with secret
+	expected := "t=1646128800,v1=37d268daf4f3a90340429269355a76a2b14f56c8d482d5f011cd073b289328ad"
+
+	if got := Sign(secret, at, []byte("{}")); got != expected {
+		t.Errorf("[Test %v]Failed. Expected %v but got %v", 1, expected, got)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	at := time.Unix(1646128800, 0)
+	body := []byte(`{"id":"e1"}`)
+	signature := Sign(secret, at, body)
+
+	testcases := []struct {
+		desc      string
+		secret    string
+		signature string
+		body      string
+		now       time.Time
+		err       error
+	}{
+		{"valid", secret, signature, `{"id":"e1"}`, at.Add(time.Minute), nil},
+		{"tampered body", secret, signature, `{"id":"e2"}`, at, errors.Error("webhook signature mismatch")},
+		{"other secret", "fedcba9876543210", signature, `{"id":"e1"}`, at, errors.Error("webhook signature mismatch")},
+		{"expired", secret, signature, `{"id":"e1"}`, at.Add(6 * time.Minute), errors.Error("webhook signature expired")},
+		{"malformed", secret, "v1=abc", `{"id":"e1"}`, at, errors.Error("malformed webhook signature")},
+		{"bad digest", secret, "t=1646128800,v1=zz", `{"id":"e1"}`, at, errors.Error("webhook signature mismatch")},
+	}
+
+	for i, tc := range testcases {
+		err := Verify(tc.secret, tc.signature, []byte(tc.body), tc.now, 5*time.Minute)
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package webhooks
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/datastore"
+	"example/model"
+)
+
+// Policy tunes the delivery worker. Batch deliveries are claimed at a time and reserved for Lease, and every request
+// must be answered within Timeout. The nth failed attempt is retried after Backoff doubled n-1 times, but never more
+// than MaxBackoff, and a delivery fails for good once MaxAttempts attempts failed.
+type Policy struct {
+	Batch       int
+	Lease       time.Duration
+	Timeout     time.Duration
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+// Validate checks that the lease outlasts a batch of requests that all time out, the longest the worker can take to
+// record the outcome of a batch. A shorter lease would let another worker claim and send the deliveries again while
+// they are still being attempted.
+func (p Policy) Validate() error {
+	if p.Lease <= time.Duration(p.Batch)*p.Timeout {
+		return errors.Error("the lease of " + p.Lease.String() + " does not outlast a batch of " +
+			strconv.Itoa(p.Batch) + " requests timing out after " + p.Timeout.String())
+	}
+
+	return nil
+}
+
+type worker struct {
+	store  datastore.DeliveryStore
+	client *http.Client
+	policy Policy
+	now    func() time.Time
+}
+
+// NewWorker returns the worker POSTing the deliveries of s to their webhooks, signed with SignatureHeader. A webhook
+// acknowledges a delivery by answering 2xx, any other answer is a failed attempt. Deliveries are only sent to public
+// addresses and redirects are not followed.
+// nolint:revive // worker should not be used without proper initialization with required dependency
+func NewWorker(s datastore.DeliveryStore, policy Policy) worker {
+	return worker{store: s, client: newClient(policy.Timeout), policy: policy, now: time.Now}
+}
+
+// Run sends batches of due deliveries until ctx is done, pausing interval whenever a batch was not full.
+func (w worker) Run(ctx *gofr.Context, interval time.Duration) {
+	for {
+		n, err := w.Work(ctx)
+		if err != nil {
+			ctx.Logger.Errorf("failed to send webhook deliveries: %v", err)
+		}
+
+		wait := interval
+		if err == nil && n == w.policy.Batch {
+			wait = 0
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// Work sends a batch of due deliveries and returns how many it claimed.
+func (w worker) Work(ctx *gofr.Context) (int, error) {
+	deliveries, err := w.store.DeliveryClaim(ctx, w.policy.Batch, w.policy.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range deliveries {
+		w.deliver(ctx, deliveries[i])
+	}
+
+	return len(deliveries), nil
+}
+
+// deliver sends the delivery and records the outcome: succeeded, due for another attempt, or failed.
+func (w worker) deliver(ctx *gofr.Context, d model.Delivery) {
+	status, err := w.send(ctx, d)
+	if err == nil {
+		if err = w.store.DeliverySucceeded(ctx, d.ID, status); err != nil {
+			ctx.Logger.Errorf("failed to record delivery %d as succeeded: %v", d.ID, err)
+		}
+
+		return
+	}
+
+	var code *int
+	if status != 0 {
+		code = &status
+	}
+
+	attempts := d.Attempts + 1
+	if attempts >= w.policy.MaxAttempts {
+		ctx.Logger.Errorf("giving up on delivery %d of event %s to webhook %d after %d attempts: %v", d.ID, d.EventID,
+			d.WebhookID, attempts, err)
+
+		err = w.store.DeliveryFailed(ctx, d.ID, code, err.Error())
+	} else {
+		retry := w.backoff(attempts)
+
+		ctx.Logger.Warnf("failed to deliver event %s to webhook %d, retrying in %v: %v", d.EventID, d.WebhookID, retry,
+			err)
+
+		err = w.store.DeliveryRetry(ctx, d.ID, w.now().Add(retry), code, err.Error())
+	}
+
+	if err != nil {
+		ctx.Logger.Errorf("failed to record the failed attempt of delivery %d: %v", d.ID, err)
+	}
+}
+
+// send POSTs the payload of the delivery to its webhook and returns the status answered, which is 0 when the webhook
+// could not be reached.
+func (w worker) send(ctx *gofr.Context, d model.Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Webhook-Event", string(d.EventType))
+	req.Header.Set("Webhook-Delivery", strconv.FormatInt(d.ID, 10))
+	req.Header.Set(SignatureHeader, Sign(d.Secret, w.now(), d.Payload))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	// drain a bounded part of the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, errors.Error("webhook answered " + resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// backoff returns how long to wait before the next attempt of a delivery that failed attempts times.
+func (w worker) backoff(attempts int) time.Duration {
+	d := w.policy.Backoff
+
+	for i := 1; i < attempts && d < w.policy.MaxBackoff; i++ {
+		d *= 2
+	}
+
+	if d > w.policy.MaxBackoff {
+		return w.policy.MaxBackoff
+	}
+
+	return d
+}
@@ -0,0 +1,221 @@
+package webhooks
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+
+	"example/datastore/mocks"
+	"example/model"
+)
+
+func TestWorker_Work(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockDeliveryStore(ctrl)
+
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	policy := Policy{Batch: 10, Lease: time.Minute, Timeout: time.Second, MaxAttempts: 3, Backoff: time.Second,
+		MaxBackoff: time.Minute}
+	w := NewWorker(store, policy)
+	w.now = func() time.Time { return now }
+
+	var received []*http.Request
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := Verify(secret, r.Header.Get(SignatureHeader), body, now, time.Minute); err != nil {
+			t.Errorf("Failed. Expected a valid signature but got %v", err)
+		}
+
+		received = append(received, r)
+
+		switch r.URL.Path {
+		case "/fail":
+			rw.WriteHeader(http.StatusInternalServerError)
+			return
+		case "/moved":
+			http.Redirect(rw, r, "/ok", http.StatusFound)
+			return
+		}
+
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// the test server listens on loopback, which the worker refuses to dial
+	w.client.Transport = server.Client().Transport
+
+	delivery := func(id int64, path string, attempts int) model.Delivery {
+		return model.Delivery{ID: id, WebhookID: 1, EventID: "e1", EventType: model.EmployeeCreated, Attempts: attempts,
+			URL: server.URL + path, Secret: secret, Payload: []byte(`{"id":"e1"}`)}
+	}
+
+	status, found := http.StatusInternalServerError, http.StatusFound
+
+	testcases := []struct {
+		desc   string
+		output int
+		err    error
+		mock   []*gomock.Call
+	}{
+		{"succeeded", 1, nil, []*gomock.Call{
+			store.EXPECT().DeliveryClaim(gomock.Any(), 10, time.Minute).Return([]model.Delivery{delivery(1, "/ok", 0)},
+				nil),
+			store.EXPECT().DeliverySucceeded(gomock.Any(), int64(1), http.StatusNoContent).Return(nil),
+		}},
+		{"retried", 1, nil, []*gomock.Call{
+			store.EXPECT().DeliveryClaim(gomock.Any(), 10, time.Minute).Return([]model.Delivery{delivery(2, "/fail", 1)},
+				nil),
+			store.EXPECT().DeliveryRetry(gomock.Any(), int64(2), now.Add(2*time.Second), &status,
+				"webhook answered 500 Internal Server Error").Return(nil),
+		}},
+		{"failed", 1, nil, []*gomock.Call{
+			store.EXPECT().DeliveryClaim(gomock.Any(), 10, time.Minute).Return([]model.Delivery{delivery(3, "/fail", 2)},
+				nil),
+			store.EXPECT().DeliveryFailed(gomock.Any(), int64(3), &status, "webhook answered 500 Internal Server Error").
+				Return(nil),
+		}},
+		{"redirected", 1, nil, []*gomock.Call{
+			store.EXPECT().DeliveryClaim(gomock.Any(), 10, time.Minute).Return([]model.Delivery{delivery(5, "/moved", 0)},
+				nil),
+			store.EXPECT().DeliveryRetry(gomock.Any(), int64(5), now.Add(time.Second), &found, "webhook answered 302 Found").
+				Return(nil),
+		}},
+		{"unreachable", 1, nil, []*gomock.Call{
+			store.EXPECT().DeliveryClaim(gomock.Any(), 10, time.Minute).Return([]model.Delivery{{ID: 4,
+				URL: "http://127.0.0.1:0", Payload: []byte("{}")}}, nil),
+			store.EXPECT().DeliveryRetry(gomock.Any(), int64(4), now.Add(time.Second), nil, gomock.Any()).Return(nil),
+		}},
+		{"claim failure", 0, errors.DB{}, []*gomock.Call{
+			store.EXPECT().DeliveryClaim(gomock.Any(), 10, time.Minute).Return(nil, errors.DB{}),
+		}},
+	}
+
+	for i, tc := range testcases {
+		n, err := w.Work(newContext())
+
+		if n != tc.output {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, n)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+
+	if len(received) != 4 {
+		t.Fatalf("Failed. Expected 4 requests but got %v", len(received))
+	}
+
+	r := received[0]
+	if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" ||
+		r.Header.Get("Webhook-Event") != "EmployeeCreated" || r.Header.Get("Webhook-Delivery") != "1" {
+		t.Errorf("Failed. Unexpected request %v %v", r.Method, r.Header)
+	}
+}
+
+func TestWorker_InternalAddress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockDeliveryStore(ctrl)
+	w := NewWorker(store, Policy{Batch: 10, Lease: time.Minute, Timeout: time.Second, MaxAttempts: 3,
+		Backoff: time.Second, MaxBackoff: time.Minute})
+
+	var reached bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) { reached = true }))
+	defer server.Close()
+
+	var lastError string
+
+	store.EXPECT().DeliveryClaim(gomock.Any(), 10, time.Minute).Return([]model.Delivery{{ID: 1, URL: server.URL,
+		Payload: []byte("{}")}}, nil)
+	store.EXPECT().DeliveryRetry(gomock.Any(), int64(1), gomock.Any(), nil, gomock.Any()).
+		Do(func(_ *gofr.Context, _ int64, _ time.Time, _ *int, msg string) { lastError = msg }).Return(nil)
+
+	if _, err := w.Work(newContext()); err != nil {
+		t.Fatalf("Failed. Expected no error but got %v", err)
+	}
+
+	if reached || !strings.Contains(lastError, "webhook address 127.0.0.1 is not allowed") {
+		t.Errorf("Failed. Expected the loopback address to be refused but got %q", lastError)
+	}
+}
+
+func TestPublicOnly(t *testing.T) {
+	notAllowed := func(host string) error { return errors.Error("webhook address " + host + " is not allowed") }
+
+	testcases := []struct {
+		address string
+		err     error
+	}{
+		{"93.184.216.34:443", nil},
+		{"[2606:2800:220:1:248:1893:25c8:1946]:443", nil},
+		{"127.0.0.1:80", notAllowed("127.0.0.1")},
+		{"[::1]:80", notAllowed("::1")},
+		{"169.254.169.254:80", notAllowed("169.254.169.254")},
+		{"10.0.0.1:80", notAllowed("10.0.0.1")},
+		{"172.16.5.4:80", notAllowed("172.16.5.4")},
+		{"192.168.1.1:80", notAllowed("192.168.1.1")},
+		{"100.64.0.1:80", notAllowed("100.64.0.1")},
+		{"[fd00::1]:80", notAllowed("fd00::1")},
+		{"[fe80::1]:80", notAllowed("fe80::1")},
+		{"0.0.0.0:80", notAllowed("0.0.0.0")},
+		{"224.0.0.1:80", notAllowed("224.0.0.1")},
+	}
+
+	for i, tc := range testcases {
+		if err := publicOnly("tcp", tc.address, nil); !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	testcases := []struct {
+		desc   string
+		policy Policy
+		err    error
+	}{
+		{"lease outlasts the batch", Policy{Batch: 20, Lease: 5 * time.Minute, Timeout: 10 * time.Second}, nil},
+		{"lease as long as the batch", Policy{Batch: 30, Lease: 5 * time.Minute, Timeout: 10 * time.Second},
+			errors.Error("the lease of 5m0s does not outlast a batch of 30 requests timing out after 10s")},
+		{"lease shorter than the batch", Policy{Batch: 20, Lease: time.Minute, Timeout: 10 * time.Second},
+			errors.Error("the lease of 1m0s does not outlast a batch of 20 requests timing out after 10s")},
+	}
+
+	for i, tc := range testcases {
+		if err := tc.policy.Validate(); !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.err, err)
+		}
+	}
+}
+
+func TestWorker_Backoff(t *testing.T) {
+	w := NewWorker(nil, Policy{Backoff: 10 * time.Second, MaxBackoff: time.Minute})
+
+	testcases := []struct {
+		attempts int
+		output   time.Duration
+	}{
+		{1, 10 * time.Second},
+		{2, 20 * time.Second},
+		{3, 40 * time.Second},
+		{4, time.Minute},
+		{30, time.Minute},
+	}
+
+	for i, tc := range testcases {
+		if d := w.backoff(tc.attempts); d != tc.output {
+			t.Errorf("[Test %v]Failed. Expected %v but got %v", i+1, tc.output, d)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package tracing
+
+import (
+	"context"
+	"io"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+)
+
+// Setup installs the global tracer provider exporting the spans of service to exporter: stdout writes them to w,
+// otlp sends them over gRPC to the collector at endpoint and none drops them. The returned function flushes the
+// spans not exported yet and stops the provider.
+func Setup(service, exporter, endpoint string, w io.Writer) (func(context.Context) error, error) {
+	var (
+		exp sdktrace.SpanExporter
+		err error
+	)
+
+	switch exporter {
+	case "stdout":
+		exp, err = stdouttrace.New(stdouttrace.WithWriter(w))
+	case "otlp":
+		exp, err = otlptracegrpc.New(context.Background(), otlptracegrpc.WithEndpoint(endpoint),
+			otlptracegrpc.WithInsecure())
+	case "none":
+		return func(context.Context) error { return nil }, nil
+	default:
+		return nil, errors.Error("unknown tracing exporter " + exporter + ", expected stdout, otlp or none")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(service))))
+
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown, nil
+}
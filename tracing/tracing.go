@@ -0,0 +1,77 @@
+// Package tracing records OpenTelemetry spans for the service and store calls made while handling a request.
+package tracing
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+)
+
+const instrumentation = "example"
+
+// Span is a span started by Start. Its End restores the context the span was started from.
+type Span struct {
+	trace.Span
+	ctx    *gofr.Context
+	parent context.Context
+}
+
+// Start starts the span called name as a child of the span in ctx, and makes it the current span of ctx, so that
+// the calls made with ctx until End are recorded beneath it.
+func Start(ctx *gofr.Context, name string, attrs ...attribute.KeyValue) Span {
+	parent := ctx.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	spanCtx, span := otel.Tracer(instrumentation).Start(parent, name, trace.WithAttributes(attrs...))
+	s := Span{Span: span, ctx: ctx, parent: ctx.Context}
+	ctx.Context = spanCtx
+
+	return s
+}
+
+// End marks the span failed when err is not nil and ends it.
+func (s Span) End(err error) {
+	if err != nil {
+		s.RecordError(err)
+		s.SetStatus(codes.Error, err.Error())
+	}
+
+	s.Span.End()
+	s.ctx.Context = s.parent
+}
+
+// Statement records the sanitized SQL statement run by the current span of ctx.
+func Statement(ctx *gofr.Context, query string) {
+	if ctx.Context == nil {
+		return
+	}
+
+	trace.SpanFromContext(ctx.Context).SetAttributes(semconv.DBSystemPostgreSQL,
+		semconv.DBStatementKey.String(Sanitize(query)))
+}
+
+// nolint:gochecknoglobals // patterns matching the literals removed by Sanitize
+var (
+	stringLiteral  = regexp.MustCompile(`'(?:[^']|'')*'`)
+	numericLiteral = regexp.MustCompile(`([^$\w.])\d+(?:\.\d+)?\b`)
+	whitespace     = regexp.MustCompile(`\s+`)
+)
+
+// Sanitize replaces the string and numeric literals of query with ?, leaving placeholders such as $1 in place, and
+// collapses its whitespace, so that statements can be recorded without leaking the values they were run with.
+func Sanitize(query string) string {
+	query = stringLiteral.ReplaceAllString(query, "?")
+	query = numericLiteral.ReplaceAllString(query, "${1}?")
+
+	return strings.TrimSpace(whitespace.ReplaceAllString(query, " "))
+}
@@ -0,0 +1,98 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"developer.zopsmart.com/go/gofr/pkg/errors"
+	"developer.zopsmart.com/go/gofr/pkg/gofr"
+)
+
+func TestSanitize(t *testing.T) {
+	testcases := []struct {
+		query  string
+		output string
+	}{
+		{"select id from employee where id = $1", "select id from employee where id = $1"},
+		{"select id from employee\n\t\twhere name = 'O''Brien' and age > 21.5",
+			"select id from employee where name = ? and age > ?"},
+		{"select e1.id from employee e1 limit 10", "select e1.id from employee e1 limit ?"},
+	}
+
+	for i, tc := range testcases {
+		if output := Sanitize(tc.query); output != tc.output {
+			t.Errorf("[Test %v]Failed.Expected %q but Got %q", i+1, tc.output, output)
+		}
+	}
+}
+
+func TestStart(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+
+	ctx := gofr.NewContext(nil, nil, gofr.New())
+	ctx.Context = context.Background()
+
+	service := Start(ctx, "EmpService.GetEmpByID", attribute.Int("employee.id", 1))
+	store := Start(ctx, "EmpStore.EmpGetByID")
+	Statement(ctx, "select name from employee where id = $1 and status = 'active'")
+	store.End(errors.EntityNotFound{Entity: "employee", ID: "1"})
+	service.End(nil)
+
+	if ctx.Context != context.Background() {
+		t.Errorf("[Test 1]Failed.Expected the context to be restored but Got %v", ctx.Context)
+	}
+
+	spans := rec.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("[Test 2]Failed.Expected 2 spans but Got %v", len(spans))
+	}
+
+	if spans[0].Parent().SpanID() != spans[1].SpanContext().SpanID() {
+		t.Errorf("[Test 3]Failed.Expected %v to be a child of %v", spans[0].Name(), spans[1].Name())
+	}
+
+	expected := []attribute.KeyValue{attribute.String("db.system", "postgresql"),
+		attribute.String("db.statement", "select name from employee where id = $1 and status = ?")}
+	if !reflect.DeepEqual(expected, spans[0].Attributes()) || spans[0].Status().Code != codes.Error {
+		t.Errorf("[Test 4]Failed.Expected %v and an error status but Got %v %v", expected, spans[0].Attributes(),
+			spans[0].Status())
+	}
+
+	if spans[1].Status().Code != codes.Unset {
+		t.Errorf("[Test 5]Failed.Expected an unset status but Got %v", spans[1].Status())
+	}
+}
+
+func TestSetup(t *testing.T) {
+	testcases := []struct {
+		exporter string
+		err      error
+	}{
+		{"stdout", nil},
+		{"none", nil},
+		{"zipkin", errors.Error("unknown tracing exporter zipkin, expected stdout, otlp or none")},
+	}
+
+	for i, tc := range testcases {
+		shutdown, err := Setup("example", tc.exporter, "", &bytes.Buffer{})
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("[Test %v]Failed.Expected %v but Got %v", i+1, tc.err, err)
+		}
+
+		if err == nil {
+			if err := shutdown(context.Background()); err != nil {
+				t.Errorf("[Test %v]Failed.Expected no error but Got %v", i+1, err)
+			}
+		}
+	}
+}